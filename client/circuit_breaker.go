@@ -0,0 +1,119 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/internal/clock"
+)
+
+// ErrCircuitOpen is returned without sending the request while the client circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState string
+
+const (
+	// CircuitClosed lets every request through.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects every request with ErrCircuitOpen until the cooldown elapses.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a single probe request through to test whether the API recovered.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitBreaker fails requests fast while the API is down. It opens after threshold
+// consecutive failures, rejects requests for the cooldown period, and then half-opens
+// to let one probe through: a successful probe closes the circuit and a failed one opens
+// it again. Only transport errors and retryable responses (5xx and 429) count as failures.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	clock     clock.Clock
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+}
+
+// NewCircuitBreaker creates a closed circuit breaker. A threshold below 1 is treated as 1,
+// opening the circuit on the first failure.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: max(threshold, 1),
+		cooldown:  cooldown,
+		clock:     clock.Real{},
+		state:     CircuitClosed,
+	}
+}
+
+// Allow reports whether a request may be sent, returning ErrCircuitOpen otherwise.
+// Every allowed request must be followed by RecordSuccess, RecordFailure or Release.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen && b.clock.Now().Sub(b.openedAt) >= b.cooldown {
+		b.state = CircuitHalfOpen
+		b.probing = false
+	}
+
+	switch b.state {
+	case CircuitOpen:
+		return ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// RecordSuccess closes the circuit and resets the failure count.
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = CircuitClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// RecordFailure counts a failure, opening the circuit once the threshold is reached
+// or when the half-open probe fails.
+func (b *CircuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.state = CircuitOpen
+		b.openedAt = b.clock.Now()
+		b.probing = false
+	}
+}
+
+// Release ends an allowed request without an outcome, such as one canceled by its
+// caller. The failure count is kept, and a half-open circuit lets another probe through.
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.probing = false
+	}
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen && b.clock.Now().Sub(b.openedAt) >= b.cooldown {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
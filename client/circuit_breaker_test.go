@@ -0,0 +1,99 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/internal/clock"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	breaker := NewCircuitBreaker(3, time.Minute)
+	breaker.clock = fake
+
+	for range 2 {
+		if err := breaker.Allow(); err != nil {
+			t.Fatalf("Expected closed circuit to allow requests, got %v", err)
+		}
+		breaker.RecordFailure()
+	}
+
+	// A success resets the consecutive failure count
+	breaker.RecordSuccess()
+	for range 3 {
+		if err := breaker.Allow(); err != nil {
+			t.Fatalf("Expected closed circuit to allow requests, got %v", err)
+		}
+		breaker.RecordFailure()
+	}
+
+	if state := breaker.State(); state != CircuitOpen {
+		t.Fatalf("Expected circuit to be open, got %s", state)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+
+	fake.Sleep(t.Context(), time.Minute)
+	if state := breaker.State(); state != CircuitHalfOpen {
+		t.Fatalf("Expected circuit to be half-open after the cooldown, got %s", state)
+	}
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("Expected a probe to be allowed, got %v", err)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected a single probe while half-open, got %v", err)
+	}
+
+	// A failed probe opens the circuit again
+	breaker.RecordFailure()
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen after a failed probe, got %v", err)
+	}
+
+	fake.Sleep(t.Context(), time.Minute)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("Expected a probe to be allowed, got %v", err)
+	}
+	breaker.RecordSuccess()
+	if state := breaker.State(); state != CircuitClosed {
+		t.Errorf("Expected circuit to close after a successful probe, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_Release(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.clock = fake
+
+	breaker.Allow()
+	breaker.RecordFailure()
+	fake.Sleep(t.Context(), time.Minute)
+
+	// A released probe lets the next request probe instead of blocking the circuit
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("Expected a probe to be allowed, got %v", err)
+	}
+	breaker.Release()
+	if state := breaker.State(); state != CircuitHalfOpen {
+		t.Errorf("Expected circuit to stay half-open after a release, got %s", state)
+	}
+	if err := breaker.Allow(); err != nil {
+		t.Errorf("Expected another probe after a release, got %v", err)
+	}
+}
+
+func TestNewCircuitBreaker_Threshold(t *testing.T) {
+	for _, threshold := range []int{0, -1} {
+		breaker := NewCircuitBreaker(threshold, time.Minute)
+		if err := breaker.Allow(); err != nil {
+			t.Fatalf("NewCircuitBreaker(%d) rejected the first request: %v", threshold, err)
+		}
+		breaker.RecordFailure()
+		if state := breaker.State(); state != CircuitOpen {
+			t.Errorf("NewCircuitBreaker(%d) state after one failure = %s, want open", threshold, state)
+		}
+	}
+}
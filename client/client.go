@@ -3,6 +3,7 @@
 package client
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"time"
@@ -40,6 +41,7 @@ func NewMgcClient(opts ...Option) *CoreClient {
 		BaseURL:     BrSe1,
 		Timeout:     DefaultTimeout,
 		ContentType: "application/json",
+		RateLimit:   NewRateLimitTracker(),
 		RetryConfig: RetryConfig{
 			MaxAttempts:     DefaultMaxAttempts,
 			InitialInterval: DefaultInitialInterval,
@@ -58,6 +60,28 @@ func NewMgcClient(opts ...Option) *CoreClient {
 	return &CoreClient{config: *cfg}
 }
 
+// RateLimitStatus returns the rate-limit quota reported by the latest API response
+// carrying the X-RateLimit-Remaining header: the requests remaining and when the quota
+// resets, which is zero when the API did not report it. ok is false until such a
+// response is received. Callers can use it to slow down before being rate limited.
+func (c *CoreClient) RateLimitStatus() (remaining int, reset time.Time, ok bool) {
+	if c.config.RateLimit == nil {
+		return 0, time.Time{}, false
+	}
+	return c.config.RateLimit.Status()
+}
+
+// Regions returns the regions available to the client with their service endpoints.
+// The MagaluCloud API does not offer a region discovery endpoint, so this returns the
+// registry built into the SDK, versioned by RegionRegistryVersion; ctx is honored so
+// callers keep working unchanged if the regions are later discovered at runtime.
+func (c *CoreClient) Regions(ctx context.Context) ([]RegionInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return KnownRegions(), nil
+}
+
 // GetConfig returns a pointer to the client's configuration.
 // This method allows access to the current configuration for inspection or modification.
 func (c *CoreClient) GetConfig() *Config {
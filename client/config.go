@@ -2,9 +2,11 @@ package client
 
 import (
 	"log/slog"
+	"maps"
 	"net/http"
 	"strings"
 	"time"
+
 )
 
 // RetryConfig contains configuration for retry behavior.
@@ -27,8 +29,47 @@ type Config struct {
 	RetryConfig   RetryConfig
 	ContentType   string
 	CustomHeaders map[string]string
+
+	// DefaultHeaders are added to every request unless the request already sets them,
+	// so they never override headers set by the SDK or CustomHeaders.
+	DefaultHeaders map[string]string
+
+	// UserAgentSuffix identifies the application using the SDK. It is appended to
+	// UserAgent by every service client created from the same CoreClient.
+	UserAgentSuffix string
+
+	// ExpectedAPIVersion, when set, must match the APIVersionHeader of every successful
+	// response that reports one.
+	ExpectedAPIVersion string
+
+	// StrictDecoding rejects JSON responses with fields the SDK models do not declare.
+	StrictDecoding bool
+
+	RequestInterceptors  []RequestInterceptor
+	ResponseInterceptors []ResponseInterceptor
+	Metrics              MetricsRecorder
+	RetryBudget          *RetryBudget
+	CircuitBreaker       *CircuitBreaker
+	ResponseCache        *ResponseCache
+
+	// RateLimit records the quota reported by the rate-limit headers of responses.
+	RateLimit *RateLimitTracker
+
+	// RequestCompression, when set, gzip-compresses large request bodies.
+	RequestCompression *RequestCompression
 }
 
+// APIVersionHeader is the response header that reports the API version.
+const APIVersionHeader = "X-API-Version"
+
+// RequestInterceptor is called with every outgoing request before it is sent.
+// Returning an error aborts the request.
+type RequestInterceptor func(*http.Request) error
+
+// ResponseInterceptor is called with every response received before it is processed.
+// Returning an error aborts the request.
+type ResponseInterceptor func(*http.Response) error
+
 // Option is a function type that modifies the client configuration.
 // Options are used to customize the client behavior during initialization.
 type Option func(*Config)
@@ -69,6 +110,22 @@ func WithUserAgent(ua string) Option {
 	}
 }
 
+// WithUserAgentSuffix appends an application identifier, such as "my-app/1.2.0",
+// to the user agent of every service client built from the core client.
+func WithUserAgentSuffix(suffix string) Option {
+	return func(c *Config) {
+		c.UserAgentSuffix = suffix
+	}
+}
+
+// FullUserAgent returns the user agent followed by the configured suffix, if any.
+func (c *Config) FullUserAgent() string {
+	if c.UserAgentSuffix == "" {
+		return c.UserAgent
+	}
+	return c.UserAgent + " " + c.UserAgentSuffix
+}
+
 // WithLogger sets the logger instance for client operations.
 // This option allows customizing logging behavior.
 func WithLogger(logger *slog.Logger) Option {
@@ -106,6 +163,46 @@ func WithRetryConfig(maxAttempts int, initialInterval, maxInterval time.Duration
 	}
 }
 
+// WithRetryBudget shares a retry budget across every request of the client, so that under
+// sustained failures retries stop globally instead of each call retrying independently.
+// Each retry consumes one token and each request that does not need a retry refills ratio tokens.
+func WithRetryBudget(ratio float64) Option {
+	return func(c *Config) {
+		c.RetryBudget = NewRetryBudget(ratio)
+	}
+}
+
+// WithCircuitBreaker makes the client fail fast with ErrCircuitOpen after threshold
+// consecutive transport errors or retryable responses, for the cooldown period, after
+// which a single request probes whether the API recovered.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Config) {
+		c.CircuitBreaker = NewCircuitBreaker(threshold, cooldown)
+	}
+}
+
+// WithResponseCache keeps the ETag and Last-Modified validators of up to size GET
+// responses, so that repeating a request sends If-None-Match or If-Modified-Since and a
+// 304 Not Modified answer is decoded from the stored body instead of downloading it again.
+// Responses are keyed by URL and request headers. Responses without validators, with a
+// Vary header naming headers outside the key, or with a body over MaxCachedResponseBytes
+// are not stored.
+func WithResponseCache(size int) Option {
+	return func(c *Config) {
+		c.ResponseCache = NewResponseCache(size)
+	}
+}
+
+// WithRequestCompression gzip-compresses JSON request bodies larger than minBytes and
+// sends them with Content-Encoding: gzip. Compression only starts after a response has
+// advertised gzip in its Accept-Encoding header, and stops if a later one no longer does,
+// so servers without support for compressed requests do not answer with 400 or 415.
+func WithRequestCompression(minBytes int) Option {
+	return func(c *Config) {
+		c.RequestCompression = NewRequestCompression(minBytes)
+	}
+}
+
 // WithCustomHeader adds a custom HTTP header to all requests.
 // This option allows adding additional headers for specific requirements.
 func WithCustomHeader(key, value string) Option {
@@ -116,3 +213,63 @@ func WithCustomHeader(key, value string) Option {
 		c.CustomHeaders[key] = value
 	}
 }
+
+// WithDefaultHeaders adds headers, such as a tenant ID or feature flags, to every request
+// made by the compute services and by object storage. Unlike WithCustomHeader, they never
+// override a header the request already has, like Authorization or Content-Type.
+// Calling it more than once merges the headers.
+func WithDefaultHeaders(headers map[string]string) Option {
+	return func(c *Config) {
+		if c.DefaultHeaders == nil {
+			c.DefaultHeaders = make(map[string]string, len(headers))
+		}
+		maps.Copy(c.DefaultHeaders, headers)
+	}
+}
+
+// WithStrictDecoding makes JSON responses with fields unknown to the SDK models fail
+// to decode instead of ignoring those fields. It is meant to detect API drift, for
+// example in CI against a staging API. Do not enable it in production: any field the
+// server adds, or any difference between server versions, breaks the calls.
+func WithStrictDecoding() Option {
+	return func(c *Config) {
+		c.StrictDecoding = true
+	}
+}
+
+// WithExpectedAPIVersion pins the client to an API version. Successful responses whose
+// APIVersionHeader differs from version fail with an *APIVersionError instead of being
+// decoded, so a version bump never changes behavior silently. Responses without the
+// header report no version and are accepted.
+func WithExpectedAPIVersion(version string) Option {
+	return func(c *Config) {
+		c.ExpectedAPIVersion = version
+	}
+}
+
+// WithMetrics sets the recorder that receives an observation for every HTTP attempt.
+// This option keeps the SDK free of a metrics dependency: adapt MetricsRecorder to
+// Prometheus, OpenTelemetry or any other backend in your application.
+func WithMetrics(recorder MetricsRecorder) Option {
+	return func(c *Config) {
+		c.Metrics = recorder
+	}
+}
+
+// WithRequestInterceptor registers a function that is called with every outgoing request,
+// including retries, before it is sent. Interceptors run in registration order and may
+// mutate the request. An interceptor error aborts the request without sending it.
+func WithRequestInterceptor(interceptor RequestInterceptor) Option {
+	return func(c *Config) {
+		c.RequestInterceptors = append(c.RequestInterceptors, interceptor)
+	}
+}
+
+// WithResponseInterceptor registers a function that is called with every response received,
+// before its status is checked or its body is decoded. Interceptors run in registration order.
+// An interceptor error aborts the request and is returned to the caller without retrying.
+func WithResponseInterceptor(interceptor ResponseInterceptor) Option {
+	return func(c *Config) {
+		c.ResponseInterceptors = append(c.ResponseInterceptors, interceptor)
+	}
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"log/slog"
+	"maps"
 	"net/http"
 	"os"
 	"testing"
@@ -63,6 +64,23 @@ func TestWithUserAgent(t *testing.T) {
 	}
 }
 
+func TestWithUserAgentSuffix(t *testing.T) {
+	config := &Config{UserAgent: DefaultUserAgent}
+
+	if got := config.FullUserAgent(); got != DefaultUserAgent {
+		t.Errorf("Expected FullUserAgent without suffix to be %s, got %s", DefaultUserAgent, got)
+	}
+
+	WithUserAgentSuffix("my-app/1.2.0")(config)
+
+	if config.UserAgentSuffix != "my-app/1.2.0" {
+		t.Errorf("Expected UserAgentSuffix to be my-app/1.2.0, got %s", config.UserAgentSuffix)
+	}
+	if got := config.FullUserAgent(); got != DefaultUserAgent+" my-app/1.2.0" {
+		t.Errorf("Expected FullUserAgent to be %s my-app/1.2.0, got %s", DefaultUserAgent, got)
+	}
+}
+
 func TestWithLogger(t *testing.T) {
 	config := &Config{}
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -294,6 +312,32 @@ func TestOverrideRetryConfig(t *testing.T) {
 	}
 }
 
+func TestWithRetryBudget(t *testing.T) {
+	config := &Config{}
+
+	WithRetryBudget(0.1)(config)
+
+	if config.RetryBudget == nil {
+		t.Fatal("Expected RetryBudget to be set")
+	}
+	if got := config.RetryBudget.Tokens(); got != DefaultRetryBudgetTokens {
+		t.Errorf("Expected a full budget of %d tokens, got %v", DefaultRetryBudgetTokens, got)
+	}
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	config := &Config{}
+
+	WithCircuitBreaker(5, time.Minute)(config)
+
+	if config.CircuitBreaker == nil {
+		t.Fatal("Expected CircuitBreaker to be set")
+	}
+	if state := config.CircuitBreaker.State(); state != CircuitClosed {
+		t.Errorf("Expected a closed circuit, got %s", state)
+	}
+}
+
 func TestWithCustomHeader(t *testing.T) {
 	config := &Config{}
 	WithCustomHeader("X-Custom-Header", "custom-value")(config)
@@ -312,3 +356,87 @@ func TestWithCustomHeader(t *testing.T) {
 			len(config.CustomHeaders), 1)
 	}
 }
+
+func TestWithDefaultHeaders(t *testing.T) {
+	config := &Config{}
+	headers := map[string]string{"X-Tenant-ID": "tenant-1"}
+	WithDefaultHeaders(headers)(config)
+	WithDefaultHeaders(map[string]string{"X-Feature": "beta"})(config)
+
+	want := map[string]string{"X-Tenant-ID": "tenant-1", "X-Feature": "beta"}
+	if !maps.Equal(config.DefaultHeaders, want) {
+		t.Errorf("DefaultHeaders = %v, want %v", config.DefaultHeaders, want)
+	}
+
+	headers["X-Tenant-ID"] = "changed"
+	if config.DefaultHeaders["X-Tenant-ID"] != "tenant-1" {
+		t.Error("DefaultHeaders changed with the caller's map")
+	}
+}
+
+func TestWithInterceptors(t *testing.T) {
+	config := &Config{}
+	var calls []string
+
+	WithRequestInterceptor(func(*http.Request) error {
+		calls = append(calls, "req1")
+		return nil
+	})(config)
+	WithRequestInterceptor(func(*http.Request) error {
+		calls = append(calls, "req2")
+		return nil
+	})(config)
+	WithResponseInterceptor(func(*http.Response) error {
+		calls = append(calls, "resp")
+		return nil
+	})(config)
+
+	if len(config.RequestInterceptors) != 2 {
+		t.Fatalf("Expected 2 request interceptors, got %d", len(config.RequestInterceptors))
+	}
+	if len(config.ResponseInterceptors) != 1 {
+		t.Fatalf("Expected 1 response interceptor, got %d", len(config.ResponseInterceptors))
+	}
+
+	for _, intercept := range config.RequestInterceptors {
+		intercept(nil)
+	}
+	if len(calls) != 2 || calls[0] != "req1" || calls[1] != "req2" {
+		t.Errorf("Expected interceptors in registration order, got %v", calls)
+	}
+}
+
+func TestWithMetrics(t *testing.T) {
+	config := &Config{}
+	recorder := &nopRecorder{}
+
+	WithMetrics(recorder)(config)
+
+	if config.Metrics != recorder {
+		t.Errorf("Expected Metrics to be %v, got %v", recorder, config.Metrics)
+	}
+}
+
+func TestWithStrictDecoding(t *testing.T) {
+	config := &Config{}
+
+	WithStrictDecoding()(config)
+
+	if !config.StrictDecoding {
+		t.Error("Expected StrictDecoding to be true")
+	}
+}
+
+func TestWithExpectedAPIVersion(t *testing.T) {
+	config := &Config{}
+
+	WithExpectedAPIVersion("v1")(config)
+
+	if config.ExpectedAPIVersion != "v1" {
+		t.Errorf("Expected ExpectedAPIVersion to be v1, got %s", config.ExpectedAPIVersion)
+	}
+}
+
+type nopRecorder struct{}
+
+func (*nopRecorder) ObserveRequest(string, string, string, time.Duration) {}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the header carrying the request ID of every SDK request.
+const RequestIDHeader = "X-Request-ID"
+
+// WithRequestID returns a copy of ctx carrying the given request ID, which is sent
+// as the X-Request-ID header of every request made with the returned context.
+// The ID must be a valid UUIDv4 string. Use it to correlate SDK calls with your own traces.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(RequestIDKey).(string)
+	return id, ok && id != ""
+}
+
+// NewRequestID generates a random request ID, used when the context carries none.
+func NewRequestID() string {
+	return uuid.NewString()
+}
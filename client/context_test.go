@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithRequestID(t *testing.T) {
+	if _, ok := RequestIDFromContext(context.Background()); ok {
+		t.Error("Expected no request ID in an empty context")
+	}
+
+	ctx := WithRequestID(context.Background(), "trace-123")
+	id, ok := RequestIDFromContext(ctx)
+	if !ok || id != "trace-123" {
+		t.Errorf("Expected request ID trace-123, got %q", id)
+	}
+
+	if _, ok := RequestIDFromContext(WithRequestID(context.Background(), "")); ok {
+		t.Error("Expected an empty request ID to be ignored")
+	}
+}
+
+func TestNewRequestID(t *testing.T) {
+	first, second := NewRequestID(), NewRequestID()
+	if len(first) != 36 {
+		t.Errorf("Expected a UUID, got %q", first)
+	}
+	if first == second {
+		t.Error("Expected distinct request IDs")
+	}
+}
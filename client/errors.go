@@ -1,11 +1,18 @@
 package client
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 )
 
+// ErrEmptyResponse is returned when a successful response has no body but one
+// was expected to be decoded. Responses with status 204 No Content are not decoded,
+// so they never return it.
+var ErrEmptyResponse = errors.New("empty response body")
+
 // HTTPError represents an error that occurred during an HTTP request.
 // This error type includes the HTTP status code, status message, and response body.
 type HTTPError struct {
@@ -46,6 +53,41 @@ func (e *ValidationError) Error() string {
 	return fmt.Sprintf("validation error: %s - %s", e.Field, e.Message)
 }
 
+// ValidationErrors holds every failure found while validating a request, so they can be
+// reported at once. errors.As finds each *ValidationError it holds.
+type ValidationErrors []ValidationError
+
+// Error returns the validation errors joined by "; ".
+// This method implements the error interface.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i := range e {
+		messages[i] = e[i].Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns each validation error, for errors.Is and errors.As.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i := range e {
+		errs[i] = &e[i]
+	}
+	return errs
+}
+
+// Err returns e as an error, or nil when it holds no failure. A single failure is
+// returned as its *ValidationError, so callers asserting that type keep working.
+func (e ValidationErrors) Err() error {
+	switch len(e) {
+	case 0:
+		return nil
+	case 1:
+		return &e[0]
+	}
+	return e
+}
+
 // RetryError represents an error that occurred after exhausting all retry attempts.
 // This error type includes the last error encountered and the number of retries attempted.
 type RetryError struct {
@@ -58,3 +100,24 @@ type RetryError struct {
 func (e *RetryError) Error() string {
 	return fmt.Sprintf("max retry attempts reached: %v", e.LastError)
 }
+
+// ErrAPIVersionMismatch is matched by APIVersionError with errors.Is.
+var ErrAPIVersionMismatch = errors.New("API version mismatch")
+
+// APIVersionError is returned when WithExpectedAPIVersion is set and a successful
+// response reports a different version in the APIVersionHeader header.
+type APIVersionError struct {
+	Expected string
+	Actual   string
+}
+
+// Error returns a string representation of the API version error.
+// This method implements the error interface.
+func (e *APIVersionError) Error() string {
+	return fmt.Sprintf("%s: expected %q, got %q", ErrAPIVersionMismatch, e.Expected, e.Actual)
+}
+
+// Unwrap returns ErrAPIVersionMismatch.
+func (e *APIVersionError) Unwrap() error {
+	return ErrAPIVersionMismatch
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -135,6 +136,35 @@ func TestValidationError_Error(t *testing.T) {
 	}
 }
 
+func TestValidationErrors(t *testing.T) {
+	errs := ValidationErrors{
+		{Field: "name", Message: "cannot be empty"},
+		{Field: "url", Message: "must be a valid HTTPS URL"},
+	}
+
+	want := "validation error: name - cannot be empty; validation error: url - must be a valid HTTPS URL"
+	if got := errs.Error(); got != want {
+		t.Errorf("ValidationErrors.Error() = %v, want %v", got, want)
+	}
+
+	err := fmt.Errorf("create: %w", errs.Err())
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "name" {
+		t.Errorf("errors.As() = %v, want the name ValidationError", validationErr)
+	}
+	var all ValidationErrors
+	if !errors.As(err, &all) || len(all) != 2 {
+		t.Errorf("errors.As() = %v, want both validation errors", all)
+	}
+
+	if err := (ValidationErrors{}).Err(); err != nil {
+		t.Errorf("ValidationErrors{}.Err() = %v, want nil", err)
+	}
+	if err, ok := errs[:1].Err().(*ValidationError); !ok || err.Field != "name" {
+		t.Errorf("Err() with one failure = %v, want the name *ValidationError", err)
+	}
+}
+
 func TestRetryError_Error(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -174,3 +204,14 @@ func TestRetryError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIVersionError(t *testing.T) {
+	var err error = &APIVersionError{Expected: "v1", Actual: "v2"}
+
+	if got, want := err.Error(), `API version mismatch: expected "v1", got "v2"`; got != want {
+		t.Errorf("APIVersionError.Error() = %v, want %v", got, want)
+	}
+	if !errors.Is(err, ErrAPIVersionMismatch) {
+		t.Error("APIVersionError does not match ErrAPIVersionMismatch")
+	}
+}
@@ -0,0 +1,47 @@
+package client
+
+import "time"
+
+// Status classes reported to a MetricsRecorder.
+const (
+	StatusClass2xx   = "2xx"
+	StatusClass3xx   = "3xx"
+	StatusClass4xx   = "4xx"
+	StatusClass5xx   = "5xx"
+	StatusClassError = "error"
+)
+
+// MetricsRecorder receives one observation per HTTP attempt made by the SDK, retries included.
+// Service is the first path segment after the base URL (e.g. "compute"), statusClass is one of
+// the StatusClass constants, StatusClassError meaning no response was received, and duration
+// is the time spent waiting for the response headers.
+//
+// Implementations must be safe for concurrent use.
+type MetricsRecorder interface {
+	ObserveRequest(service, method, statusClass string, duration time.Duration)
+}
+
+// OperationRecorder is implemented by a MetricsRecorder that also receives the operations
+// of services that do not go through the SDK HTTP client, such as object storage. Each
+// observation is labeled by operation (e.g. "PutObject") instead of HTTP method, and
+// duration covers the whole operation. Recorders that do not implement it receive no
+// observations for these services.
+type OperationRecorder interface {
+	ObserveOperation(service, operation, statusClass string, duration time.Duration)
+}
+
+// StatusClass returns the status class label for an HTTP status code.
+func StatusClass(statusCode int) string {
+	switch {
+	case statusCode >= 200 && statusCode < 300:
+		return StatusClass2xx
+	case statusCode >= 300 && statusCode < 400:
+		return StatusClass3xx
+	case statusCode >= 400 && statusCode < 500:
+		return StatusClass4xx
+	case statusCode >= 500 && statusCode < 600:
+		return StatusClass5xx
+	default:
+		return StatusClassError
+	}
+}
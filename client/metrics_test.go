@@ -0,0 +1,25 @@
+package client
+
+import "testing"
+
+func TestStatusClass(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       string
+	}{
+		{200, StatusClass2xx},
+		{204, StatusClass2xx},
+		{304, StatusClass3xx},
+		{404, StatusClass4xx},
+		{429, StatusClass4xx},
+		{503, StatusClass5xx},
+		{0, StatusClassError},
+		{999, StatusClassError},
+	}
+
+	for _, tt := range tests {
+		if got := StatusClass(tt.statusCode); got != tt.want {
+			t.Errorf("StatusClass(%d) = %q, want %q", tt.statusCode, got, tt.want)
+		}
+	}
+}
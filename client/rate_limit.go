@@ -0,0 +1,68 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Rate-limit headers reported by the API.
+const (
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// resetEpochThreshold separates X-RateLimit-Reset values that are Unix timestamps from
+// values that are a number of seconds until the reset.
+const resetEpochThreshold = 1_000_000_000
+
+// RateLimitTracker keeps the rate-limit quota reported by the latest response carrying
+// RateLimitRemainingHeader. It is shared by every request of a client and is safe for
+// concurrent use.
+type RateLimitTracker struct {
+	mu        sync.Mutex
+	remaining int
+	reset     time.Time
+	ok        bool
+}
+
+// NewRateLimitTracker creates a tracker that has not seen any quota yet.
+func NewRateLimitTracker() *RateLimitTracker {
+	return &RateLimitTracker{}
+}
+
+// Observe records the quota reported by the headers of a response received at now.
+// Responses without a valid RateLimitRemainingHeader are ignored. RateLimitResetHeader
+// is read as a Unix timestamp when it is large enough to be one, and otherwise as a
+// number of seconds after now.
+func (t *RateLimitTracker) Observe(header http.Header, now time.Time) {
+	remaining, err := strconv.Atoi(header.Get(RateLimitRemainingHeader))
+	if err != nil {
+		return
+	}
+
+	var reset time.Time
+	if seconds, err := strconv.ParseInt(header.Get(RateLimitResetHeader), 10, 64); err == nil {
+		if seconds >= resetEpochThreshold {
+			reset = time.Unix(seconds, 0)
+		} else {
+			reset = now.Add(time.Duration(seconds) * time.Second)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.remaining, t.reset, t.ok = remaining, reset, true
+}
+
+// Status returns the latest quota: the requests remaining and when the quota resets,
+// which is zero when the API did not report it. ok is false until a response has
+// reported the quota.
+func (t *RateLimitTracker) Status() (remaining int, reset time.Time, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.remaining, t.reset, t.ok
+}
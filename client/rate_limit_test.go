@@ -0,0 +1,76 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTracker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		remaining     string
+		reset         string
+		wantRemaining int
+		wantReset     time.Time
+		wantOK        bool
+	}{
+		{name: "no headers"},
+		{name: "invalid remaining", remaining: "many", reset: "30"},
+		{name: "remaining only", remaining: "42", wantRemaining: 42, wantOK: true},
+		{name: "reset in seconds", remaining: "10", reset: "30", wantRemaining: 10, wantReset: now.Add(30 * time.Second), wantOK: true},
+		{name: "reset as unix time", remaining: "0", reset: "1735736400", wantRemaining: 0, wantReset: time.Unix(1735736400, 0), wantOK: true},
+		{name: "invalid reset", remaining: "5", reset: "soon", wantRemaining: 5, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.remaining != "" {
+				header.Set(RateLimitRemainingHeader, tt.remaining)
+			}
+			if tt.reset != "" {
+				header.Set(RateLimitResetHeader, tt.reset)
+			}
+
+			tracker := NewRateLimitTracker()
+			tracker.Observe(header, now)
+
+			remaining, reset, ok := tracker.Status()
+			if remaining != tt.wantRemaining || !reset.Equal(tt.wantReset) || ok != tt.wantOK {
+				t.Errorf("Status() = %d, %v, %v; want %d, %v, %v", remaining, reset, ok, tt.wantRemaining, tt.wantReset, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRateLimitTracker_KeepsLatestQuota(t *testing.T) {
+	tracker := NewRateLimitTracker()
+	tracker.Observe(remainingHeader("10"), time.Now())
+	tracker.Observe(http.Header{}, time.Now())
+	tracker.Observe(remainingHeader("9"), time.Now())
+
+	if remaining, _, _ := tracker.Status(); remaining != 9 {
+		t.Errorf("Expected the latest remaining quota 9, got %d", remaining)
+	}
+}
+
+func TestCoreClient_RateLimitStatus(t *testing.T) {
+	c := NewMgcClient()
+	if _, _, ok := c.RateLimitStatus(); ok {
+		t.Error("Expected no quota before any response")
+	}
+
+	c.GetConfig().RateLimit.Observe(remainingHeader("3"), time.Now())
+	if remaining, _, ok := c.RateLimitStatus(); !ok || remaining != 3 {
+		t.Errorf("RateLimitStatus() = %d, %v; want 3, true", remaining, ok)
+	}
+}
+
+func remainingHeader(remaining string) http.Header {
+	header := http.Header{}
+	header.Set(RateLimitRemainingHeader, remaining)
+	return header
+}
@@ -1,5 +1,7 @@
 package client
 
+import "maps"
+
 // MgcUrl represents a MagaluCloud API URL.
 // This type is used to ensure type safety when working with API endpoints.
 type MgcUrl string
@@ -21,3 +23,86 @@ const (
 func (m MgcUrl) String() string {
 	return string(m)
 }
+
+// Region identifiers shared by the regional services, such as compute and object storage.
+const (
+	// RegionBrSe1 identifies the Brazil Southeast 1 region
+	RegionBrSe1 = "br-se1"
+	// RegionBrNe1 identifies the Brazil Northeast 1 region
+	RegionBrNe1 = "br-ne1"
+	// RegionBrMgl1 identifies the Brazil Magalu region
+	RegionBrMgl1 = "br-se-1"
+)
+
+// Services listed in RegionInfo.Endpoints.
+const (
+	// ServiceAPI is the MagaluCloud API, used by compute and the other HTTP services
+	ServiceAPI = "api"
+	// ServiceObjectStorage is the S3-compatible MagaluObjects endpoint
+	ServiceObjectStorage = "objectstorage"
+)
+
+// RegionRegistryVersion identifies the contents of the region registry returned by
+// KnownRegions. It is incremented whenever a region or service endpoint is added,
+// removed or changed, so tooling can tell which registry it was built against.
+const RegionRegistryVersion = 1
+
+// RegionInfo describes a MagaluCloud region.
+type RegionInfo struct {
+	// Code is the region identifier, such as RegionBrSe1.
+	Code string
+	// DisplayName is the human-readable name of the region.
+	DisplayName string
+	// Endpoints maps service names, such as ServiceAPI, to their URL in the region.
+	// Services not available in the region are absent.
+	Endpoints map[string]string
+}
+
+// regions is the registry of known regions, in order of preference.
+var regions = []RegionInfo{
+	{
+		Code:        RegionBrSe1,
+		DisplayName: "Brazil Southeast 1",
+		Endpoints: map[string]string{
+			ServiceAPI:           BrSe1.String(),
+			ServiceObjectStorage: "https://br-se1.magaluobjects.com",
+		},
+	},
+	{
+		Code:        RegionBrNe1,
+		DisplayName: "Brazil Northeast 1",
+		Endpoints: map[string]string{
+			ServiceAPI:           BrNe1.String(),
+			ServiceObjectStorage: "https://br-ne1.magaluobjects.com",
+		},
+	},
+	{
+		Code:        RegionBrMgl1,
+		DisplayName: "Brazil Magalu",
+		Endpoints: map[string]string{
+			ServiceAPI: BrMgl1.String(),
+		},
+	},
+}
+
+// KnownRegions returns the regions known to this version of the SDK, identified by
+// RegionRegistryVersion. The result is a copy and may be modified by the caller.
+func KnownRegions() []RegionInfo {
+	result := make([]RegionInfo, len(regions))
+	for i, r := range regions {
+		r.Endpoints = maps.Clone(r.Endpoints)
+		result[i] = r
+	}
+	return result
+}
+
+// URLForRegion returns the API URL of a region identifier, such as BrSe1 for "br-se1".
+// The region is the last path segment of the URL. It returns false for unknown regions.
+func URLForRegion(region string) (MgcUrl, bool) {
+	for _, r := range regions {
+		if r.Code == region {
+			return MgcUrl(r.Endpoints[ServiceAPI]), true
+		}
+	}
+	return "", false
+}
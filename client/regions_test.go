@@ -1,6 +1,11 @@
 package client
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
 
 func TestMgcUrl_String(t *testing.T) {
 	tests := []struct {
@@ -53,3 +58,68 @@ func TestRegionConstants(t *testing.T) {
 		t.Errorf("BrSe1 constant has unexpected value: %s", BrSe1)
 	}
 }
+
+func TestURLForRegion(t *testing.T) {
+	tests := []struct {
+		region string
+		want   MgcUrl
+		wantOk bool
+	}{
+		{region: RegionBrSe1, want: BrSe1, wantOk: true},
+		{region: RegionBrNe1, want: BrNe1, wantOk: true},
+		{region: RegionBrMgl1, want: BrMgl1, wantOk: true},
+		{region: "us-east-1", want: "", wantOk: false},
+		{region: "", want: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			got, ok := URLForRegion(tt.region)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("URLForRegion(%q) = %v, %v, want %v, %v", tt.region, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestKnownRegions(t *testing.T) {
+	regions := KnownRegions()
+
+	codes := make([]string, len(regions))
+	for i, r := range regions {
+		codes[i] = r.Code
+		if r.DisplayName == "" {
+			t.Errorf("region %q has no display name", r.Code)
+		}
+		if url, ok := URLForRegion(r.Code); !ok || url.String() != r.Endpoints[ServiceAPI] {
+			t.Errorf("URLForRegion(%q) = %v, %v, want %v", r.Code, url, ok, r.Endpoints[ServiceAPI])
+		}
+	}
+	want := []string{RegionBrSe1, RegionBrNe1, RegionBrMgl1}
+	if !slices.Equal(codes, want) {
+		t.Errorf("KnownRegions() codes = %v, want %v", codes, want)
+	}
+
+	regions[0].Endpoints[ServiceAPI] = "https://modified.example"
+	if url, _ := URLForRegion(RegionBrSe1); url != BrSe1 {
+		t.Errorf("Modifying the result of KnownRegions changed the registry: %v", url)
+	}
+}
+
+func TestCoreClient_Regions(t *testing.T) {
+	c := NewMgcClient()
+
+	regions, err := c.Regions(context.Background())
+	if err != nil {
+		t.Fatalf("Regions() error = %v", err)
+	}
+	if len(regions) != len(KnownRegions()) {
+		t.Errorf("Regions() returned %d regions, want %d", len(regions), len(KnownRegions()))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Regions(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Regions() with canceled context error = %v, want context.Canceled", err)
+	}
+}
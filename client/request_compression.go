@@ -0,0 +1,57 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// RequestCompression decides whether request bodies are gzip-compressed. Bodies are only
+// compressed once the API has advertised gzip support through the Accept-Encoding header
+// of a response (RFC 7694), so servers that do not accept compressed requests never see
+// one. It is shared by every request of a client and is safe for concurrent use.
+type RequestCompression struct {
+	minBytes  int
+	supported atomic.Bool
+}
+
+// NewRequestCompression creates a RequestCompression for bodies larger than minBytes.
+func NewRequestCompression(minBytes int) *RequestCompression {
+	return &RequestCompression{minBytes: minBytes}
+}
+
+// Observe records whether the response headers advertise gzip in Accept-Encoding.
+// Responses without Accept-Encoding leave the previous state unchanged.
+func (r *RequestCompression) Observe(header http.Header) {
+	values := header.Values("Accept-Encoding")
+	if len(values) == 0 {
+		return
+	}
+	r.supported.Store(acceptsGzip(values))
+}
+
+// ShouldCompress reports whether a body of size bytes should be compressed.
+func (r *RequestCompression) ShouldCompress(size int) bool {
+	return size > r.minBytes && r.supported.Load()
+}
+
+// acceptsGzip reports whether an Accept-Encoding header lists gzip without a zero weight.
+func acceptsGzip(values []string) bool {
+	for _, value := range values {
+		for coding := range strings.SplitSeq(value, ",") {
+			name, params, _ := strings.Cut(coding, ";")
+			if !strings.EqualFold(strings.TrimSpace(name), "gzip") {
+				continue
+			}
+			q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+			if !ok {
+				return true
+			}
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
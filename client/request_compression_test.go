@@ -0,0 +1,59 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRequestCompression_Observe(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding []string
+		want           bool
+	}{
+		{name: "no header"},
+		{name: "gzip", acceptEncoding: []string{"gzip"}, want: true},
+		{name: "gzip in list", acceptEncoding: []string{"br, GZIP;q=0.5"}, want: true},
+		{name: "gzip in second value", acceptEncoding: []string{"br", "gzip"}, want: true},
+		{name: "gzip refused", acceptEncoding: []string{"gzip;q=0"}},
+		{name: "identity only", acceptEncoding: []string{"identity"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for _, v := range tt.acceptEncoding {
+				header.Add("Accept-Encoding", v)
+			}
+
+			r := NewRequestCompression(10)
+			r.Observe(header)
+
+			if got := r.ShouldCompress(100); got != tt.want {
+				t.Errorf("ShouldCompress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestCompression_ShouldCompress(t *testing.T) {
+	r := NewRequestCompression(10)
+	r.Observe(http.Header{"Accept-Encoding": []string{"gzip"}})
+
+	if r.ShouldCompress(10) {
+		t.Error("Expected a body at the threshold not to be compressed")
+	}
+	if !r.ShouldCompress(11) {
+		t.Error("Expected a body above the threshold to be compressed")
+	}
+
+	r.Observe(http.Header{})
+	if !r.ShouldCompress(11) {
+		t.Error("Expected a response without Accept-Encoding to keep compression enabled")
+	}
+
+	r.Observe(http.Header{"Accept-Encoding": []string{"identity"}})
+	if r.ShouldCompress(11) {
+		t.Error("Expected compression to stop once gzip is no longer advertised")
+	}
+}
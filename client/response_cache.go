@@ -0,0 +1,53 @@
+package client
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/internal/cache"
+)
+
+// MaxCachedResponseBytes is the largest response body a ResponseCache stores. Larger
+// responses are decoded as usual but not buffered for revalidation.
+const MaxCachedResponseBytes = 1 << 20
+
+// CachedResponse is a response stored by a ResponseCache, with its body already decompressed.
+type CachedResponse struct {
+	ETag         string
+	LastModified string
+	Header       http.Header
+	Body         []byte
+}
+
+// ResponseCache stores the validated responses of GET requests, keyed by URL and request
+// headers, so that repeated requests are sent with If-None-Match or If-Modified-Since and a
+// 304 Not Modified answer is served from the stored body. It holds at most size responses,
+// evicting the least recently used ones first, and is safe for concurrent use.
+type ResponseCache struct {
+	lru *cache.LRU[CachedResponse]
+}
+
+// NewResponseCache creates an empty response cache holding at most size responses.
+func NewResponseCache(size int) *ResponseCache {
+	return &ResponseCache{lru: cache.New[CachedResponse](size, 0)}
+}
+
+// Get returns the response stored under key.
+func (c *ResponseCache) Get(key string) (CachedResponse, bool) {
+	return c.lru.Get(key, time.Time{})
+}
+
+// Add stores a response under key, replacing any previous one.
+func (c *ResponseCache) Add(key string, response CachedResponse) {
+	c.lru.Add(key, response, time.Time{})
+}
+
+// Purge drops every stored response.
+func (c *ResponseCache) Purge() {
+	c.lru.Purge()
+}
+
+// Len returns the number of stored responses.
+func (c *ResponseCache) Len() int {
+	return c.lru.Len()
+}
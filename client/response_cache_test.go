@@ -0,0 +1,37 @@
+package client
+
+import "testing"
+
+func TestResponseCache(t *testing.T) {
+	cache := NewResponseCache(2)
+	cache.Add("a", CachedResponse{ETag: `"a"`})
+	cache.Add("b", CachedResponse{ETag: `"b"`})
+
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("Expected a to be cached")
+	}
+	cache.Add("c", CachedResponse{ETag: `"c"`})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected the least recently used response to be evicted")
+	}
+	if got := cache.Len(); got != 2 {
+		t.Errorf("Expected 2 responses, got %d", got)
+	}
+
+	cache.Add("a", CachedResponse{ETag: `"a2"`})
+	if got, _ := cache.Get("a"); got.ETag != `"a2"` {
+		t.Errorf("Expected the response to be replaced, got ETag %s", got.ETag)
+	}
+
+	cache.Purge()
+	if got := cache.Len(); got != 0 {
+		t.Errorf("Expected an empty cache after Purge, got %d", got)
+	}
+
+	disabled := NewResponseCache(0)
+	disabled.Add("a", CachedResponse{ETag: `"a"`})
+	if _, ok := disabled.Get("a"); ok {
+		t.Error("Expected a cache of size 0 to store nothing")
+	}
+}
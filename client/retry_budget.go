@@ -0,0 +1,58 @@
+package client
+
+import (
+	"errors"
+	"sync"
+)
+
+// DefaultRetryBudgetTokens is the capacity of a retry budget, which starts full.
+const DefaultRetryBudgetTokens = 10
+
+// ErrRetryBudgetExhausted is returned, wrapping the last error, when a request is not
+// retried because the client retry budget is exhausted.
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// RetryBudget is a token bucket shared by every request of a client that limits
+// retries under sustained failures. Each retry withdraws one token, and each request
+// that does not need a retry deposits ratio tokens, up to DefaultRetryBudgetTokens.
+// Once the bucket is empty, failed requests are returned without retrying until
+// enough requests succeed again.
+type RetryBudget struct {
+	mu     sync.Mutex
+	tokens float64
+	ratio  float64
+}
+
+// NewRetryBudget creates a full retry budget refilled by ratio tokens per successful
+// request. For example, a ratio of 0.1 allows one retry for every ten successes.
+func NewRetryBudget(ratio float64) *RetryBudget {
+	return &RetryBudget{tokens: DefaultRetryBudgetTokens, ratio: ratio}
+}
+
+// Withdraw takes a token for a retry, reporting whether the retry is allowed.
+func (b *RetryBudget) Withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Deposit refills the budget after a request that did not need a retry.
+func (b *RetryBudget) Deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.tokens+b.ratio, DefaultRetryBudgetTokens)
+}
+
+// Tokens returns the number of retries currently available.
+func (b *RetryBudget) Tokens() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.tokens
+}
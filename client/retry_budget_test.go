@@ -0,0 +1,33 @@
+package client
+
+import "testing"
+
+func TestRetryBudget(t *testing.T) {
+	budget := NewRetryBudget(0.25)
+
+	for i := range DefaultRetryBudgetTokens {
+		if !budget.Withdraw() {
+			t.Fatalf("Expected withdraw %d to succeed", i+1)
+		}
+	}
+	if budget.Withdraw() {
+		t.Error("Expected withdraw to fail once the budget is empty")
+	}
+
+	for range 4 {
+		budget.Deposit()
+	}
+	if !budget.Withdraw() {
+		t.Error("Expected four deposits of 0.25 to allow one retry")
+	}
+	if budget.Withdraw() {
+		t.Error("Expected the refilled token to be consumed")
+	}
+
+	for range 100 {
+		budget.Deposit()
+	}
+	if got := budget.Tokens(); got != DefaultRetryBudgetTokens {
+		t.Errorf("Expected tokens to be capped at %d, got %v", DefaultRetryBudgetTokens, got)
+	}
+}
@@ -251,8 +251,11 @@ func ExampleDeleteInstance(id string) {
 	c := client.NewMgcClient(client.WithAPIKey(apiToken))
 	computeClient := compute.New(c)
 
-	// Delete instance and its public IP
-	if err := computeClient.Instances().Delete(context.Background(), id, true); err != nil {
+	// Delete instance, its public IP and attached volumes
+	if err := computeClient.Instances().Delete(context.Background(), id, compute.DeleteInstanceOptions{
+		DeletePublicIP: true,
+		DeleteVolumes:  true,
+	}); err != nil {
 		log.Fatal(err)
 	}
 
@@ -4,25 +4,110 @@ package compute
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/cache"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/clock"
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
 )
 
 const (
 	DefaultBasePath = "/compute"
+	// DefaultListAllSort is the sort applied by the ListAll methods of images, snapshots
+	// and instance types when the filter options leave Sort unset. A stable order keeps
+	// the pages fetched by offset from skipping or repeating items.
+	DefaultListAllSort = "id:asc"
 )
 
 // VirtualMachineClient represents a client for the compute service.
 // It encapsulates functionality to access instances, images, instance types, and snapshots.
 type VirtualMachineClient struct {
 	*client.CoreClient
+	basePath    string
+	region      string
+	listAllSort string
+	clock       clock.Clock
+	imageCache  *cache.LRU[any]
 }
 
 // ClientOption allows customizing the virtual machine client configuration.
 type ClientOption func(*VirtualMachineClient)
 
+// WithBasePath overrides the API base path prepended to every request path.
+// This is useful when the compute API is mounted elsewhere, such as behind a gateway.
+//
+// Example:
+//
+//	vmClient := compute.New(core, compute.WithBasePath("/api/compute"))
+func WithBasePath(path string) ClientOption {
+	return func(c *VirtualMachineClient) {
+		c.basePath = path
+	}
+}
+
+// WithRegion sends the requests of this client to a region, such as client.RegionBrNe1,
+// instead of the base URL of the core client. The compute API is scoped by the first path
+// segment of the URL: "br-ne1" maps to client.BrNe1, "https://api.magalu.cloud/br-ne1".
+// Other clients created from the same core client are not affected. Requests fail with a
+// client.ValidationError when the region is unknown.
+//
+// Example:
+//
+//	vmClient := compute.New(core, compute.WithRegion(client.RegionBrNe1))
+func WithRegion(region string) ClientOption {
+	return func(c *VirtualMachineClient) {
+		c.region = region
+	}
+}
+
+// WithListAllSort replaces DefaultListAllSort as the sort used by the ListAll methods of
+// images, snapshots and instance types when the filter options leave Sort unset, such as
+// "name:asc". An empty sort leaves the order to the API.
+func WithListAllSort(sort string) ClientOption {
+	return func(c *VirtualMachineClient) {
+		c.listAllSort = sort
+	}
+}
+
+// WithImageCache caches the responses of Images().List and Images().GetCustom in memory
+// for ttl, keeping at most size entries and evicting the least recently used ones first.
+// Responses are keyed by the request parameters, so each page and filter is cached
+// separately, and each call returns its own copy. UpdateCustom and DeleteCustom evict the
+// image they change along with every cached List page; use InvalidateImageCache to drop
+// every entry, for example after creating a custom image.
+// A non-positive ttl or size leaves caching disabled.
+//
+// Example:
+//
+//	vmClient := compute.New(core, compute.WithImageCache(10*time.Minute, 100))
+func WithImageCache(ttl time.Duration, size int) ClientOption {
+	return func(c *VirtualMachineClient) {
+		if ttl <= 0 || size <= 0 {
+			c.imageCache = nil
+			return
+		}
+		c.imageCache = cache.New[any](size, ttl)
+	}
+}
+
+// withClock replaces the clock used to wait between polls and before the concurrent
+// ListAll page fetches (for testing).
+func withClock(c clock.Clock) ClientOption {
+	return func(vmClient *VirtualMachineClient) {
+		vmClient.clock = c
+	}
+}
+
+// WithRequestID returns a copy of ctx carrying the given request ID, which is sent
+// as the X-Request-ID header of every compute request made with it.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return client.WithRequestID(ctx, id)
+}
+
 // New creates a new instance of VirtualMachineClient.
 // If the core client is nil, returns nil.
 func New(core *client.CoreClient, opts ...ClientOption) *VirtualMachineClient {
@@ -30,7 +115,10 @@ func New(core *client.CoreClient, opts ...ClientOption) *VirtualMachineClient {
 		return nil
 	}
 	vmClient := &VirtualMachineClient{
-		CoreClient: core,
+		CoreClient:  core,
+		basePath:    DefaultBasePath,
+		listAllSort: DefaultListAllSort,
+		clock:       clock.Real{},
 	}
 	for _, opt := range opts {
 		opt(vmClient)
@@ -41,7 +129,49 @@ func New(core *client.CoreClient, opts ...ClientOption) *VirtualMachineClient {
 // newRequest creates a new HTTP request for the compute service.
 // This method is internal and should not be called directly by SDK users.
 func (c *VirtualMachineClient) newRequest(ctx context.Context, method, path string, body any) (*http.Request, error) {
-	return mgc_http.NewRequest(c.GetConfig(), ctx, method, DefaultBasePath+path, &body)
+	config := c.GetConfig()
+	if c.region != "" {
+		baseURL, ok := client.URLForRegion(c.region)
+		if !ok {
+			return nil, &client.ValidationError{Field: "region", Message: fmt.Sprintf("unknown region %q", c.region)}
+		}
+		regional := *config
+		regional.BaseURL = baseURL
+		config = &regional
+	}
+	return mgc_http.NewRequest(config, ctx, method, c.basePath+path, &body)
+}
+
+// sortOrDefault returns sort when set, and otherwise the client's ListAll sort,
+// or nil when it is empty.
+func (c *VirtualMachineClient) sortOrDefault(sort *string) *string {
+	if sort != nil || c.listAllSort == "" {
+		return sort
+	}
+	return &c.listAllSort
+}
+
+// Ping verifies that the compute API is reachable and the credentials are valid
+// by listing a single instance type. It returns a *client.HTTPError carrying the
+// status code when the API rejects the request (e.g. 401 or 403 for bad credentials).
+func (c *VirtualMachineClient) Ping(ctx context.Context) error {
+	return mgc_http.ExecuteSimpleRequest(
+		ctx,
+		c.newRequest,
+		c.GetConfig(),
+		http.MethodGet,
+		"/v1/instance-types",
+		nil,
+		url.Values{"_limit": []string{"1"}},
+	)
+}
+
+// InvalidateImageCache drops every response cached by WithImageCache.
+// It does nothing when the cache is disabled.
+func (c *VirtualMachineClient) InvalidateImageCache() {
+	if c.imageCache != nil {
+		c.imageCache.Purge()
+	}
 }
 
 // Instances returns a service to manage virtual machine instances.
@@ -2,7 +2,9 @@ package compute
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
@@ -129,6 +131,20 @@ func TestVirtualMachineClient_newRequest_Headers(t *testing.T) {
 	}
 }
 
+func TestVirtualMachineClient_newRequest_UserAgentSuffix(t *testing.T) {
+	core := client.NewMgcClient(client.WithUserAgentSuffix("my-app/1.2.0"))
+	vmClient := New(core)
+
+	req, err := vmClient.newRequest(context.Background(), http.MethodGet, "/vms", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if userAgent := req.Header.Get("User-Agent"); userAgent != client.DefaultUserAgent+" my-app/1.2.0" {
+		t.Errorf("expected User-Agent to carry the suffix, got %s", userAgent)
+	}
+}
+
 func TestVirtualMachineClient_NewWithNilCore(t *testing.T) {
 	vmClient := New(nil)
 	if vmClient != nil {
@@ -151,3 +167,209 @@ func TestVirtualMachineClient_Instances(t *testing.T) {
 		t.Error("expected instanceSvc to be of type *instanceService")
 	}
 }
+
+func TestVirtualMachineClient_Ping(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			wantErr:    false,
+		},
+		{
+			name:       "invalid credentials",
+			statusCode: http.StatusForbidden,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/compute/v1/instance-types" {
+					t.Errorf("expected path /compute/v1/instance-types, got %s", r.URL.Path)
+				}
+				if r.URL.Query().Get("_limit") != "1" {
+					t.Errorf("expected _limit=1, got %s", r.URL.Query().Get("_limit"))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(`{"instance_types": [], "meta": {"page": {}}}`))
+			}))
+			defer server.Close()
+
+			err := testClient(server.URL).Ping(context.Background())
+
+			if tt.wantErr {
+				httpErr, ok := err.(*client.HTTPError)
+				if !ok {
+					t.Fatalf("expected *client.HTTPError, got %T", err)
+				}
+				if httpErr.StatusCode != tt.statusCode {
+					t.Errorf("expected status %d, got %d", tt.statusCode, httpErr.StatusCode)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVirtualMachineClient_WithBasePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []ClientOption
+		wantPath string
+	}{
+		{
+			name:     "default base path",
+			wantPath: "/compute/v1/instances",
+		},
+		{
+			name:     "custom base path",
+			opts:     []ClientOption{WithBasePath("/api/compute")},
+			wantPath: "/api/compute/v1/instances",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vmClient := New(newTestCoreClient(), tt.opts...)
+
+			req, err := vmClient.newRequest(context.Background(), http.MethodGet, "/v1/instances", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if req.URL.Path != tt.wantPath {
+				t.Errorf("expected path %s, got %s", tt.wantPath, req.URL.Path)
+			}
+		})
+	}
+}
+
+func TestVirtualMachineClient_WithListAllSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []ClientOption
+		sort     *string
+		wantSort string
+	}{
+		{
+			name:     "default sort",
+			wantSort: DefaultListAllSort,
+		},
+		{
+			name:     "custom default sort",
+			opts:     []ClientOption{WithListAllSort("name:asc")},
+			wantSort: "name:asc",
+		},
+		{
+			name:     "default sort disabled",
+			opts:     []ClientOption{WithListAllSort("")},
+			wantSort: "",
+		},
+		{
+			name:     "caller sort takes precedence",
+			opts:     []ClientOption{WithListAllSort("name:asc")},
+			sort:     strPtr("created_at:desc"),
+			wantSort: "created_at:desc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sorts []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sorts = append(sorts, r.URL.Query().Get("_sort"))
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"meta": {"page": {"offset": 0, "limit": 50, "count": 0, "total": 0}}}`))
+			}))
+			defer server.Close()
+
+			core := client.NewMgcClient(client.WithAPIKey("test-api-key"), client.WithBaseURL(client.MgcUrl(server.URL)))
+			vmClient := New(core, tt.opts...)
+			ctx := context.Background()
+
+			if _, err := vmClient.Images().ListAll(ctx, ImageFilterOptions{Sort: tt.sort}); err != nil {
+				t.Fatalf("Images().ListAll() error = %v", err)
+			}
+			if _, err := vmClient.Snapshots().ListAll(ctx, SnapshotFilterOptions{Sort: tt.sort}); err != nil {
+				t.Fatalf("Snapshots().ListAll() error = %v", err)
+			}
+			if _, err := vmClient.InstanceTypes().ListAll(ctx, InstanceTypeFilterOptions{Sort: tt.sort}); err != nil {
+				t.Fatalf("InstanceTypes().ListAll() error = %v", err)
+			}
+
+			for i, sort := range sorts {
+				if sort != tt.wantSort {
+					t.Errorf("request %d: expected _sort %q, got %q", i, tt.wantSort, sort)
+				}
+			}
+			if len(sorts) != 3 {
+				t.Errorf("expected 3 requests, got %d", len(sorts))
+			}
+		})
+	}
+}
+
+func TestVirtualMachineClient_WithRegion(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		wantURL string
+		wantErr bool
+	}{
+		{
+			name:    "core client base URL",
+			wantURL: "http://test-api.com/compute/v1/instances",
+		},
+		{
+			name:    "region",
+			opts:    []ClientOption{WithRegion(client.RegionBrNe1)},
+			wantURL: "https://api.magalu.cloud/br-ne1/compute/v1/instances",
+		},
+		{
+			name:    "region with custom base path",
+			opts:    []ClientOption{WithRegion(client.RegionBrSe1), WithBasePath("/api/compute")},
+			wantURL: "https://api.magalu.cloud/br-se1/api/compute/v1/instances",
+		},
+		{
+			name:    "unknown region",
+			opts:    []ClientOption{WithRegion("us-east-1")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core := newTestCoreClient()
+			vmClient := New(core, tt.opts...)
+
+			req, err := vmClient.newRequest(context.Background(), http.MethodGet, "/v1/instances", nil)
+			if tt.wantErr {
+				var validErr *client.ValidationError
+				if !errors.As(err, &validErr) || validErr.Field != "region" {
+					t.Errorf("expected region ValidationError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if req.URL.String() != tt.wantURL {
+				t.Errorf("expected URL %s, got %s", tt.wantURL, req.URL)
+			}
+			if core.GetConfig().BaseURL != "http://test-api.com" {
+				t.Errorf("expected the core client base URL to be unchanged, got %s", core.GetConfig().BaseURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package compute
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+// InstanceNotFoundError is returned when an instance does not exist.
+// It wraps the underlying *client.HTTPError.
+type InstanceNotFoundError struct {
+	ID  string
+	Err error
+}
+
+// Error returns a string representation of the error.
+func (e *InstanceNotFoundError) Error() string {
+	return fmt.Sprintf("instance not found: %s", e.ID)
+}
+
+// Unwrap returns the underlying HTTP error.
+func (e *InstanceNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// SnapshotNotFoundError is returned when a snapshot does not exist.
+// It wraps the underlying *client.HTTPError.
+type SnapshotNotFoundError struct {
+	ID  string
+	Err error
+}
+
+// Error returns a string representation of the error.
+func (e *SnapshotNotFoundError) Error() string {
+	return fmt.Sprintf("snapshot not found: %s", e.ID)
+}
+
+// Unwrap returns the underlying HTTP error.
+func (e *SnapshotNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// ImageNotFoundError is returned when no active image has a name starting with the given prefix.
+type ImageNotFoundError struct {
+	NamePrefix string
+}
+
+// Error returns a string representation of the error.
+func (e *ImageNotFoundError) Error() string {
+	return fmt.Sprintf("no active image found with name prefix %q", e.NamePrefix)
+}
+
+// PasswordNotReadyError is returned by GetPassword while a Windows instance has not
+// generated its administrator password yet. It is temporary: retry after the instance
+// finishes booting.
+type PasswordNotReadyError struct {
+	ID string
+}
+
+// Error returns a string representation of the error.
+func (e *PasswordNotReadyError) Error() string {
+	return fmt.Sprintf("password of instance %s is not ready yet", e.ID)
+}
+
+// Temporary reports that the password may be available on a later attempt.
+func (e *PasswordNotReadyError) Temporary() bool {
+	return true
+}
+
+// isNotFound reports whether err is an HTTP 404 response.
+func isNotFound(err error) bool {
+	var httpErr *client.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
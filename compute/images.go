@@ -3,10 +3,19 @@ package compute
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/pagination"
+	"github.com/MagaluCloud/mgc-sdk-go/objectstorage"
 )
 
 // ImageList represents the response from listing images.
@@ -32,6 +41,101 @@ type Image struct {
 	AvailabilityZones    *[]string           `json:"availability_zones,omitempty"`
 }
 
+// VersionOrEmpty returns the image version, or an empty string if it was omitted.
+func (i Image) VersionOrEmpty() string {
+	return helpers.Deref(i.Version)
+}
+
+// PlatformOrEmpty returns the image platform, or an empty string if it was omitted.
+func (i Image) PlatformOrEmpty() string {
+	return helpers.Deref(i.Platform)
+}
+
+// ReleaseAtOrEmpty returns the image release date, or an empty string if it was omitted.
+func (i Image) ReleaseAtOrEmpty() string {
+	return helpers.Deref(i.ReleaseAt)
+}
+
+// EndStandardSupportAtOrEmpty returns the end of standard support date, or an empty string if it was omitted.
+func (i Image) EndStandardSupportAtOrEmpty() string {
+	return helpers.Deref(i.EndStandardSupportAt)
+}
+
+// EndLifeAtOrEmpty returns the end of life date, or an empty string if it was omitted.
+func (i Image) EndLifeAtOrEmpty() string {
+	return helpers.Deref(i.EndLifeAt)
+}
+
+// ReleaseTime returns the image release date, with ok false when it was omitted or cannot be parsed.
+func (i Image) ReleaseTime() (t time.Time, ok bool) {
+	return parseImageDate(i.ReleaseAt)
+}
+
+// EndStandardSupportTime returns the end of standard support date, with ok false when it was
+// omitted or cannot be parsed.
+func (i Image) EndStandardSupportTime() (t time.Time, ok bool) {
+	return parseImageDate(i.EndStandardSupportAt)
+}
+
+// EndLifeTime returns the end of life date, with ok false when it was omitted or cannot be parsed.
+func (i Image) EndLifeTime() (t time.Time, ok bool) {
+	return parseImageDate(i.EndLifeAt)
+}
+
+// IsEndOfLife reports whether the image reached its end of life at now.
+// Images without a valid end of life date are never considered end of life.
+func (i Image) IsEndOfLife(now time.Time) bool {
+	endLife, ok := i.EndLifeTime()
+	return ok && !now.Before(endLife)
+}
+
+// parseImageDate parses an image lifecycle date, given either in RFC 3339 or as a date only.
+func parseImageDate(date *string) (time.Time, bool) {
+	if date == nil {
+		return time.Time{}, false
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, *date); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// GetLabels returns the image labels, or nil if they were omitted.
+func (i Image) GetLabels() []string {
+	return helpers.Deref(i.Labels)
+}
+
+// GetAvailabilityZones returns the availability zones of the image, or nil if they were omitted.
+func (i Image) GetAvailabilityZones() []string {
+	return helpers.Deref(i.AvailabilityZones)
+}
+
+// clone returns a copy of the image sharing no pointers or slices with it.
+func (i Image) clone() Image {
+	clone := i
+	clone.Version = clonePtr(i.Version)
+	clone.Platform = clonePtr(i.Platform)
+	clone.ReleaseAt = clonePtr(i.ReleaseAt)
+	clone.EndStandardSupportAt = clonePtr(i.EndStandardSupportAt)
+	clone.EndLifeAt = clonePtr(i.EndLifeAt)
+	clone.Labels = cloneSlicePtr(i.Labels)
+	clone.AvailabilityZones = cloneSlicePtr(i.AvailabilityZones)
+	return clone
+}
+
+// clone returns a copy of the list whose images share no pointers or slices with it.
+func (l *ImageList) clone() *ImageList {
+	clone := *l
+	clone.Images = make([]Image, len(l.Images))
+	for i, image := range l.Images {
+		clone.Images[i] = image.clone()
+	}
+	return &clone
+}
+
 // MinimumRequirements represents the minimum hardware requirements for an image.
 // These requirements must be met by the instance type when creating instances from this image.
 type MinimumRequirements struct {
@@ -59,6 +163,8 @@ const (
 )
 
 // Platform represents the system platform.
+// Values unknown to the SDK are decoded unchanged; IsValid reports them so
+// callers can detect new values returned by the API.
 type Platform string
 
 const (
@@ -66,12 +172,24 @@ const (
 	PlatformWindows Platform = "windows"
 )
 
+// IsValid reports whether p is a platform known to the SDK.
+func (p Platform) IsValid() bool {
+	return slices.Contains(supportedPlatforms, p)
+}
+
 // Architecture represents the system architecure.
+// Values unknown to the SDK are decoded unchanged; IsValid reports them.
 type Architecture string
 
 const ArchitectureX86_64 Architecture = "x86/64"
 
+// IsValid reports whether a is an architecture known to the SDK.
+func (a Architecture) IsValid() bool {
+	return slices.Contains(supportedArchitectures, a)
+}
+
 // License indicates if the image software requires a license.
+// Values unknown to the SDK are decoded unchanged; IsValid reports them.
 type License string
 
 const (
@@ -79,6 +197,38 @@ const (
 	LicenseUnlicensed License = "unlicensed"
 )
 
+// IsValid reports whether l is a license known to the SDK.
+func (l License) IsValid() bool {
+	return slices.Contains(supportedLicenses, l)
+}
+
+// Values accepted when creating custom images, the single source of truth of
+// the IsValid methods and SupportedCustomImageOptions.
+var (
+	supportedPlatforms     = []Platform{PlatformLinux, PlatformWindows}
+	supportedArchitectures = []Architecture{ArchitectureX86_64}
+	supportedLicenses      = []License{LicenseLicensed, LicenseUnlicensed}
+)
+
+// CustomImageOptions lists the values accepted when creating a custom image.
+type CustomImageOptions struct {
+	Platforms     []Platform     `json:"platforms"`
+	Architectures []Architecture `json:"architectures"`
+	Licenses      []License      `json:"licenses"`
+}
+
+// SupportedCustomImageOptions returns the platforms, architectures and licenses
+// accepted by CreateCustom, for example to build selection lists in a UI.
+// The compute API has no endpoint listing them, so they are the values known to
+// this version of the SDK; CreateCustom validates requests against the same lists.
+func SupportedCustomImageOptions() CustomImageOptions {
+	return CustomImageOptions{
+		Platforms:     slices.Clone(supportedPlatforms),
+		Architectures: slices.Clone(supportedArchitectures),
+		Licenses:      slices.Clone(supportedLicenses),
+	}
+}
+
 // CreateCustomImageRequest represents the request to create a new custom image.
 type CreateCustomImageRequest struct {
 	Name         string               `json:"name"`
@@ -92,10 +242,60 @@ type CreateCustomImageRequest struct {
 	UEFI         *bool                `json:"uefi,omitempty"`
 }
 
+// Validate checks the request before it is sent, returning a *client.ValidationError
+// for a single failure, a client.ValidationErrors with every failure when there are
+// several, or nil when the request is valid. The platform, architecture
+// and license must be known to the SDK, and the URL must be an absolute HTTPS URL.
+func (r CreateCustomImageRequest) Validate() error {
+	var errs client.ValidationErrors
+	if !r.Platform.IsValid() {
+		errs = append(errs, client.ValidationError{Field: "platform", Message: fmt.Sprintf("unknown platform %q", r.Platform)})
+	}
+	if !r.Architecture.IsValid() {
+		errs = append(errs, client.ValidationError{Field: "architecture", Message: fmt.Sprintf("unknown architecture %q", r.Architecture)})
+	}
+	if !r.License.IsValid() {
+		errs = append(errs, client.ValidationError{Field: "license", Message: fmt.Sprintf("unknown license %q", r.License)})
+	}
+	if u, err := url.Parse(r.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+		errs = append(errs, client.ValidationError{Field: "url", Message: fmt.Sprintf("must be a valid HTTPS URL, got %q", r.URL)})
+	}
+	return errs.Err()
+}
+
+// Clone returns a deep copy of the request, sharing no pointers with it,
+// so a template can be copied and changed without affecting other copies.
+func (r CreateCustomImageRequest) Clone() CreateCustomImageRequest {
+	clone := r
+	clone.Requirements = clonePtr(r.Requirements)
+	clone.Version = clonePtr(r.Version)
+	clone.Description = clonePtr(r.Description)
+	clone.UEFI = clonePtr(r.UEFI)
+	return clone
+}
+
 // UpdateCustomImageRequest represents the request to update a custom image.
+// Only non-nil fields are sent, so unset fields keep their current values.
 type UpdateCustomImageRequest struct {
-	Version     *string `json:"version,omitempty"`
-	Description *string `json:"description,omitempty"`
+	Version     *string         `json:"version,omitempty"`
+	Description *string         `json:"description,omitempty"`
+	Metadata    *map[string]any `json:"metadata,omitempty"`
+	Labels      *[]string       `json:"labels,omitempty"`
+}
+
+// Clone returns a copy of the request sharing no pointers, slices or maps with it,
+// so a template can be copied and changed without affecting other copies.
+// Metadata values are copied as is, so nested maps or slices in them are shared.
+func (r UpdateCustomImageRequest) Clone() UpdateCustomImageRequest {
+	clone := r
+	clone.Version = clonePtr(r.Version)
+	clone.Description = clonePtr(r.Description)
+	if r.Metadata != nil {
+		metadata := maps.Clone(*r.Metadata)
+		clone.Metadata = &metadata
+	}
+	clone.Labels = cloneSlicePtr(r.Labels)
+	return clone
 }
 
 // CustomImage represents a custom virtual machine image.
@@ -110,6 +310,47 @@ type CustomImage struct {
 	Version      *string              `json:"version,omitempty"`
 	Description  *string              `json:"description,omitempty"`
 	Metadata     *map[string]any      `json:"metadata,omitempty"`
+	Labels       *[]string            `json:"labels,omitempty"`
+}
+
+// clone returns a copy of the image sharing no pointers, slices or maps with it.
+// Metadata values are copied as is, so nested maps or slices in them are shared.
+func (i *CustomImage) clone() *CustomImage {
+	clone := *i
+	clone.Requirements = clonePtr(i.Requirements)
+	clone.Version = clonePtr(i.Version)
+	clone.Description = clonePtr(i.Description)
+	if i.Metadata != nil {
+		metadata := maps.Clone(*i.Metadata)
+		clone.Metadata = &metadata
+	}
+	clone.Labels = cloneSlicePtr(i.Labels)
+	return &clone
+}
+
+// GetRequirements returns the minimum requirements of the image, or zero values if they were omitted.
+func (i CustomImage) GetRequirements() MinimumRequirements {
+	return helpers.Deref(i.Requirements)
+}
+
+// VersionOrEmpty returns the image version, or an empty string if it was omitted.
+func (i CustomImage) VersionOrEmpty() string {
+	return helpers.Deref(i.Version)
+}
+
+// DescriptionOrEmpty returns the image description, or an empty string if it was omitted.
+func (i CustomImage) DescriptionOrEmpty() string {
+	return helpers.Deref(i.Description)
+}
+
+// GetMetadata returns the image metadata, or nil if it was omitted.
+func (i CustomImage) GetMetadata() map[string]any {
+	return helpers.Deref(i.Metadata)
+}
+
+// GetLabels returns the image labels, or nil if they were omitted.
+func (i CustomImage) GetLabels() []string {
+	return helpers.Deref(i.Labels)
 }
 
 // CustomImageList represents the response from listing custom images.
@@ -128,16 +369,46 @@ type CustomImageListOptions struct {
 	Name   *string
 }
 
+// ExportStatus represents the state of a custom image export job.
+type ExportStatus string
+
+const (
+	ExportStatusPending   ExportStatus = "pending"
+	ExportStatusExporting ExportStatus = "exporting"
+	ExportStatusCompleted ExportStatus = "completed"
+	ExportStatusFailed    ExportStatus = "failed"
+)
+
+// ExportDestination identifies the object storage location a custom image is exported to.
+type ExportDestination struct {
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+}
+
+// CustomImageExport represents a custom image export job.
+type CustomImageExport struct {
+	ID          string            `json:"id"`
+	ImageID     string            `json:"image_id"`
+	Status      ExportStatus      `json:"status"`
+	Destination ExportDestination `json:"destination"`
+	Error       *string           `json:"error,omitempty"`
+}
+
 // ImageService provides operations for managing virtual machine images.
 // This interface allows listing available images with optional filtering.
 type ImageService interface {
 	List(ctx context.Context, opts ImageListOptions) (*ImageList, error)
 	ListAll(ctx context.Context, opts ImageFilterOptions) ([]Image, error)
+	ListAllPartial(ctx context.Context, opts ImageFilterOptions) ([]Image, error)
+	LatestByName(ctx context.Context, namePrefix string, opts ImageFilterOptions) (*Image, error)
 	CreateCustom(ctx context.Context, req CreateCustomImageRequest) (string, error)
 	GetCustom(ctx context.Context, id string) (*CustomImage, error)
 	ListCustom(ctx context.Context, opts CustomImageListOptions) (*CustomImageList, error)
 	DeleteCustom(ctx context.Context, id string) error
 	UpdateCustom(ctx context.Context, id string, req UpdateCustomImageRequest) error
+	UpdateCustomAndGet(ctx context.Context, id string, req UpdateCustomImageRequest) (*CustomImage, error)
+	ExportCustom(ctx context.Context, id string, dst ExportDestination) (string, error)
+	GetCustomExportStatus(ctx context.Context, exportID string) (*CustomImageExport, error)
 }
 
 // imageService implements the ImageService interface.
@@ -149,8 +420,11 @@ type imageService struct {
 // ImageListOptions defines the parameters for filtering and pagination of image lists.
 // All fields are optional and allow controlling the listing behavior.
 type ImageListOptions struct {
-	Limit            *int
-	Offset           *int
+	Limit  *int
+	Offset *int
+	// PageToken requests the page following a previous response's Meta.NextPageToken.
+	// It takes precedence over Offset.
+	PageToken        *string
 	Sort             *string
 	AvailabilityZone *string
 }
@@ -164,6 +438,7 @@ type ImageFilterOptions struct {
 // List retrieves images matching the provided options with pagination metadata.
 // This method makes an HTTP request to get the list of images
 // and applies the filters specified in the options.
+// With WithImageCache, pages are served from the cache until they expire.
 func (s *imageService) List(ctx context.Context, opts ImageListOptions) (*ImageList, error) {
 	req, err := s.client.newRequest(ctx, http.MethodGet, "/v1/images", nil)
 	if err != nil {
@@ -174,7 +449,9 @@ func (s *imageService) List(ctx context.Context, opts ImageListOptions) (*ImageL
 	if opts.Limit != nil {
 		q.Add("_limit", strconv.Itoa(*opts.Limit))
 	}
-	if opts.Offset != nil {
+	if opts.PageToken != nil {
+		q.Add("_page_token", *opts.PageToken)
+	} else if opts.Offset != nil {
 		q.Add("_offset", strconv.Itoa(*opts.Offset))
 	}
 	if opts.Sort != nil {
@@ -185,6 +462,11 @@ func (s *imageService) List(ctx context.Context, opts ImageListOptions) (*ImageL
 	}
 	req.URL.RawQuery = q.Encode()
 
+	key := imageListCacheKeyPrefix + req.URL.String()
+	if cached, ok := s.cacheGet(key); ok {
+		return cached.(*ImageList).clone(), nil
+	}
+
 	response := &ImageList{}
 
 	_, err = mgc_http.Do(s.client.GetConfig(), ctx, req, response)
@@ -192,48 +474,95 @@ func (s *imageService) List(ctx context.Context, opts ImageListOptions) (*ImageL
 		return nil, err
 	}
 
-	return response, nil
+	s.cacheAdd(key, response)
+	return response.clone(), nil
 }
 
 // ListAll retrieves all images across all pages with optional filtering.
-// This method automatically handles pagination and returns all results.
+// When the API returns page tokens they are followed, which keeps the results consistent
+// if images change during the listing; a token returned twice fails the listing instead
+// of looping. Otherwise pages after the first are fetched concurrently by offset and
+// returned in API order.
+// When opts.Sort is unset, images are sorted by the client's ListAll sort, DefaultListAllSort by default.
 func (s *imageService) ListAll(ctx context.Context, opts ImageFilterOptions) ([]Image, error) {
-	var allImages []Image
-	offset := 0
-	limit := 50
+	return pagination.FetchAllCursor(ctx, s.client.clock, 50, s.pageFetcher(opts))
+}
 
-	for {
-		currentOffset := offset
-		currentLimit := limit
+// ListAllPartial retrieves all images like ListAll, but when a page fails it returns the
+// images fetched before that page, in API order, together with the error, so that long
+// aggregations can decide whether the partial result is useful. ListAll returns no
+// images on error.
+func (s *imageService) ListAllPartial(ctx context.Context, opts ImageFilterOptions) ([]Image, error) {
+	return pagination.FetchAllCursorPartial(ctx, s.client.clock, 50, s.pageFetcher(opts))
+}
+
+// pageFetcher fetches the pages of ListAll and ListAllPartial.
+func (s *imageService) pageFetcher(opts ImageFilterOptions) pagination.CursorFetcher[Image] {
+	return func(ctx context.Context, token string, offset, limit int) ([]Image, int, string, error) {
 		listOpts := ImageListOptions{
-			Offset:           &currentOffset,
-			Limit:            &currentLimit,
-			Sort:             opts.Sort,
+			Offset:           &offset,
+			Limit:            &limit,
+			Sort:             s.client.sortOrDefault(opts.Sort),
 			AvailabilityZone: opts.AvailabilityZone,
 		}
+		if token != "" {
+			listOpts.PageToken = &token
+		}
 
 		response, err := s.List(ctx, listOpts)
 		if err != nil {
-			return nil, err
+			return nil, 0, "", err
 		}
+		return response.Images, response.Meta.Page.Total, response.Meta.NextPageToken, nil
+	}
+}
 
-		allImages = append(allImages, response.Images...)
+// LatestByName returns the active image whose name starts with namePrefix and has the most
+// recent release date, such as the newest "ubuntu-22.04" image, so that infrastructure code
+// does not depend on image IDs. Images with no valid release date are only returned when no
+// other image matches, and ties are broken by the greatest name.
+// Returns an ImageNotFoundError when no active image matches.
+func (s *imageService) LatestByName(ctx context.Context, namePrefix string, opts ImageFilterOptions) (*Image, error) {
+	if namePrefix == "" {
+		return nil, &client.ValidationError{Field: "namePrefix", Message: "cannot be empty"}
+	}
+
+	images, err := s.ListAll(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
 
-		// Check if we've retrieved all results
-		if len(response.Images) < limit {
-			break
+	var latest *Image
+	var latestRelease time.Time
+	for i := range images {
+		image := &images[i]
+		if image.Status != ImageStatusActive || !strings.HasPrefix(image.Name, namePrefix) {
+			continue
 		}
 
-		offset += limit
+		release, _ := image.ReleaseTime()
+		if latest == nil || release.After(latestRelease) || (release.Equal(latestRelease) && image.Name > latest.Name) {
+			latest, latestRelease = image, release
+		}
 	}
 
-	return allImages, nil
+	if latest == nil {
+		return nil, &ImageNotFoundError{NamePrefix: namePrefix}
+	}
+	return latest, nil
 }
 
 // Create creates a new custom image.
 // This method makes an HTTP request to publish a new custom image
 // and returns the ID of the created image.
+// The URL must be a well-formed HTTPS URL; a warning is logged when its host
+// is not a known Magalu object storage endpoint.
 func (s *imageService) CreateCustom(ctx context.Context, createReq CreateCustomImageRequest) (string, error) {
+	if err := createReq.Validate(); err != nil {
+		return "", err
+	}
+	s.warnUnknownImageHost(createReq.URL)
+
 	res, err := mgc_http.ExecuteSimpleRequestWithRespBody[struct{ ID string }](
 		ctx,
 		s.client.newRequest,
@@ -251,8 +580,14 @@ func (s *imageService) CreateCustom(ctx context.Context, createReq CreateCustomI
 
 // GetCustom retrieves a specific custom image.
 // This method makes an HTTP request to get detailed information about an image.
+// With WithImageCache, the image is served from the cache until it expires.
 func (s *imageService) GetCustom(ctx context.Context, id string) (*CustomImage, error) {
-	return mgc_http.ExecuteSimpleRequestWithRespBody[CustomImage](
+	key := customImageCacheKey(id)
+	if cached, ok := s.cacheGet(key); ok {
+		return cached.(*CustomImage).clone(), nil
+	}
+
+	response, err := mgc_http.ExecuteSimpleRequestWithRespBody[CustomImage](
 		ctx,
 		s.client.newRequest,
 		s.client.GetConfig(),
@@ -261,6 +596,12 @@ func (s *imageService) GetCustom(ctx context.Context, id string) (*CustomImage,
 		nil,
 		nil,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheAdd(key, response)
+	return response.clone(), nil
 }
 
 // ListCustom retrieves custom images matching the provided options with pagination metadata.
@@ -300,6 +641,7 @@ func (s *imageService) ListCustom(ctx context.Context, opts CustomImageListOptio
 // DeleteCustom deletes a specific custom image.
 // This method makes an HTTP request to delete the specified image.
 func (s *imageService) DeleteCustom(ctx context.Context, id string) error {
+	s.cacheRemoveImage(id)
 	return mgc_http.ExecuteSimpleRequest(
 		ctx,
 		s.client.newRequest,
@@ -311,10 +653,14 @@ func (s *imageService) DeleteCustom(ctx context.Context, id string) error {
 	)
 }
 
-// UpdateCustom updates a specific custom image.
-// This method makes an HTTP request to update the specified image.
+// UpdateCustom partially updates a specific custom image.
+// This method PATCHes the non-nil fields of the request.
 func (s *imageService) UpdateCustom(ctx context.Context, id string, updateReq UpdateCustomImageRequest) error {
-	return mgc_http.ExecuteSimpleRequest(
+	if id == "" {
+		return &client.ValidationError{Field: "id", Message: "cannot be empty"}
+	}
+
+	err := mgc_http.ExecuteSimpleRequest(
 		ctx,
 		s.client.newRequest,
 		s.client.GetConfig(),
@@ -323,4 +669,112 @@ func (s *imageService) UpdateCustom(ctx context.Context, id string, updateReq Up
 		updateReq,
 		nil,
 	)
+	s.cacheRemoveImage(id)
+	return err
+}
+
+// UpdateCustomAndGet partially updates a specific custom image and returns it.
+// The PATCH response has no body, so the image is fetched with a separate GET after the
+// update. The two requests are not atomic: the returned image may already include changes
+// made by someone else in between.
+func (s *imageService) UpdateCustomAndGet(ctx context.Context, id string, updateReq UpdateCustomImageRequest) (*CustomImage, error) {
+	if err := s.UpdateCustom(ctx, id, updateReq); err != nil {
+		return nil, err
+	}
+
+	return s.GetCustom(ctx, id)
+}
+
+// ExportCustom starts exporting a custom image to an object storage bucket.
+// This method returns the ID of the export job, which can be polled with GetCustomExportStatus.
+func (s *imageService) ExportCustom(ctx context.Context, id string, dst ExportDestination) (string, error) {
+	if id == "" {
+		return "", &client.ValidationError{Field: "id", Message: "cannot be empty"}
+	}
+	if dst.Bucket == "" {
+		return "", &client.ValidationError{Field: "bucket", Message: "cannot be empty"}
+	}
+	if dst.Key == "" {
+		return "", &client.ValidationError{Field: "key", Message: "cannot be empty"}
+	}
+
+	res, err := mgc_http.ExecuteSimpleRequestWithRespBody[struct{ ID string }](
+		ctx,
+		s.client.newRequest,
+		s.client.GetConfig(),
+		http.MethodPost,
+		fmt.Sprintf("/v1/images/custom/%s/export", id),
+		dst,
+		nil,
+	)
+	if err != nil {
+		return "", err
+	}
+	return res.ID, nil
+}
+
+// GetCustomExportStatus retrieves the state of a custom image export job.
+func (s *imageService) GetCustomExportStatus(ctx context.Context, exportID string) (*CustomImageExport, error) {
+	if exportID == "" {
+		return nil, &client.ValidationError{Field: "exportID", Message: "cannot be empty"}
+	}
+
+	return mgc_http.ExecuteSimpleRequestWithRespBody[CustomImageExport](
+		ctx,
+		s.client.newRequest,
+		s.client.GetConfig(),
+		http.MethodGet,
+		fmt.Sprintf("/v1/images/custom/exports/%s", exportID),
+		nil,
+		nil,
+	)
+}
+
+// imageListCacheKeyPrefix starts the image cache keys of List pages.
+const imageListCacheKeyPrefix = "list "
+
+// customImageCacheKey returns the image cache key of the custom image with the given ID.
+func customImageCacheKey(id string) string {
+	return "custom " + id
+}
+
+// cacheGet returns the response cached under key, when the image cache is enabled.
+// Cached responses are shared: callers copy them before returning them to users.
+func (s *imageService) cacheGet(key string) (any, bool) {
+	if s.client.imageCache == nil {
+		return nil, false
+	}
+	return s.client.imageCache.Get(key, s.client.clock.Now())
+}
+
+// cacheAdd stores a response under key, when the image cache is enabled.
+func (s *imageService) cacheAdd(key string, value any) {
+	if s.client.imageCache != nil {
+		s.client.imageCache.Add(key, value, s.client.clock.Now())
+	}
+}
+
+// cacheRemoveImage drops the cached custom image with the given ID and every cached List
+// page, which may include it, when the image cache is enabled.
+func (s *imageService) cacheRemoveImage(id string) {
+	if s.client.imageCache != nil {
+		s.client.imageCache.Remove(customImageCacheKey(id))
+		s.client.imageCache.RemoveFunc(func(key string) bool {
+			return strings.HasPrefix(key, imageListCacheKeyPrefix)
+		})
+	}
+}
+
+// warnUnknownImageHost logs a warning when the URL of a custom image is not on a
+// Magalu object storage endpoint.
+func (s *imageService) warnUnknownImageHost(rawURL string) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return
+	}
+
+	if _, ok := objectstorage.EndpointForHost(u.Hostname()); !ok {
+		s.client.GetConfig().Logger.Warn("custom image URL is not a Magalu object storage endpoint",
+			"url", rawURL)
+	}
 }
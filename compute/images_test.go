@@ -3,14 +3,20 @@ package compute
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/MagaluCloud/mgc-sdk-go/client"
 	"github.com/MagaluCloud/mgc-sdk-go/helpers"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/clock"
 )
 
 func TestImageService_List(t *testing.T) {
@@ -253,6 +259,38 @@ func TestImageService_ListAll(t *testing.T) {
 			wantCount:  125,
 			wantErr:    false,
 		},
+		{
+			name: "short intermediate page",
+			pages: []string{
+				`{
+					"meta": {"page": {"offset": 0, "limit": 50, "count": 30, "total": 80}},
+					"images": [` + generateImageListJSON(0, 30) + `]
+				}`,
+				`{
+					"meta": {"page": {"offset": 50, "limit": 50, "count": 50, "total": 80}},
+					"images": [` + generateImageListJSON(30, 50) + `]
+				}`,
+			},
+			statusCode: http.StatusOK,
+			wantCount:  80,
+			wantErr:    false,
+		},
+		{
+			name: "short page without total",
+			pages: []string{
+				`{
+					"meta": {"page": {"offset": 0, "limit": 50, "count": 30}},
+					"images": [` + generateImageListJSON(0, 30) + `]
+				}`,
+				`{
+					"meta": {"page": {"offset": 50, "limit": 50, "count": 50}},
+					"images": [` + generateImageListJSON(30, 50) + `]
+				}`,
+			},
+			statusCode: http.StatusOK,
+			wantCount:  30,
+			wantErr:    false,
+		},
 		{
 			name: "empty results",
 			pages: []string{
@@ -329,6 +367,87 @@ func TestImageService_ListAll(t *testing.T) {
 	}
 }
 
+func TestImageService_ListAll_PageToken(t *testing.T) {
+	pages := map[string]string{
+		"": `{
+			"meta": {"page": {"offset": 0, "limit": 50, "count": 50, "total": 60}, "next_page_token": "page-2"},
+			"images": [` + generateImageListJSON(0, 50) + `]
+		}`,
+		"page-2": `{
+			"meta": {"page": {"limit": 50, "count": 10, "total": 60}},
+			"images": [` + generateImageListJSON(50, 10) + `]
+		}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("_page_token")
+		if token != "" && r.URL.Query().Has("_offset") {
+			t.Errorf("request with page token %q also sent _offset", token)
+		}
+
+		page, ok := pages[token]
+		if !ok {
+			t.Errorf("unexpected page token %q", token)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	images, err := testClient(server.URL).Images().ListAll(context.Background(), ImageFilterOptions{})
+	if err != nil {
+		t.Fatalf("ListAll() error = %v", err)
+	}
+	if len(images) != 60 {
+		t.Fatalf("ListAll() got %d images, want 60", len(images))
+	}
+	if images[59].ID != "img59" {
+		t.Errorf("ListAll() last image = %q, want img59", images[59].ID)
+	}
+}
+
+func TestImageService_ListAllPartial(t *testing.T) {
+	pages := map[string]string{
+		"": `{
+			"meta": {"page": {"offset": 0, "limit": 50, "count": 50, "total": 150}, "next_page_token": "page-2"},
+			"images": [` + generateImageListJSON(0, 50) + `]
+		}`,
+		"page-2": `{
+			"meta": {"page": {"limit": 50, "count": 50, "total": 150}, "next_page_token": "page-3"},
+			"images": [` + generateImageListJSON(50, 50) + `]
+		}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Query().Get("_page_token")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "bad page"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	images := testClient(server.URL).Images()
+
+	partial, err := images.ListAllPartial(context.Background(), ImageFilterOptions{})
+	if err == nil {
+		t.Fatal("ListAllPartial() expected an error for the failed page")
+	}
+	if len(partial) != 100 || partial[99].ID != "img99" {
+		t.Errorf("ListAllPartial() got %d images, want the 100 fetched before the error", len(partial))
+	}
+
+	all, err := images.ListAll(context.Background(), ImageFilterOptions{})
+	if err == nil || all != nil {
+		t.Errorf("ListAll() = %d images, %v; want no images and an error", len(all), err)
+	}
+}
+
 func generateImageListJSON(start, count int) string {
 	result := ""
 	for i := 0; i < count; i++ {
@@ -385,9 +504,7 @@ func TestImageService_CreateCustom(t *testing.T) {
 				License:      LicenseUnlicensed,
 				URL:          "https://br-se1.magaluobjects.com/bucket/image.qcow2",
 			},
-			response:   `{"error": "invalid architecture"}`,
-			statusCode: http.StatusBadRequest,
-			wantErr:    true,
+			wantErr: true,
 		},
 		{
 			name: "server error",
@@ -415,6 +532,73 @@ func TestImageService_CreateCustom(t *testing.T) {
 			statusCode: http.StatusConflict,
 			wantErr:    true,
 		},
+		{
+			name: "http url",
+			req: CreateCustomImageRequest{
+				Name:         "test-image",
+				Platform:     PlatformLinux,
+				Architecture: ArchitectureX86_64,
+				License:      LicenseUnlicensed,
+				URL:          "http://br-se1.magaluobjects.com/bucket/image.qcow2",
+			},
+			wantErr: true,
+		},
+		{
+			name: "relative url",
+			req: CreateCustomImageRequest{
+				Name:         "test-image",
+				Platform:     PlatformLinux,
+				Architecture: ArchitectureX86_64,
+				License:      LicenseUnlicensed,
+				URL:          "bucket/image.qcow2",
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty url",
+			req: CreateCustomImageRequest{
+				Name:         "test-image",
+				Platform:     PlatformLinux,
+				Architecture: ArchitectureX86_64,
+				License:      LicenseUnlicensed,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown platform",
+			req: CreateCustomImageRequest{
+				Name:         "test-image",
+				Platform:     Platform("Linux"),
+				Architecture: ArchitectureX86_64,
+				License:      LicenseUnlicensed,
+				URL:          "https://br-se1.magaluobjects.com/bucket/image.qcow2",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown license",
+			req: CreateCustomImageRequest{
+				Name:         "test-image",
+				Platform:     PlatformLinux,
+				Architecture: ArchitectureX86_64,
+				License:      License("free"),
+				URL:          "https://br-se1.magaluobjects.com/bucket/image.qcow2",
+			},
+			wantErr: true,
+		},
+		{
+			name: "external host is allowed",
+			req: CreateCustomImageRequest{
+				Name:         "test-image",
+				Platform:     PlatformLinux,
+				Architecture: ArchitectureX86_64,
+				License:      LicenseUnlicensed,
+				URL:          "https://images.example.com/image.qcow2",
+			},
+			response:   `{"id": "8cf5c6d9-d5c5-4af9-bd1b-c17d032dc761"}`,
+			statusCode: http.StatusOK,
+			wantID:     "8cf5c6d9-d5c5-4af9-bd1b-c17d032dc761",
+		},
 	}
 
 	for _, tt := range tests {
@@ -426,13 +610,18 @@ func TestImageService_CreateCustom(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client := testClient(server.URL)
-			gotID, err := client.Images().CreateCustom(context.Background(), tt.req)
+			vmClient := testClient(server.URL)
+			gotID, err := vmClient.Images().CreateCustom(context.Background(), tt.req)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.statusCode == 0 {
+				if _, ok := err.(*client.ValidationError); !ok {
+					t.Errorf("Create() expected *client.ValidationError, got %T", err)
+				}
+			}
 			if gotID != tt.wantID {
 				t.Errorf("Create() got = %v, want %v", gotID, tt.wantID)
 			}
@@ -440,6 +629,33 @@ func TestImageService_CreateCustom(t *testing.T) {
 	}
 }
 
+func TestCreateCustomImageRequest_Validate(t *testing.T) {
+	req := CreateCustomImageRequest{
+		Name:         "custom",
+		Platform:     Platform("bsd"),
+		Architecture: ArchitectureX86_64,
+		License:      License("gpl"),
+		URL:          "http://example.com/image.qcow2",
+	}
+
+	var errs client.ValidationErrors
+	if !errors.As(req.Validate(), &errs) {
+		t.Fatalf("Validate() = %v, want client.ValidationErrors", req.Validate())
+	}
+	var fields []string
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	if want := []string{"platform", "license", "url"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("Validate() failed fields = %v, want %v", fields, want)
+	}
+
+	req.Platform, req.License, req.URL = PlatformLinux, LicenseUnlicensed, "https://example.com/image.qcow2"
+	if err := req.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
 func TestImageService_GetCustom(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -762,6 +978,8 @@ func TestImageService_UpdateCustom(t *testing.T) {
 			req: UpdateCustomImageRequest{
 				Description: helpers.StrPtr("Unit test"),
 				Version:     helpers.StrPtr("0.0.1"),
+				Metadata:    &map[string]any{"owner": "sdk"},
+				Labels:      &[]string{"test"},
 			},
 			statusCode: http.StatusNoContent,
 			wantErr:    false,
@@ -791,6 +1009,13 @@ func TestImageService_UpdateCustom(t *testing.T) {
 			statusCode: http.StatusNoContent,
 			wantErr:    false,
 		},
+		{
+			name:       "empty id",
+			id:         "",
+			req:        UpdateCustomImageRequest{},
+			statusCode: http.StatusNoContent,
+			wantErr:    true,
+		},
 		{
 			name:       "unknown image",
 			id:         "bee43a76-d964-48d6-82fc-218b936000a7",
@@ -814,6 +1039,10 @@ func TestImageService_UpdateCustom(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				defer r.Body.Close()
 
+				if r.Method != http.MethodPatch {
+					t.Errorf("expected method PATCH, got %s", r.Method)
+				}
+
 				data, err := io.ReadAll(r.Body)
 				if err != nil {
 					t.Errorf("failed to read request body: %s", err)
@@ -846,3 +1075,576 @@ func TestImageService_UpdateCustom(t *testing.T) {
 		})
 	}
 }
+
+func TestImageService_UpdateCustomAndGet(t *testing.T) {
+	t.Parallel()
+
+	id := "86a304b0-dc28-454e-9448-5275c4008dfa"
+	req := UpdateCustomImageRequest{Description: helpers.StrPtr("Unit test")}
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == http.MethodPatch {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CustomImage{ID: id, Name: "custom-image", Description: req.Description})
+	}))
+	defer server.Close()
+
+	got, err := testClient(server.URL).Images().UpdateCustomAndGet(context.Background(), id, req)
+	if err != nil {
+		t.Fatalf("UpdateCustomAndGet() error = %v", err)
+	}
+	if got.ID != id || !reflect.DeepEqual(got.Description, req.Description) {
+		t.Errorf("UpdateCustomAndGet() = %+v, want image %s with the new description", got, id)
+	}
+	if !reflect.DeepEqual(methods, []string{http.MethodPatch, http.MethodGet}) {
+		t.Errorf("UpdateCustomAndGet() requests = %v, want PATCH then GET", methods)
+	}
+
+	methods = nil
+	if _, err := testClient(server.URL).Images().UpdateCustomAndGet(context.Background(), "", req); err == nil {
+		t.Error("UpdateCustomAndGet() with empty id expected error, got nil")
+	}
+	if len(methods) != 0 {
+		t.Errorf("UpdateCustomAndGet() with empty id sent %v", methods)
+	}
+}
+
+func TestImageService_ExportCustom(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		id         string
+		dst        ExportDestination
+		response   string
+		statusCode int
+		wantID     string
+		wantErr    bool
+	}{
+		{
+			name:       "successful export",
+			id:         "img1",
+			dst:        ExportDestination{Bucket: "archive", Key: "images/img1.qcow2"},
+			response:   `{"id": "exp1"}`,
+			statusCode: http.StatusOK,
+			wantID:     "exp1",
+		},
+		{
+			name:    "empty id",
+			dst:     ExportDestination{Bucket: "archive", Key: "images/img1.qcow2"},
+			wantErr: true,
+		},
+		{
+			name:    "empty bucket",
+			id:      "img1",
+			dst:     ExportDestination{Key: "images/img1.qcow2"},
+			wantErr: true,
+		},
+		{
+			name:    "empty key",
+			id:      "img1",
+			dst:     ExportDestination{Bucket: "archive"},
+			wantErr: true,
+		},
+		{
+			name:       "image not found",
+			id:         "img1",
+			dst:        ExportDestination{Bucket: "archive", Key: "images/img1.qcow2"},
+			response:   `{"error": "not found"}`,
+			statusCode: http.StatusNotFound,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("expected method POST, got %s", r.Method)
+				}
+				if r.URL.Path != "/compute/v1/images/custom/"+tt.id+"/export" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+
+				var body ExportDestination
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("failed to decode body: %s", err)
+				}
+				if body != tt.dst {
+					t.Errorf("expected body %+v, got %+v", tt.dst, body)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := testClient(server.URL)
+			gotID, err := client.Images().ExportCustom(context.Background(), tt.id, tt.dst)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExportCustom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotID != tt.wantID {
+				t.Errorf("ExportCustom() got = %v, want %v", gotID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestImageService_GetCustomExportStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		exportID   string
+		response   string
+		statusCode int
+		wantStatus ExportStatus
+		wantErr    bool
+	}{
+		{
+			name:       "exporting",
+			exportID:   "exp1",
+			response:   `{"id": "exp1", "image_id": "img1", "status": "exporting", "destination": {"bucket": "archive", "key": "img1.qcow2"}}`,
+			statusCode: http.StatusOK,
+			wantStatus: ExportStatusExporting,
+		},
+		{
+			name:       "failed",
+			exportID:   "exp1",
+			response:   `{"id": "exp1", "image_id": "img1", "status": "failed", "error": "bucket not found"}`,
+			statusCode: http.StatusOK,
+			wantStatus: ExportStatusFailed,
+		},
+		{
+			name:     "empty export id",
+			exportID: "",
+			wantErr:  true,
+		},
+		{
+			name:       "export not found",
+			exportID:   "exp1",
+			response:   `{"error": "not found"}`,
+			statusCode: http.StatusNotFound,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/compute/v1/images/custom/exports/"+tt.exportID {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := testClient(server.URL)
+			got, err := client.Images().GetCustomExportStatus(context.Background(), tt.exportID)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetCustomExportStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("GetCustomExportStatus() got status %s, want %s", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestImage_Accessors(t *testing.T) {
+	var omitted Image
+	if err := json.Unmarshal([]byte(`{"id": "img1", "name": "ubuntu", "status": "active"}`), &omitted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := json.Marshal(omitted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var roundTrip Image
+	if err := json.Unmarshal(data, &roundTrip); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, img := range []Image{omitted, roundTrip} {
+		if img.Platform != nil || img.Labels != nil {
+			t.Errorf("expected omitted fields to stay nil, got %+v", img)
+		}
+		if img.PlatformOrEmpty() != "" || img.VersionOrEmpty() != "" || img.ReleaseAtOrEmpty() != "" ||
+			img.EndStandardSupportAtOrEmpty() != "" || img.EndLifeAtOrEmpty() != "" {
+			t.Errorf("expected empty strings for omitted fields")
+		}
+		if img.GetLabels() != nil || img.GetAvailabilityZones() != nil {
+			t.Errorf("expected nil slices for omitted fields")
+		}
+	}
+
+	img := Image{
+		Platform:          helpers.StrPtr("linux"),
+		Version:           helpers.StrPtr("24.04"),
+		Labels:            &[]string{"lts"},
+		AvailabilityZones: &[]string{"br-se1-a"},
+	}
+	if img.PlatformOrEmpty() != "linux" || img.VersionOrEmpty() != "24.04" {
+		t.Errorf("unexpected accessor values: %q %q", img.PlatformOrEmpty(), img.VersionOrEmpty())
+	}
+	if !reflect.DeepEqual(img.GetLabels(), []string{"lts"}) || !reflect.DeepEqual(img.GetAvailabilityZones(), []string{"br-se1-a"}) {
+		t.Errorf("unexpected accessor values: %v %v", img.GetLabels(), img.GetAvailabilityZones())
+	}
+}
+
+func TestImageService_LatestByName(t *testing.T) {
+	images := `{
+		"meta": {"page": {"offset": 0, "limit": 50, "count": 6, "total": 6}},
+		"images": [
+			{"id": "img1", "name": "ubuntu-22.04-20240101", "status": "active", "release_at": "2024-01-01T00:00:00Z"},
+			{"id": "img2", "name": "ubuntu-22.04-20240601", "status": "active", "release_at": "2024-06-01T00:00:00Z"},
+			{"id": "img3", "name": "ubuntu-22.04-20241001", "status": "deprecated", "release_at": "2024-10-01T00:00:00Z"},
+			{"id": "img4", "name": "ubuntu-24.04-20250101", "status": "active", "release_at": "2025-01-01T00:00:00Z"},
+			{"id": "img5", "name": "debian-12-undated-a", "status": "active"},
+			{"id": "img6", "name": "debian-12-undated-b", "status": "active"}
+		]
+	}`
+
+	tests := []struct {
+		name       string
+		namePrefix string
+		wantID     string
+		wantErr    error
+	}{
+		{name: "newest active release", namePrefix: "ubuntu-22.04", wantID: "img2"},
+		{name: "any version", namePrefix: "ubuntu", wantID: "img4"},
+		{name: "undated images", namePrefix: "debian-12", wantID: "img6"},
+		{name: "no match", namePrefix: "centos", wantErr: &ImageNotFoundError{}},
+		{name: "empty prefix", namePrefix: "", wantErr: &client.ValidationError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("availability-zone") != "br-se1-a" {
+					t.Errorf("expected availability-zone br-se1-a, got %q", r.URL.Query().Get("availability-zone"))
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(images))
+			}))
+			defer server.Close()
+
+			image, err := testClient(server.URL).Images().LatestByName(context.Background(), tt.namePrefix, ImageFilterOptions{
+				AvailabilityZone: strPtr("br-se1-a"),
+			})
+
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Fatalf("LatestByName() error = %v, want %T", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && image.ID != tt.wantID {
+				t.Errorf("LatestByName() = %s, want %s", image.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestCustomImageRequests_Clone(t *testing.T) {
+	createReq := CreateCustomImageRequest{
+		Name:         "base",
+		Requirements: &MinimumRequirements{VCPU: 2},
+		Version:      strPtr("1.0"),
+		Description:  strPtr("base image"),
+		UEFI:         helpers.BoolPtr(true),
+	}
+	createClone := createReq.Clone()
+	if !reflect.DeepEqual(createClone, createReq) {
+		t.Fatalf("expected clone %+v to equal the original", createClone)
+	}
+	createClone.Requirements.VCPU = 4
+	*createClone.Version = "2.0"
+	*createClone.Description = "changed"
+	*createClone.UEFI = false
+	if createReq.Requirements.VCPU != 2 || *createReq.Version != "1.0" || *createReq.Description != "base image" || !*createReq.UEFI {
+		t.Errorf("changing the clone changed the original: %+v", createReq)
+	}
+
+	updateReq := UpdateCustomImageRequest{
+		Version:     strPtr("1.0"),
+		Description: strPtr("base image"),
+		Metadata:    &map[string]any{"team": "infra"},
+		Labels:      &[]string{"base"},
+	}
+	updateClone := updateReq.Clone()
+	if !reflect.DeepEqual(updateClone, updateReq) {
+		t.Fatalf("expected clone %+v to equal the original", updateClone)
+	}
+	*updateClone.Version = "2.0"
+	*updateClone.Description = "changed"
+	(*updateClone.Metadata)["team"] = "changed"
+	(*updateClone.Labels)[0] = "changed"
+	if *updateReq.Version != "1.0" || *updateReq.Description != "base image" ||
+		(*updateReq.Metadata)["team"] != "infra" || (*updateReq.Labels)[0] != "base" {
+		t.Errorf("changing the clone changed the original: %+v", updateReq)
+	}
+	if empty := (UpdateCustomImageRequest{}).Clone(); empty.Metadata != nil || empty.Labels != nil {
+		t.Errorf("expected nil fields to stay nil, got %+v", empty)
+	}
+}
+
+func TestImage_LifecycleTimes(t *testing.T) {
+	img := Image{
+		ReleaseAt:            helpers.StrPtr("2024-04-25T00:00:00Z"),
+		EndStandardSupportAt: helpers.StrPtr("2029-05-31"),
+		EndLifeAt:            helpers.StrPtr("2034-04-25T12:00:00-03:00"),
+	}
+
+	if got, ok := img.ReleaseTime(); !ok || !got.Equal(time.Date(2024, 4, 25, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ReleaseTime() = %v, %v", got, ok)
+	}
+	if got, ok := img.EndStandardSupportTime(); !ok || !got.Equal(time.Date(2029, 5, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("EndStandardSupportTime() = %v, %v", got, ok)
+	}
+	endLife, ok := img.EndLifeTime()
+	if !ok || !endLife.Equal(time.Date(2034, 4, 25, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("EndLifeTime() = %v, %v", endLife, ok)
+	}
+
+	if img.IsEndOfLife(endLife.Add(-time.Second)) {
+		t.Error("expected image before its end of life date not to be end of life")
+	}
+	if !img.IsEndOfLife(endLife) {
+		t.Error("expected image at its end of life date to be end of life")
+	}
+
+	for _, invalid := range []Image{{}, {ReleaseAt: helpers.StrPtr(""), EndLifeAt: helpers.StrPtr("soon")}} {
+		if _, ok := invalid.ReleaseTime(); ok {
+			t.Errorf("ReleaseTime() of %v: expected ok to be false", invalid.ReleaseAt)
+		}
+		if _, ok := invalid.EndLifeTime(); ok {
+			t.Errorf("EndLifeTime() of %v: expected ok to be false", invalid.EndLifeAt)
+		}
+		if invalid.IsEndOfLife(time.Now()) {
+			t.Error("expected image without a valid end of life date not to be end of life")
+		}
+	}
+}
+
+func TestCustomImage_Accessors(t *testing.T) {
+	var omitted CustomImage
+	if err := json.Unmarshal([]byte(`{"id": "img1", "name": "custom", "status": "active", "platform": "linux", "license": "unlicensed"}`), &omitted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if omitted.VersionOrEmpty() != "" || omitted.DescriptionOrEmpty() != "" {
+		t.Errorf("expected empty strings for omitted fields")
+	}
+	if omitted.GetLabels() != nil || omitted.GetMetadata() != nil {
+		t.Errorf("expected nil values for omitted fields")
+	}
+	if omitted.GetRequirements() != (MinimumRequirements{}) {
+		t.Errorf("expected zero requirements, got %+v", omitted.GetRequirements())
+	}
+
+	img := CustomImage{
+		Description:  helpers.StrPtr("base image"),
+		Metadata:     &map[string]any{"team": "infra"},
+		Labels:       &[]string{"base"},
+		Requirements: &MinimumRequirements{VCPU: 2, RAM: 4, Disk: 20},
+	}
+	if img.DescriptionOrEmpty() != "base image" || img.GetMetadata()["team"] != "infra" || img.GetLabels()[0] != "base" {
+		t.Errorf("unexpected accessor values: %+v", img)
+	}
+	if img.GetRequirements().VCPU != 2 {
+		t.Errorf("expected 2 vcpus, got %d", img.GetRequirements().VCPU)
+	}
+}
+
+func TestImageEnums_IsValid(t *testing.T) {
+	if !PlatformLinux.IsValid() || !PlatformWindows.IsValid() || Platform("bsd").IsValid() || Platform("").IsValid() {
+		t.Error("unexpected Platform.IsValid result")
+	}
+	if !ArchitectureX86_64.IsValid() || Architecture("arm64").IsValid() {
+		t.Error("unexpected Architecture.IsValid result")
+	}
+	if !LicenseLicensed.IsValid() || !LicenseUnlicensed.IsValid() || License("free").IsValid() {
+		t.Error("unexpected License.IsValid result")
+	}
+
+	var img CustomImage
+	if err := json.Unmarshal([]byte(`{"id": "img1", "platform": "bsd", "license": "licensed"}`), &img); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if img.Platform != "bsd" || img.Platform.IsValid() {
+		t.Errorf("expected unknown platform to be preserved and flagged, got %q", img.Platform)
+	}
+}
+
+func TestSupportedCustomImageOptions(t *testing.T) {
+	opts := SupportedCustomImageOptions()
+
+	for _, p := range opts.Platforms {
+		if !p.IsValid() {
+			t.Errorf("expected supported platform %q to be valid", p)
+		}
+	}
+	for _, a := range opts.Architectures {
+		if !a.IsValid() {
+			t.Errorf("expected supported architecture %q to be valid", a)
+		}
+	}
+	for _, l := range opts.Licenses {
+		if !l.IsValid() {
+			t.Errorf("expected supported license %q to be valid", l)
+		}
+	}
+	if len(opts.Platforms) != 2 || len(opts.Architectures) != 1 || len(opts.Licenses) != 2 {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	// Mutating the returned slices must not change validation.
+	opts.Platforms[0] = "bsd"
+	if !PlatformLinux.IsValid() {
+		t.Error("expected returned options to be a copy")
+	}
+}
+
+func TestImageService_Cache(t *testing.T) {
+	var listCalls, getCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/compute/v1/images":
+			listCalls.Add(1)
+			w.Write([]byte(`{"meta": {"page": {"offset": 0, "limit": 1, "count": 1, "total": 1}}, "images": [{"id": "img-1", "name": "ubuntu", "status": "active", "labels": ["lts"]}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/compute/v1/images/custom/img-2":
+			getCalls.Add(1)
+			w.Write([]byte(`{"id": "img-2", "name": "custom", "status": "active", "metadata": {"owner": "sdk"}}`))
+		case r.Method == http.MethodPatch:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	fake := clock.NewFake(time.Unix(0, 0))
+	vmClient := New(testClient(server.URL).CoreClient, WithImageCache(time.Minute, 10), withClock(fake))
+	ctx := context.Background()
+	svc := vmClient.Images()
+
+	first, err := svc.List(ctx, ImageListOptions{Limit: intPtr(1)})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	first.Images[0].Name = "changed"
+	(*first.Images[0].Labels)[0] = "changed"
+	second, err := svc.List(ctx, ImageListOptions{Limit: intPtr(1)})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if got := listCalls.Load(); got != 1 {
+		t.Errorf("List() requests = %d, want 1", got)
+	}
+	if second.Images[0].Name != "ubuntu" || second.Images[0].GetLabels()[0] != "lts" {
+		t.Errorf("cached image = %+v, want caller changes not to leak", second.Images[0])
+	}
+
+	if _, err := svc.List(ctx, ImageListOptions{Limit: intPtr(2)}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if got := listCalls.Load(); got != 2 {
+		t.Errorf("List() requests with other options = %d, want 2", got)
+	}
+
+	fake.Sleep(ctx, time.Minute)
+	if _, err := svc.List(ctx, ImageListOptions{Limit: intPtr(1)}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if got := listCalls.Load(); got != 3 {
+		t.Errorf("List() requests after TTL = %d, want 3", got)
+	}
+
+	for range 2 {
+		image, err := svc.GetCustom(ctx, "img-2")
+		if err != nil {
+			t.Fatalf("GetCustom() error = %v", err)
+		}
+		if owner := (*image.Metadata)["owner"]; owner != "sdk" {
+			t.Errorf("cached image owner = %v, want caller changes not to leak", owner)
+		}
+		(*image.Metadata)["owner"] = "changed"
+	}
+	if got := getCalls.Load(); got != 1 {
+		t.Errorf("GetCustom() requests = %d, want 1", got)
+	}
+	if err := svc.UpdateCustom(ctx, "img-2", UpdateCustomImageRequest{Description: strPtr("renamed")}); err != nil {
+		t.Fatalf("UpdateCustom() error = %v", err)
+	}
+	if _, err := svc.GetCustom(ctx, "img-2"); err != nil {
+		t.Fatalf("GetCustom() error = %v", err)
+	}
+	if got := getCalls.Load(); got != 2 {
+		t.Errorf("GetCustom() requests after UpdateCustom = %d, want 2", got)
+	}
+	if _, err := svc.List(ctx, ImageListOptions{Limit: intPtr(1)}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if got := listCalls.Load(); got != 4 {
+		t.Errorf("List() requests after UpdateCustom = %d, want 4", got)
+	}
+
+	vmClient.InvalidateImageCache()
+	if _, err := svc.List(ctx, ImageListOptions{Limit: intPtr(1)}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if _, err := svc.GetCustom(ctx, "img-2"); err != nil {
+		t.Fatalf("GetCustom() error = %v", err)
+	}
+	if listCalls.Load() != 5 || getCalls.Load() != 3 {
+		t.Errorf("requests after InvalidateImageCache = %d list, %d get; want 5, 3", listCalls.Load(), getCalls.Load())
+	}
+}
+
+func TestImageService_CacheConcurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta": {"page": {"offset": 0, "limit": 50, "count": 0, "total": 0}}, "images": []}`))
+	}))
+	defer server.Close()
+
+	vmClient := New(testClient(server.URL).CoreClient, WithImageCache(time.Minute, 2))
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := vmClient.Images().List(ctx, ImageListOptions{Offset: intPtr(i % 3)}); err != nil {
+				t.Errorf("concurrent List() error = %v", err)
+			}
+			if i%4 == 0 {
+				vmClient.InvalidateImageCache()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWithImageCache_Disabled(t *testing.T) {
+	vmClient := New(newTestCoreClient(), WithImageCache(0, 10))
+	if vmClient.imageCache != nil {
+		t.Error("WithImageCache(0, 10) should leave caching disabled")
+	}
+	vmClient.InvalidateImageCache()
+}
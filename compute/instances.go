@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
@@ -29,6 +31,9 @@ const (
 	VmInstanceHeaderVersion     = "1.1"
 )
 
+// BulkConcurrency is the number of instance operations BulkStop and BulkDelete run concurrently.
+const BulkConcurrency = 4
+
 // ListInstancesResponse represents the response from listing instances.
 type ListInstancesResponse struct {
 	Meta      Meta       `json:"meta"`
@@ -69,6 +74,35 @@ type Instance struct {
 	Error            *Error         `json:"error,omitempty"`
 }
 
+// Tags returns the labels of the instance written as "key=value" as a map of tag keys to
+// values. Labels without "=" are not tags and are left out.
+func (i Instance) Tags() map[string]string {
+	tags := make(map[string]string)
+	if i.Labels == nil {
+		return tags
+	}
+	for _, label := range *i.Labels {
+		if key, value, ok := strings.Cut(label, "="); ok {
+			tags[key] = value
+		}
+	}
+	return tags
+}
+
+// hasTags reports whether the instance has every tag of tags.
+func (i Instance) hasTags(tags map[string]string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	instanceTags := i.Tags()
+	for key, value := range tags {
+		if got, ok := instanceTags[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
 // Error represents an error that occurred with an instance.
 type Error struct {
 	Message string `json:"message"`
@@ -87,19 +121,100 @@ type CreateRequest struct {
 	UserData         *string                  `json:"user_data,omitempty"`
 }
 
+// Clone returns a deep copy of the request, sharing no pointers, slices or maps with it,
+// so a template can be copied and changed without affecting other copies.
+func (r CreateRequest) Clone() CreateRequest {
+	clone := r
+	clone.AvailabilityZone = clonePtr(r.AvailabilityZone)
+	clone.Image = r.Image.clone()
+	clone.Labels = cloneSlicePtr(r.Labels)
+	clone.MachineType = r.MachineType.clone()
+	clone.Network = r.Network.clone()
+	clone.SshKeyName = clonePtr(r.SshKeyName)
+	clone.UserData = clonePtr(r.UserData)
+	return clone
+}
+
+// Validate checks the request before it is sent, returning a *client.ValidationError
+// for a single failure, a client.ValidationErrors with every failure when there are
+// several, or nil when the request is valid.
+func (r CreateRequest) Validate() error {
+	var errs client.ValidationErrors
+	errs = r.Network.validate(errs)
+	return errs.Err()
+}
+
 // CreateParametersNetwork represents network configuration for instance creation.
+// When omitted, the instance is placed in the default VPC of the tenant.
 type CreateParametersNetwork struct {
-	AssociatePublicIp *bool                             `json:"associate_public_ip,omitempty"`
-	Interface         *CreateParametersNetworkInterface `json:"interface,omitempty"`
-	Vpc               *IDOrName                         `json:"vpc,omitempty"`
+	AssociatePublicIp *bool `json:"associate_public_ip,omitempty"`
+	// Interface attaches an existing network interface (port) as the primary interface,
+	// placing the instance in the subnet of that interface.
+	Interface *CreateParametersNetworkInterface `json:"interface,omitempty"`
+	// Vpc places the instance in a VPC other than the default one, by ID or name.
+	Vpc *IDOrName `json:"vpc,omitempty"`
 }
 
 // CreateParametersNetworkInterface represents network interface configuration.
+// At least one of ID or SecurityGroups must be set.
 type CreateParametersNetworkInterface struct {
 	ID             *string                                   `json:"id,omitempty"`
 	SecurityGroups *[]CreateParametersNetworkInterfaceWithID `json:"security_groups,omitempty"`
 }
 
+// clone returns a deep copy of the network configuration, or nil when n is nil.
+func (n *CreateParametersNetwork) clone() *CreateParametersNetwork {
+	if n == nil {
+		return nil
+	}
+
+	clone := &CreateParametersNetwork{AssociatePublicIp: clonePtr(n.AssociatePublicIp)}
+	if n.Interface != nil {
+		clone.Interface = &CreateParametersNetworkInterface{
+			ID:             clonePtr(n.Interface.ID),
+			SecurityGroups: cloneSlicePtr(n.Interface.SecurityGroups),
+		}
+	}
+	if n.Vpc != nil {
+		vpc := n.Vpc.clone()
+		clone.Vpc = &vpc
+	}
+	return clone
+}
+
+// validate appends a failure to errs for every network identifier given that is empty.
+func (n *CreateParametersNetwork) validate(errs client.ValidationErrors) client.ValidationErrors {
+	if n == nil {
+		return errs
+	}
+
+	if n.Vpc != nil && n.Vpc.isEmpty() {
+		errs = append(errs, client.ValidationError{Field: "network.vpc", Message: "must have an ID or a name"})
+	}
+
+	if n.Interface == nil {
+		return errs
+	}
+
+	if n.Interface.ID == nil && n.Interface.SecurityGroups == nil {
+		errs = append(errs, client.ValidationError{Field: "network.interface", Message: "must have an ID or security groups"})
+	}
+
+	if n.Interface.ID != nil && *n.Interface.ID == "" {
+		errs = append(errs, client.ValidationError{Field: "network.interface.id", Message: "cannot be empty"})
+	}
+
+	if n.Interface.SecurityGroups != nil {
+		for i, group := range *n.Interface.SecurityGroups {
+			if group.ID == "" {
+				errs = append(errs, client.ValidationError{Field: fmt.Sprintf("network.interface.security_groups[%d].id", i), Message: "cannot be empty"})
+			}
+		}
+	}
+
+	return errs
+}
+
 // CreateParametersNetworkInterfaceWithID represents a security group item.
 type CreateParametersNetworkInterfaceWithID struct {
 	ID string `json:"id"`
@@ -111,6 +226,45 @@ type IDOrName struct {
 	Name *string `json:"name,omitempty,omitzero"`
 }
 
+// clone returns a copy of r that does not share its pointers.
+func (r IDOrName) clone() IDOrName {
+	return IDOrName{ID: clonePtr(r.ID), Name: clonePtr(r.Name)}
+}
+
+// clonePtr returns a pointer to a copy of the value p points to, or nil when p is nil.
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// cloneSlicePtr returns a pointer to a copy of the slice p points to, or nil when p is nil.
+func cloneSlicePtr[T any](p *[]T) *[]T {
+	if p == nil {
+		return nil
+	}
+	v := slices.Clone(*p)
+	return &v
+}
+
+// isEmpty reports whether neither a non-empty ID nor a non-empty name is set.
+func (r IDOrName) isEmpty() bool {
+	return (r.ID == nil || *r.ID == "") && (r.Name == nil || *r.Name == "")
+}
+
+// DeleteInstanceOptions controls which resources are removed along with an instance.
+// The zero value matches the API default: the public IP and any attached volumes
+// outlive the instance and must be deleted separately.
+type DeleteInstanceOptions struct {
+	// DeletePublicIP releases the public IP associated with the instance.
+	DeletePublicIP bool
+	// DeleteVolumes deletes the volumes attached to the instance. The delete_volumes
+	// parameter is only sent when it is true.
+	DeleteVolumes bool
+}
+
 // UpdateNameRequest represents the request to update an instance name.
 type UpdateNameRequest struct {
 	Name string `json:"name"`
@@ -172,22 +326,43 @@ type InitLogResponse struct {
 	Logs []string `json:"logs"`
 }
 
+// InstanceConsole represents a time-limited URL to the console of an instance.
+type InstanceConsole struct {
+	URL string `json:"url"`
+	// ExpiresAt is when the URL stops working, if the API reports it.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// InstanceOpResult is the outcome of a bulk operation for one instance.
+// Err is nil when the operation succeeded.
+type InstanceOpResult struct {
+	ID  string
+	Err error
+}
+
 // InstanceService provides operations for managing virtual machine instances.
 type InstanceService interface {
 	List(ctx context.Context, opts ListOptions) (*ListInstancesResponse, error)
 	ListAll(ctx context.Context, opts InstanceFilterOptions) ([]Instance, error)
 	Create(ctx context.Context, req CreateRequest) (string, error)
+	CreateAndWait(ctx context.Context, req CreateRequest, opts ...WaitOption) (*Instance, error)
 	Get(ctx context.Context, id string, expand []InstanceExpand) (*Instance, error)
-	Delete(ctx context.Context, id string, deletePublicIP bool) error
+	Delete(ctx context.Context, id string, opts DeleteInstanceOptions) error
 	Rename(ctx context.Context, id string, newName string) error
 	Retype(ctx context.Context, id string, req RetypeRequest) error
 	Start(ctx context.Context, id string) error
 	Stop(ctx context.Context, id string) error
+	BulkStop(ctx context.Context, ids []string) []InstanceOpResult
+	BulkDelete(ctx context.Context, ids []string, opts DeleteInstanceOptions) []InstanceOpResult
 	Suspend(ctx context.Context, id string) error
 	GetFirstWindowsPassword(ctx context.Context, id string) (*WindowsPasswordResponse, error)
+	GetPassword(ctx context.Context, id string, privateKeyPEM []byte) (string, error)
 	AttachNetworkInterface(ctx context.Context, req NICRequest) error
 	DetachNetworkInterface(ctx context.Context, req NICRequest) error
 	InitLog(ctx context.Context, id string, maxLines *int) (*InitLogResponse, error)
+	Console(ctx context.Context, id string) (*InstanceConsole, error)
+	ConsoleURL(ctx context.Context, id string) (string, error)
+	CreateSnapshot(ctx context.Context, id string, name string) (string, error)
 }
 
 // instanceService implements the InstanceService interface.
@@ -196,19 +371,27 @@ type instanceService struct {
 }
 
 // ListOptions defines the parameters for filtering and pagination of instance lists.
+// Status, Name and AvailabilityZone are applied server-side.
 type ListOptions struct {
-	Limit  *int
-	Offset *int
-	Sort   *string
-	Expand []InstanceExpand
-	Name   *string
+	Limit            *int
+	Offset           *int
+	Sort             *string
+	Expand           []InstanceExpand
+	Name             *string
+	Status           *string
+	AvailabilityZone *string
 }
 
 // InstanceFilterOptions defines filtering options for ListAll (without pagination)
 type InstanceFilterOptions struct {
-	Sort   *string
-	Expand []InstanceExpand
-	Name   *string
+	Sort             *string
+	Expand           []InstanceExpand
+	Name             *string
+	Status           *string
+	AvailabilityZone *string
+	// Tags keeps only the instances having all of these tags, labels written as "key=value".
+	// The API cannot filter by labels, so tags are matched client-side on every page listed.
+	Tags map[string]string
 }
 
 // List retrieves instances with pagination metadata.
@@ -243,6 +426,12 @@ func (s *instanceService) List(ctx context.Context, opts ListOptions) (*ListInst
 	if opts.Name != nil {
 		q.Add("name", *opts.Name)
 	}
+	if opts.Status != nil {
+		q.Add("status", *opts.Status)
+	}
+	if opts.AvailabilityZone != nil {
+		q.Add("availability-zone", *opts.AvailabilityZone)
+	}
 
 	req.URL.RawQuery = q.Encode()
 
@@ -257,6 +446,7 @@ func (s *instanceService) List(ctx context.Context, opts ListOptions) (*ListInst
 
 // ListAll retrieves all instances across all pages with optional filtering.
 // This method automatically handles pagination and returns all results.
+// Tags are matched client-side, after each page is fetched.
 func (s *instanceService) ListAll(ctx context.Context, opts InstanceFilterOptions) ([]Instance, error) {
 	var allInstances []Instance
 	offset := 0
@@ -266,11 +456,13 @@ func (s *instanceService) ListAll(ctx context.Context, opts InstanceFilterOption
 		currentOffset := offset
 		currentLimit := limit
 		listOpts := ListOptions{
-			Offset: &currentOffset,
-			Limit:  &currentLimit,
-			Sort:   opts.Sort,
-			Expand: opts.Expand,
-			Name:   opts.Name,
+			Offset:           &currentOffset,
+			Limit:            &currentLimit,
+			Sort:             opts.Sort,
+			Expand:           opts.Expand,
+			Name:             opts.Name,
+			Status:           opts.Status,
+			AvailabilityZone: opts.AvailabilityZone,
 		}
 
 		response, err := s.List(ctx, listOpts)
@@ -278,7 +470,11 @@ func (s *instanceService) ListAll(ctx context.Context, opts InstanceFilterOption
 			return nil, err
 		}
 
-		allInstances = append(allInstances, response.Instances...)
+		for _, instance := range response.Instances {
+			if instance.hasTags(opts.Tags) {
+				allInstances = append(allInstances, instance)
+			}
+		}
 
 		// Check if we've retrieved all results
 		if len(response.Instances) < limit {
@@ -294,7 +490,12 @@ func (s *instanceService) ListAll(ctx context.Context, opts InstanceFilterOption
 // Create creates a new instance.
 // This method makes an HTTP request to provision a new virtual machine instance
 // and returns the ID of the created instance.
+// Returns the error of createReq.Validate if createReq is not valid.
 func (s *instanceService) Create(ctx context.Context, createReq CreateRequest) (string, error) {
+	if err := createReq.Validate(); err != nil {
+		return "", err
+	}
+
 	res, err := mgc_http.ExecuteSimpleRequestWithRespBody[struct{ ID string }](
 		ctx,
 		s.client.newRequest,
@@ -310,6 +511,19 @@ func (s *instanceService) Create(ctx context.Context, createReq CreateRequest) (
 	return res.ID, nil
 }
 
+// CreateAndWait creates a new instance and polls it until it is running,
+// returning the full instance. It returns an InstanceFailedError if the instance
+// reaches an error status, and the context error if ctx is done or the wait times out;
+// in both cases the instance may still exist and must be deleted by the caller.
+func (s *instanceService) CreateAndWait(ctx context.Context, createReq CreateRequest, opts ...WaitOption) (*Instance, error) {
+	id, err := s.Create(ctx, createReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.waitForInstance(ctx, id, isRunning, opts)
+}
+
 // Get retrieves a specific instance.
 // This method makes an HTTP request to get detailed information about an instance
 // and optionally expands related resources.
@@ -342,19 +556,29 @@ func (s *instanceService) Get(ctx context.Context, id string, expand []InstanceE
 
 // Delete removes an instance.
 // This method makes an HTTP request to terminate and remove an instance.
-// If deletePublicIP is true, any associated public IP will also be released.
-func (s *instanceService) Delete(ctx context.Context, id string, deletePublicIP bool) error {
+// Returns an InstanceNotFoundError if the instance does not exist.
+func (s *instanceService) Delete(ctx context.Context, id string, opts DeleteInstanceOptions) error {
+	if id == "" {
+		return &client.ValidationError{Field: "id", Message: "cannot be empty"}
+	}
+
 	req, err := s.client.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/v1/instances/%s", id), nil)
 	if err != nil {
 		return err
 	}
 
 	q := req.URL.Query()
-	q.Add("delete_public_ip", strconv.FormatBool(deletePublicIP))
+	q.Add("delete_public_ip", strconv.FormatBool(opts.DeletePublicIP))
+	if opts.DeleteVolumes {
+		q.Add("delete_volumes", "true")
+	}
 	req.URL.RawQuery = q.Encode()
 
 	_, err = mgc_http.Do[any](s.client.GetConfig(), ctx, req, nil)
 	if err != nil {
+		if isNotFound(err) {
+			return &InstanceNotFoundError{ID: id, Err: err}
+		}
 		return err
 	}
 	return nil
@@ -412,6 +636,52 @@ func (s *instanceService) Stop(ctx context.Context, id string) error {
 	return s.executeInstanceAction(ctx, id, "stop")
 }
 
+// BulkStop stops every instance of ids, running up to BulkConcurrency operations at once.
+// A failure does not stop the others: the results, in the order of ids, carry the error of
+// each instance. Instances not stopped yet when ctx is done fail with the context error.
+func (s *instanceService) BulkStop(ctx context.Context, ids []string) []InstanceOpResult {
+	return runBulk(ctx, ids, s.Stop)
+}
+
+// BulkDelete deletes every instance of ids with the given options, running up to
+// BulkConcurrency operations at once. A failure does not stop the others: the results,
+// in the order of ids, carry the error of each instance, such as an InstanceNotFoundError.
+// Instances not deleted yet when ctx is done fail with the context error.
+func (s *instanceService) BulkDelete(ctx context.Context, ids []string, opts DeleteInstanceOptions) []InstanceOpResult {
+	return runBulk(ctx, ids, func(ctx context.Context, id string) error {
+		return s.Delete(ctx, id, opts)
+	})
+}
+
+// runBulk runs op for every ID with a bounded worker pool and collects the results in order.
+func runBulk(ctx context.Context, ids []string, op func(ctx context.Context, id string) error) []InstanceOpResult {
+	results := make([]InstanceOpResult, len(ids))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(BulkConcurrency, len(ids)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				err := ctx.Err()
+				if err == nil {
+					err = op(ctx, ids[i])
+				}
+				results[i] = InstanceOpResult{ID: ids[i], Err: err}
+			}
+		}()
+	}
+
+	for i := range ids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
 // Suspend suspends the instance.
 // This method makes an HTTP request to pause the execution of an instance
 // while maintaining its state in memory.
@@ -461,6 +731,31 @@ func (s *instanceService) GetFirstWindowsPassword(ctx context.Context, id string
 	return result, nil
 }
 
+// GetPassword returns the administrator password of a Windows instance in plain text.
+// The password is encrypted by the API with the public part of the instance's SSH key;
+// privateKeyPEM is the matching RSA private key, in PKCS#1 or PKCS#8 PEM format.
+// Returns a PasswordNotReadyError while the instance has not generated the password yet
+// and an InstanceNotFoundError if the instance does not exist.
+func (s *instanceService) GetPassword(ctx context.Context, id string, privateKeyPEM []byte) (string, error) {
+	key, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := s.GetFirstWindowsPassword(ctx, id)
+	if err != nil {
+		if isNotFound(err) {
+			return "", &InstanceNotFoundError{ID: id, Err: err}
+		}
+		return "", err
+	}
+	if result.Instance.Password == "" {
+		return "", &PasswordNotReadyError{ID: id}
+	}
+
+	return decryptPassword(result.Instance.Password, key)
+}
+
 // AttachNetworkInterface connects a network interface to an instance.
 // This method makes an HTTP request to attach a network interface to an instance.
 func (s *instanceService) AttachNetworkInterface(ctx context.Context, req NICRequest) error {
@@ -514,3 +809,52 @@ func (s *instanceService) InitLog(ctx context.Context, id string, maxLines *int)
 	}
 	return resp, nil
 }
+
+// Console requests a time-limited URL to the VNC console of an instance, useful to debug
+// an instance that does not respond over the network.
+// Returns an InstanceNotFoundError if the instance does not exist.
+func (s *instanceService) Console(ctx context.Context, id string) (*InstanceConsole, error) {
+	if id == "" {
+		return nil, &client.ValidationError{Field: "id", Message: "cannot be empty"}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/v1/instances/%s/console", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := mgc_http.Do(s.client.GetConfig(), ctx, req, &InstanceConsole{})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, &InstanceNotFoundError{ID: id, Err: err}
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ConsoleURL returns the URL of the console of an instance. Use Console to also learn
+// when the URL expires.
+func (s *instanceService) ConsoleURL(ctx context.Context, id string) (string, error) {
+	console, err := s.Console(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return console.URL, nil
+}
+
+// CreateSnapshot creates a snapshot of the instance.
+// This method delegates to the snapshot service and returns the ID of the created snapshot.
+func (s *instanceService) CreateSnapshot(ctx context.Context, id string, name string) (string, error) {
+	if id == "" {
+		return "", &client.ValidationError{Field: "id", Message: "cannot be empty"}
+	}
+	if name == "" {
+		return "", &client.ValidationError{Field: "name", Message: "cannot be empty"}
+	}
+
+	return s.client.Snapshots().Create(ctx, CreateSnapshotRequest{
+		Name:     name,
+		Instance: IDOrName{ID: &id},
+	})
+}
@@ -2,15 +2,26 @@ package compute
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/helpers"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/clock"
 )
 
 func TestInstanceService_List(t *testing.T) {
@@ -262,6 +273,98 @@ func TestInstanceService_ListAll(t *testing.T) {
 	}
 }
 
+func TestInstanceService_ListAll_Tags(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Tags are not sent, as the API cannot filter by labels
+		want := url.Values{"_limit": {"50"}, "_offset": {"0"}, "status": {"running"}}
+		if !reflect.DeepEqual(r.URL.Query(), want) {
+			t.Errorf("expected query %v, got %v", want, r.URL.Query())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"meta": {"page": {"offset": 0, "limit": 50, "count": 4, "total": 4}},
+			"instances": [
+				{"id": "inst1", "labels": ["env=prod", "owner=payments", "critical"]},
+				{"id": "inst2", "labels": ["env=prod", "owner=search"]},
+				{"id": "inst3", "labels": ["env=staging", "owner=payments"]},
+				{"id": "inst4"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	instances, err := testClient(server.URL).Instances().ListAll(context.Background(), InstanceFilterOptions{
+		Status: strPtr("running"),
+		Tags:   map[string]string{"env": "prod", "owner": "payments"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(instances) != 1 || instances[0].ID != "inst1" {
+		t.Errorf("expected only inst1, got %+v", instances)
+	}
+}
+
+func TestInstance_Tags(t *testing.T) {
+	t.Parallel()
+	instance := Instance{Labels: &[]string{"env=prod", "critical", "note=a=b", "empty="}}
+
+	want := map[string]string{"env": "prod", "note": "a=b", "empty": ""}
+	if got := instance.Tags(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected tags %v, got %v", want, got)
+	}
+	if got := (Instance{}).Tags(); len(got) != 0 {
+		t.Errorf("expected no tags, got %v", got)
+	}
+}
+
+func TestInstanceService_ListFilters(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("status"); got != "running" {
+			t.Errorf("expected status=running, got %q", got)
+		}
+		if got := query.Get("name"); got != "web" {
+			t.Errorf("expected name=web, got %q", got)
+		}
+		if got := query.Get("availability-zone"); got != "br-se1-a" {
+			t.Errorf("expected availability-zone=br-se1-a, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta": {"page": {"offset": 0, "limit": 50, "count": 1, "total": 1}}, "instances": [{"id": "inst1", "name": "web", "state": "running"}]}`))
+	}))
+	defer server.Close()
+
+	instances := testClient(server.URL).Instances()
+
+	list, err := instances.List(context.Background(), ListOptions{
+		Name:             strPtr("web"),
+		Status:           strPtr("running"),
+		AvailabilityZone: strPtr("br-se1-a"),
+	})
+	if err != nil {
+		t.Fatalf("List() unexpected error: %v", err)
+	}
+	if len(list.Instances) != 1 {
+		t.Errorf("List() got %d instances, want 1", len(list.Instances))
+	}
+
+	all, err := instances.ListAll(context.Background(), InstanceFilterOptions{
+		Name:             strPtr("web"),
+		Status:           strPtr("running"),
+		AvailabilityZone: strPtr("br-se1-a"),
+	})
+	if err != nil {
+		t.Fatalf("ListAll() unexpected error: %v", err)
+	}
+	if len(all) != 1 {
+		t.Errorf("ListAll() got %d instances, want 1", len(all))
+	}
+}
+
 func TestInstanceService_Create(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -352,6 +455,237 @@ func TestInstanceService_Create(t *testing.T) {
 	}
 }
 
+func TestInstanceService_Create_Network(t *testing.T) {
+	t.Parallel()
+	network := &CreateParametersNetwork{
+		Vpc: &IDOrName{ID: strPtr("vpc-1")},
+		Interface: &CreateParametersNetworkInterface{
+			ID:             strPtr("port-1"),
+			SecurityGroups: &[]CreateParametersNetworkInterfaceWithID{{ID: "sg-1"}, {ID: "sg-2"}},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("error decoding request: %v", err)
+		}
+		want := map[string]any{
+			"vpc": map[string]any{"id": "vpc-1"},
+			"interface": map[string]any{
+				"id":              "port-1",
+				"security_groups": []any{map[string]any{"id": "sg-1"}, map[string]any{"id": "sg-2"}},
+			},
+		}
+		if !reflect.DeepEqual(body["network"], want) {
+			t.Errorf("expected network %v, got %v", want, body["network"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "inst1"}`))
+	}))
+	defer server.Close()
+
+	id, err := testClient(server.URL).Instances().Create(context.Background(), CreateRequest{Name: "test-vm", Network: network})
+	if err != nil || id != "inst1" {
+		t.Errorf("Create() = %q, %v", id, err)
+	}
+}
+
+func TestCreateRequest_Validate(t *testing.T) {
+	t.Parallel()
+	req := CreateRequest{
+		Name: "test-vm",
+		Network: &CreateParametersNetwork{
+			Vpc: &IDOrName{},
+			Interface: &CreateParametersNetworkInterface{
+				ID:             strPtr(""),
+				SecurityGroups: &[]CreateParametersNetworkInterfaceWithID{{}},
+			},
+		},
+	}
+
+	var errs client.ValidationErrors
+	if !errors.As(req.Validate(), &errs) {
+		t.Fatalf("Validate() = %v, want client.ValidationErrors", req.Validate())
+	}
+	var fields []string
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	want := []string{"network.vpc", "network.interface.id", "network.interface.security_groups[0].id"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("Validate() failed fields = %v, want %v", fields, want)
+	}
+
+	if err := (CreateRequest{Name: "test-vm"}).Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestInstanceService_Create_InvalidNetwork(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		network   *CreateParametersNetwork
+		wantField string
+	}{
+		{
+			name:      "empty vpc",
+			network:   &CreateParametersNetwork{Vpc: &IDOrName{ID: strPtr("")}},
+			wantField: "network.vpc",
+		},
+		{
+			name:      "empty interface",
+			network:   &CreateParametersNetwork{Interface: &CreateParametersNetworkInterface{}},
+			wantField: "network.interface",
+		},
+		{
+			name:      "empty interface ID",
+			network:   &CreateParametersNetwork{Interface: &CreateParametersNetworkInterface{ID: strPtr("")}},
+			wantField: "network.interface.id",
+		},
+		{
+			name: "empty security group ID",
+			network: &CreateParametersNetwork{Interface: &CreateParametersNetworkInterface{
+				SecurityGroups: &[]CreateParametersNetworkInterfaceWithID{{ID: "sg-1"}, {}},
+			}},
+			wantField: "network.interface.security_groups[1].id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Error("unexpected request")
+			}))
+			defer server.Close()
+
+			_, err := testClient(server.URL).Instances().Create(context.Background(), CreateRequest{Name: "test-vm", Network: tt.network})
+
+			var validErr *client.ValidationError
+			if !errors.As(err, &validErr) || validErr.Field != tt.wantField {
+				t.Errorf("Create() error = %v, want ValidationError for %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestInstanceService_CreateAndWait(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		states    []string
+		wantState string
+		wantPolls int
+		wantErr   bool
+	}{
+		{
+			name:      "running after provisioning",
+			states:    []string{`"stopped", "status": "provisioning"`, `"running", "status": "creating"`, `"running", "status": "completed"`},
+			wantState: InstanceStateRunning,
+			wantPolls: 3,
+		},
+		{
+			name:      "already running",
+			states:    []string{`"running", "status": "completed"`},
+			wantState: InstanceStateRunning,
+			wantPolls: 1,
+		},
+		{
+			name:      "creation error",
+			states:    []string{`"stopped", "status": "provisioning"`, `"stopped", "status": "creating_error", "error": {"message": "no capacity", "slug": "capacity"}`},
+			wantPolls: 2,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodPost {
+					w.Write([]byte(`{"id": "inst1"}`))
+					return
+				}
+				if r.URL.Path != "/compute/v1/instances/inst1" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				state := tt.states[min(polls, len(tt.states)-1)]
+				polls++
+				fmt.Fprintf(w, `{"id": "inst1", "state": %s}`, state)
+			}))
+			defer server.Close()
+
+			fake := clock.NewFake(time.Unix(0, 0))
+			vmClient := New(testClient(server.URL).CoreClient, withClock(fake))
+
+			instance, err := vmClient.Instances().CreateAndWait(context.Background(), CreateRequest{Name: "test-vm"}, WithWaitInterval(time.Second))
+			if polls != tt.wantPolls {
+				t.Errorf("expected %d polls, got %d", tt.wantPolls, polls)
+			}
+			if len(fake.Sleeps()) != tt.wantPolls-1 {
+				t.Errorf("expected %d sleeps, got %v", tt.wantPolls-1, fake.Sleeps())
+			}
+
+			if tt.wantErr {
+				var failedErr *InstanceFailedError
+				if !errors.As(err, &failedErr) {
+					t.Fatalf("expected *InstanceFailedError, got %v", err)
+				}
+				if failedErr.ID != "inst1" || failedErr.Reason == nil || failedErr.Reason.Slug != "capacity" {
+					t.Errorf("unexpected error details: %+v", failedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if instance.ID != "inst1" || instance.State != tt.wantState {
+				t.Errorf("unexpected instance: %+v", instance)
+			}
+		})
+	}
+}
+
+func TestInstanceService_CreateAndWait_Timeout(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{"id": "inst1"}`))
+			return
+		}
+		w.Write([]byte(`{"id": "inst1", "state": "stopped", "status": "provisioning"}`))
+	}))
+	defer server.Close()
+
+	_, err := testClient(server.URL).Instances().CreateAndWait(context.Background(), CreateRequest{Name: "test-vm"},
+		WithWaitTimeout(50*time.Millisecond), WithWaitInterval(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestInstanceService_CreateAndWait_CreateError(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected poll after failed creation")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "name is required"}`))
+	}))
+	defer server.Close()
+
+	_, err := testClient(server.URL).Instances().CreateAndWait(context.Background(), CreateRequest{})
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
 func TestInstanceService_Get(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -445,32 +779,31 @@ func TestInstanceService_Get(t *testing.T) {
 func TestInstanceService_Delete(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
-		name           string
-		id             string
-		deletePublicIP bool
-		statusCode     int
-		response       string
-		wantErr        bool
+		name       string
+		id         string
+		opts       DeleteInstanceOptions
+		statusCode int
+		response   string
+		wantErr    bool
 	}{
 		{
-			name:           "successful delete",
-			id:             "inst1",
-			deletePublicIP: true,
-			statusCode:     http.StatusNoContent,
-			wantErr:        false,
+			name:       "successful delete",
+			id:         "inst1",
+			opts:       DeleteInstanceOptions{DeletePublicIP: true},
+			statusCode: http.StatusNoContent,
+			wantErr:    false,
 		},
 		{
-			name:           "not found",
-			id:             "invalid",
-			deletePublicIP: false,
-			statusCode:     http.StatusNotFound,
-			wantErr:        true,
+			name:       "delete with volumes",
+			id:         "inst1",
+			opts:       DeleteInstanceOptions{DeletePublicIP: true, DeleteVolumes: true},
+			statusCode: http.StatusNoContent,
+			wantErr:    false,
 		},
 		{
-			name:       "empty id",
-			id:         "",
-			response:   `{"error": "id is required"}`,
-			statusCode: http.StatusBadRequest,
+			name:       "not found",
+			id:         "invalid",
+			statusCode: http.StatusNotFound,
 			wantErr:    true,
 		},
 		{
@@ -485,16 +818,19 @@ func TestInstanceService_Delete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				if r.URL.Query().Get("delete_public_ip") != strconv.FormatBool(tt.deletePublicIP) {
+				if r.URL.Query().Get("delete_public_ip") != strconv.FormatBool(tt.opts.DeletePublicIP) {
 					t.Errorf("unexpected delete_public_ip query param: got %v", r.URL.Query().Get("delete_public_ip"))
 				}
+				if r.URL.Query().Has("delete_volumes") != tt.opts.DeleteVolumes {
+					t.Errorf("unexpected delete_volumes query param: got %q", r.URL.RawQuery)
+				}
 				w.WriteHeader(tt.statusCode)
 				w.Write([]byte(tt.response))
 			}))
 			defer server.Close()
 
 			client := testClient(server.URL)
-			err := client.Instances().Delete(context.Background(), tt.id, tt.deletePublicIP)
+			err := client.Instances().Delete(context.Background(), tt.id, tt.opts)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -502,6 +838,39 @@ func TestInstanceService_Delete(t *testing.T) {
 	}
 }
 
+func TestInstanceService_Delete_NotFound(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "instance not found"}`))
+	}))
+	defer server.Close()
+
+	err := testClient(server.URL).Instances().Delete(context.Background(), "missing", DeleteInstanceOptions{})
+
+	var notFound *InstanceNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected *InstanceNotFoundError, got %T", err)
+	}
+	if notFound.ID != "missing" {
+		t.Errorf("expected ID missing, got %s", notFound.ID)
+	}
+	var httpErr *client.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected wrapped 404 *client.HTTPError, got %v", err)
+	}
+}
+
+func TestInstanceService_Delete_EmptyID(t *testing.T) {
+	t.Parallel()
+	err := testClient("http://unused").Instances().Delete(context.Background(), "", DeleteInstanceOptions{})
+
+	var validErr *client.ValidationError
+	if !errors.As(err, &validErr) || validErr.Field != "id" {
+		t.Errorf("expected id *client.ValidationError, got %v", err)
+	}
+}
+
 func TestInstanceService_Rename(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -1420,3 +1789,367 @@ func TestInstanceService_InitLog(t *testing.T) {
 		})
 	}
 }
+
+func TestInstanceService_CreateSnapshot(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		id           string
+		snapshotName string
+		response     string
+		statusCode   int
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "successful creation",
+			id:           "inst1",
+			snapshotName: "backup",
+			response:     `{"id": "snap1"}`,
+			statusCode:   http.StatusOK,
+			want:         "snap1",
+			wantErr:      false,
+		},
+		{
+			name:         "empty instance id",
+			id:           "",
+			snapshotName: "backup",
+			wantErr:      true,
+		},
+		{
+			name:         "empty snapshot name",
+			id:           "inst1",
+			snapshotName: "",
+			wantErr:      true,
+		},
+		{
+			name:         "bad request",
+			id:           "inst1",
+			snapshotName: "backup",
+			response:     `{"error": "instance not found"}`,
+			statusCode:   http.StatusBadRequest,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/compute/v1/snapshots" {
+					t.Errorf("expected path /compute/v1/snapshots, got %s", r.URL.Path)
+				}
+
+				var req CreateSnapshotRequest
+				json.NewDecoder(r.Body).Decode(&req)
+				if req.Name != tt.snapshotName || req.Instance.ID == nil || *req.Instance.ID != tt.id {
+					t.Errorf("unexpected request body: %+v", req)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := testClient(server.URL)
+			got, err := client.Instances().CreateSnapshot(context.Background(), tt.id, tt.snapshotName)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateSnapshot() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CreateSnapshot() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceService_Console(t *testing.T) {
+	t.Parallel()
+	expiresAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		response      string
+		wantExpiresAt *time.Time
+	}{
+		{
+			name:          "with expiry",
+			response:      `{"url": "https://console.example.com/vnc?token=abc", "expires_at": "2024-01-01T12:00:00Z"}`,
+			wantExpiresAt: &expiresAt,
+		},
+		{
+			name:     "without expiry",
+			response: `{"url": "https://console.example.com/vnc?token=abc"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/compute/v1/instances/inst1/console" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			instances := testClient(server.URL).Instances()
+			console, err := instances.Console(context.Background(), "inst1")
+			if err != nil {
+				t.Fatalf("Console() error = %v", err)
+			}
+			if console.URL != "https://console.example.com/vnc?token=abc" {
+				t.Errorf("Console() URL = %q", console.URL)
+			}
+			if (console.ExpiresAt == nil) != (tt.wantExpiresAt == nil) ||
+				(console.ExpiresAt != nil && !console.ExpiresAt.Equal(*tt.wantExpiresAt)) {
+				t.Errorf("Console() ExpiresAt = %v, want %v", console.ExpiresAt, tt.wantExpiresAt)
+			}
+
+			url, err := instances.ConsoleURL(context.Background(), "inst1")
+			if err != nil || url != console.URL {
+				t.Errorf("ConsoleURL() = %q, %v, want %q", url, err, console.URL)
+			}
+		})
+	}
+}
+
+func TestInstanceService_Console_Errors(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "instance not found"}`))
+	}))
+	defer server.Close()
+
+	instances := testClient(server.URL).Instances()
+
+	_, err := instances.ConsoleURL(context.Background(), "missing")
+	var notFound *InstanceNotFoundError
+	if !errors.As(err, &notFound) || notFound.ID != "missing" {
+		t.Errorf("ConsoleURL() error = %v, want InstanceNotFoundError for missing", err)
+	}
+
+	_, err = instances.ConsoleURL(context.Background(), "")
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("ConsoleURL() error = %v, want ValidationError", err)
+	}
+}
+
+func TestInstanceService_GetPassword(t *testing.T) {
+	t.Parallel()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	ciphertext, err := rsa.EncryptPKCS1v15(rand.Reader, &key.PublicKey, []byte("s3cr3t-P@ss"))
+	if err != nil {
+		t.Fatalf("EncryptPKCS1v15() error = %v", err)
+	}
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(key)
+
+	pkcs1PEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	pkcs8PEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})
+	encrypted := base64.StdEncoding.EncodeToString(ciphertext)
+
+	tests := []struct {
+		name       string
+		key        []byte
+		statusCode int
+		password   string
+		want       string
+		wantErr    any
+	}{
+		{name: "PKCS#1 key", key: pkcs1PEM, statusCode: http.StatusOK, password: encrypted, want: "s3cr3t-P@ss"},
+		{name: "PKCS#8 key", key: pkcs8PEM, statusCode: http.StatusOK, password: encrypted, want: "s3cr3t-P@ss"},
+		{name: "not ready", key: pkcs1PEM, statusCode: http.StatusOK, wantErr: new(*PasswordNotReadyError)},
+		{name: "not found", key: pkcs1PEM, statusCode: http.StatusNotFound, wantErr: new(*InstanceNotFoundError)},
+		{name: "invalid key", key: []byte("not a key"), wantErr: new(*client.ValidationError)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/compute/v1/instances/config/inst1/first-windows-password" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				fmt.Fprintf(w, `{"instance": {"id": "inst1", "password": %q, "user": "Administrator"}}`, tt.password)
+			}))
+			defer server.Close()
+
+			got, err := testClient(server.URL).Instances().GetPassword(context.Background(), "inst1", tt.key)
+			if tt.wantErr != nil {
+				if !errors.As(err, tt.wantErr) {
+					t.Errorf("GetPassword() error = %v, want %T", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("GetPassword() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceService_BulkStop(t *testing.T) {
+	t.Parallel()
+	var running, maxRunning atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path == "/compute/v1/instances/inst2/stop" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"message": "instance already stopped"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ids := []string{"inst1", "inst2", "", "inst4", "inst5", "inst6", "inst7", "inst8"}
+	results := testClient(server.URL).Instances().BulkStop(context.Background(), ids)
+
+	if len(results) != len(ids) {
+		t.Fatalf("expected %d results, got %d", len(ids), len(results))
+	}
+	for i, result := range results {
+		if result.ID != ids[i] {
+			t.Errorf("result %d: expected ID %q, got %q", i, ids[i], result.ID)
+		}
+		wantErr := ids[i] == "inst2" || ids[i] == ""
+		if (result.Err != nil) != wantErr {
+			t.Errorf("result %d: error = %v, wantErr %v", i, result.Err, wantErr)
+		}
+	}
+	var validErr *client.ValidationError
+	if !errors.As(results[2].Err, &validErr) {
+		t.Errorf("expected ValidationError for the empty ID, got %v", results[2].Err)
+	}
+	if maxRunning.Load() > BulkConcurrency {
+		t.Errorf("expected at most %d concurrent requests, got %d", BulkConcurrency, maxRunning.Load())
+	}
+}
+
+func TestInstanceService_BulkDelete(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Query().Get("delete_volumes") != "true" {
+			t.Errorf("expected delete_volumes=true, got %q", r.URL.Query().Get("delete_volumes"))
+		}
+		if r.URL.Path == "/compute/v1/instances/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "instance not found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	results := testClient(server.URL).Instances().BulkDelete(context.Background(), []string{"inst1", "missing", "inst3"}, DeleteInstanceOptions{DeleteVolumes: true})
+
+	var notFound *InstanceNotFoundError
+	if results[0].Err != nil || results[2].Err != nil {
+		t.Errorf("expected inst1 and inst3 to be deleted, got %+v", results)
+	}
+	if !errors.As(results[1].Err, &notFound) || notFound.ID != "missing" {
+		t.Errorf("expected InstanceNotFoundError for missing, got %v", results[1].Err)
+	}
+}
+
+func TestInstanceService_BulkStop_CanceledContext(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := testClient(server.URL).Instances().BulkStop(ctx, []string{"inst1", "inst2"})
+	for _, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("expected context.Canceled for %s, got %v", result.ID, result.Err)
+		}
+	}
+}
+
+func TestCreateRequest_Clone(t *testing.T) {
+	t.Parallel()
+	original := CreateRequest{
+		AvailabilityZone: strPtr("br-se1-a"),
+		Image:            IDOrName{Name: strPtr("ubuntu")},
+		Labels:           &[]string{"env=prod"},
+		MachineType:      IDOrName{ID: strPtr("mt1")},
+		Name:             "vm",
+		Network: &CreateParametersNetwork{
+			AssociatePublicIp: helpers.BoolPtr(true),
+			Interface: &CreateParametersNetworkInterface{
+				ID:             strPtr("port-1"),
+				SecurityGroups: &[]CreateParametersNetworkInterfaceWithID{{ID: "sg-1"}},
+			},
+			Vpc: &IDOrName{ID: strPtr("vpc-1")},
+		},
+		SshKeyName: strPtr("key"),
+		UserData:   strPtr("data"),
+	}
+	want := CreateRequest{
+		AvailabilityZone: strPtr("br-se1-a"),
+		Image:            IDOrName{Name: strPtr("ubuntu")},
+		Labels:           &[]string{"env=prod"},
+		MachineType:      IDOrName{ID: strPtr("mt1")},
+		Name:             "vm",
+		Network: &CreateParametersNetwork{
+			AssociatePublicIp: helpers.BoolPtr(true),
+			Interface: &CreateParametersNetworkInterface{
+				ID:             strPtr("port-1"),
+				SecurityGroups: &[]CreateParametersNetworkInterfaceWithID{{ID: "sg-1"}},
+			},
+			Vpc: &IDOrName{ID: strPtr("vpc-1")},
+		},
+		SshKeyName: strPtr("key"),
+		UserData:   strPtr("data"),
+	}
+
+	clone := original.Clone()
+	if !reflect.DeepEqual(clone, original) {
+		t.Fatalf("expected clone %+v to equal the original", clone)
+	}
+
+	*clone.AvailabilityZone = "changed"
+	*clone.Image.Name = "changed"
+	(*clone.Labels)[0] = "changed"
+	*clone.MachineType.ID = "changed"
+	*clone.Network.AssociatePublicIp = false
+	*clone.Network.Interface.ID = "changed"
+	(*clone.Network.Interface.SecurityGroups)[0].ID = "changed"
+	*clone.Network.Vpc.ID = "changed"
+	*clone.SshKeyName = "changed"
+	*clone.UserData = "changed"
+
+	if !reflect.DeepEqual(original, want) {
+		t.Errorf("changing the clone changed the original: %+v", original)
+	}
+	if (CreateRequest{}).Clone().Network != nil {
+		t.Error("expected a nil network to stay nil")
+	}
+}
@@ -0,0 +1,55 @@
+package compute
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+// parseRSAPrivateKey decodes an RSA private key in PKCS#1 or PKCS#8 PEM format.
+func parseRSAPrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, &client.ValidationError{Field: "privateKeyPEM", Message: "no PEM data found"}
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, &client.ValidationError{Field: "privateKeyPEM", Message: err.Error()}
+		}
+		return key, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, &client.ValidationError{Field: "privateKeyPEM", Message: err.Error()}
+		}
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, &client.ValidationError{Field: "privateKeyPEM", Message: fmt.Sprintf("unsupported key type %T, want RSA", key)}
+		}
+		return rsaKey, nil
+	default:
+		return nil, &client.ValidationError{Field: "privateKeyPEM", Message: fmt.Sprintf("unsupported PEM block %q", block.Type)}
+	}
+}
+
+// decryptPassword decrypts a base64 encoded password encrypted with RSA PKCS#1 v1.5.
+func decryptPassword(encrypted string, key *rsa.PrivateKey) (string, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", fmt.Errorf("decoding password: %w", err)
+	}
+
+	plaintext, err := rsa.DecryptPKCS1v15(rand.Reader, key, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("decrypting password: %w", err)
+	}
+	return string(plaintext), nil
+}
@@ -7,8 +7,11 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/MagaluCloud/mgc-sdk-go/client"
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/pagination"
 )
 
 // SnapshotExpand represents the expand options for snapshot responses.
@@ -22,6 +25,9 @@ const (
 	SnapshotMachineTypeExpand SnapshotExpand = "machine-type"
 )
 
+// MaxSnapshotNameLength is the maximum length, in characters, of a snapshot name.
+const MaxSnapshotNameLength = 255
+
 // ListSnapshotsResponse represents the response from listing snapshots.
 // This structure encapsulates the API response format for snapshots with pagination metadata.
 type ListSnapshotsResponse struct {
@@ -142,38 +148,21 @@ func (s *snapshotService) List(ctx context.Context, opts SnapshotListOptions) (*
 }
 
 // ListAll retrieves all snapshots across all pages with optional filtering.
-// This method automatically handles pagination and returns all results.
+// Pages after the first are fetched concurrently and returned in API order.
+// When opts.Sort is unset, snapshots are sorted by the client's ListAll sort, DefaultListAllSort by default.
 func (s *snapshotService) ListAll(ctx context.Context, opts SnapshotFilterOptions) ([]Snapshot, error) {
-	var allSnapshots []Snapshot
-	offset := 0
-	limit := 50
-
-	for {
-		currentOffset := offset
-		currentLimit := limit
-		listOpts := SnapshotListOptions{
-			Offset: &currentOffset,
-			Limit:  &currentLimit,
-			Sort:   opts.Sort,
+	return pagination.FetchAll(ctx, s.client.clock, 50, func(ctx context.Context, offset, limit int) ([]Snapshot, int, error) {
+		response, err := s.List(ctx, SnapshotListOptions{
+			Offset: &offset,
+			Limit:  &limit,
+			Sort:   s.client.sortOrDefault(opts.Sort),
 			Expand: opts.Expand,
-		}
-
-		response, err := s.List(ctx, listOpts)
+		})
 		if err != nil {
-			return nil, err
-		}
-
-		allSnapshots = append(allSnapshots, response.Snapshots...)
-
-		// Check if we've retrieved all results
-		if len(response.Snapshots) < limit {
-			break
+			return nil, 0, err
 		}
-
-		offset += limit
-	}
-
-	return allSnapshots, nil
+		return response.Snapshots, response.Meta.Page.Total, nil
+	})
 }
 
 // Create creates a new snapshot from an instance.
@@ -199,7 +188,12 @@ func (s *snapshotService) Create(ctx context.Context, createReq CreateSnapshotRe
 // Get retrieves a specific snapshot.
 // This method makes an HTTP request to get detailed information about a snapshot
 // and optionally expands related resources.
+// Returns a SnapshotNotFoundError if the snapshot does not exist.
 func (s *snapshotService) Get(ctx context.Context, id string, expand []SnapshotExpand) (*Snapshot, error) {
+	if id == "" {
+		return nil, &client.ValidationError{Field: "id", Message: "cannot be empty"}
+	}
+
 	req, err := s.client.newRequest(ctx, http.MethodGet, fmt.Sprintf("/v1/snapshots/%s", id), nil)
 	if err != nil {
 		return nil, err
@@ -218,6 +212,9 @@ func (s *snapshotService) Get(ctx context.Context, id string, expand []SnapshotE
 	var snapshot Snapshot
 	resp, err := mgc_http.Do(s.client.GetConfig(), ctx, req, &snapshot)
 	if err != nil {
+		if isNotFound(err) {
+			return nil, &SnapshotNotFoundError{ID: id, Err: err}
+		}
 		return nil, err
 	}
 	return resp, nil
@@ -225,7 +222,12 @@ func (s *snapshotService) Get(ctx context.Context, id string, expand []SnapshotE
 
 // Delete removes a snapshot.
 // This method makes an HTTP request to delete a snapshot permanently.
+// Returns a SnapshotNotFoundError if the snapshot does not exist.
 func (s *snapshotService) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return &client.ValidationError{Field: "id", Message: "cannot be empty"}
+	}
+
 	req, err := s.client.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/v1/snapshots/%s", id), nil)
 	if err != nil {
 		return err
@@ -233,6 +235,9 @@ func (s *snapshotService) Delete(ctx context.Context, id string) error {
 
 	_, err = mgc_http.Do[any](s.client.GetConfig(), ctx, req, nil)
 	if err != nil {
+		if isNotFound(err) {
+			return &SnapshotNotFoundError{ID: id, Err: err}
+		}
 		return err
 	}
 	return nil
@@ -240,7 +245,18 @@ func (s *snapshotService) Delete(ctx context.Context, id string) error {
 
 // Rename changes the name of a snapshot.
 // This method makes an HTTP request to rename an existing snapshot.
+// The new name must be non-empty and at most MaxSnapshotNameLength characters.
 func (s *snapshotService) Rename(ctx context.Context, id string, newName string) error {
+	if id == "" {
+		return &client.ValidationError{Field: "id", Message: "cannot be empty"}
+	}
+	if newName == "" {
+		return &client.ValidationError{Field: "name", Message: "cannot be empty"}
+	}
+	if utf8.RuneCountInString(newName) > MaxSnapshotNameLength {
+		return &client.ValidationError{Field: "name", Message: fmt.Sprintf("cannot be longer than %d characters", MaxSnapshotNameLength)}
+	}
+
 	req, err := s.client.newRequest(ctx, http.MethodPatch,
 		fmt.Sprintf("/v1/snapshots/%s/rename", id),
 		UpdateNameRequest{Name: newName})
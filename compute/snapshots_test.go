@@ -2,11 +2,15 @@ package compute
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
 )
 
 func TestSnapshotService_List(t *testing.T) {
@@ -469,6 +473,50 @@ func TestSnapshotService_Delete(t *testing.T) {
 	}
 }
 
+func TestSnapshotService_NotFound(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/compute/v1/snapshots/missing" {
+			t.Errorf("expected path /compute/v1/snapshots/missing, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "snapshot not found"}`))
+	}))
+	defer server.Close()
+
+	svc := testClient(server.URL).Snapshots()
+	_, getErr := svc.Get(context.Background(), "missing", nil)
+	deleteErr := svc.Delete(context.Background(), "missing")
+
+	for _, err := range []error{getErr, deleteErr} {
+		var notFound *SnapshotNotFoundError
+		if !errors.As(err, &notFound) {
+			t.Fatalf("expected *SnapshotNotFoundError, got %T", err)
+		}
+		if notFound.ID != "missing" {
+			t.Errorf("expected ID missing, got %s", notFound.ID)
+		}
+		var httpErr *client.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+			t.Errorf("expected wrapped 404 *client.HTTPError, got %v", err)
+		}
+	}
+}
+
+func TestSnapshotService_EmptyID(t *testing.T) {
+	t.Parallel()
+	svc := testClient("http://unused").Snapshots()
+	_, getErr := svc.Get(context.Background(), "", nil)
+	deleteErr := svc.Delete(context.Background(), "")
+
+	for _, err := range []error{getErr, deleteErr} {
+		var validErr *client.ValidationError
+		if !errors.As(err, &validErr) || validErr.Field != "id" {
+			t.Errorf("expected id *client.ValidationError, got %v", err)
+		}
+	}
+}
+
 func TestSnapshotService_Rename(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -515,6 +563,31 @@ func TestSnapshotService_Rename(t *testing.T) {
 	}
 }
 
+func TestSnapshotService_Rename_Validation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		id        string
+		newName   string
+		wantField string
+	}{
+		{name: "empty id", id: "", newName: "new-name", wantField: "id"},
+		{name: "empty name", id: "snap1", newName: "", wantField: "name"},
+		{name: "name too long", id: "snap1", newName: strings.Repeat("a", MaxSnapshotNameLength+1), wantField: "name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testClient("http://unused").Snapshots().Rename(context.Background(), tt.id, tt.newName)
+
+			var validErr *client.ValidationError
+			if !errors.As(err, &validErr) || validErr.Field != tt.wantField {
+				t.Errorf("expected %s *client.ValidationError, got %v", tt.wantField, err)
+			}
+		})
+	}
+}
+
 func TestSnapshotService_Restore(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -6,12 +6,16 @@ import (
 	"strconv"
 
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/pagination"
 )
 
 // Meta contains pagination metadata for API responses.
 // This structure provides information about the current page and total results.
 type Meta struct {
 	Page Page `json:"page"`
+	// NextPageToken identifies the next page when the API supports token pagination.
+	// It is empty on the last page.
+	NextPageToken string `json:"next_page_token,omitempty"`
 }
 
 // Page contains pagination information
@@ -104,36 +108,19 @@ func (s *instanceTypeService) List(ctx context.Context, opts InstanceTypeListOpt
 }
 
 // ListAll retrieves all instance types across all pages with optional filtering.
-// This method automatically handles pagination and returns all results.
+// Pages after the first are fetched concurrently and returned in API order.
+// When opts.Sort is unset, instance types are sorted by the client's ListAll sort, DefaultListAllSort by default.
 func (s *instanceTypeService) ListAll(ctx context.Context, opts InstanceTypeFilterOptions) ([]InstanceType, error) {
-	var allInstanceTypes []InstanceType
-	offset := 0
-	limit := 50
-
-	for {
-		currentOffset := offset
-		currentLimit := limit
-		listOpts := InstanceTypeListOptions{
-			Offset:           &currentOffset,
-			Limit:            &currentLimit,
-			Sort:             opts.Sort,
+	return pagination.FetchAll(ctx, s.client.clock, 50, func(ctx context.Context, offset, limit int) ([]InstanceType, int, error) {
+		response, err := s.List(ctx, InstanceTypeListOptions{
+			Offset:           &offset,
+			Limit:            &limit,
+			Sort:             s.client.sortOrDefault(opts.Sort),
 			AvailabilityZone: opts.AvailabilityZone,
-		}
-
-		response, err := s.List(ctx, listOpts)
+		})
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
-
-		allInstanceTypes = append(allInstanceTypes, response.InstanceTypes...)
-
-		// Check if we've retrieved all results
-		if len(response.InstanceTypes) < limit {
-			break
-		}
-
-		offset += limit
-	}
-
-	return allInstanceTypes, nil
+		return response.InstanceTypes, response.Meta.Page.Total, nil
+	})
 }
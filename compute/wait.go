@@ -0,0 +1,94 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Default polling parameters of the methods that wait for an instance state.
+const (
+	DefaultWaitTimeout  = 10 * time.Minute
+	DefaultWaitInterval = 5 * time.Second
+)
+
+// Instance state and status reported once an instance is ready to use.
+const (
+	InstanceStateRunning    = "running"
+	InstanceStatusCompleted = "completed"
+)
+
+// WaitOption customizes how long and how often an instance is polled.
+type WaitOption func(*waitConfig)
+
+type waitConfig struct {
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// WithWaitTimeout sets how long to wait before giving up, DefaultWaitTimeout by default.
+// A deadline already set on the context takes precedence when it is earlier.
+func WithWaitTimeout(timeout time.Duration) WaitOption {
+	return func(c *waitConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WithWaitInterval sets the delay between polls, DefaultWaitInterval by default.
+func WithWaitInterval(interval time.Duration) WaitOption {
+	return func(c *waitConfig) {
+		c.interval = interval
+	}
+}
+
+// InstanceFailedError is returned when an instance reaches an error status while waiting for it.
+type InstanceFailedError struct {
+	ID     string
+	Status string
+	Reason *Error
+}
+
+// Error returns a string representation of the error.
+func (e *InstanceFailedError) Error() string {
+	if e.Reason != nil {
+		return fmt.Sprintf("instance %s failed with status %s: %s", e.ID, e.Status, e.Reason.Message)
+	}
+	return fmt.Sprintf("instance %s failed with status %s", e.ID, e.Status)
+}
+
+// waitForInstance polls an instance until done reports it ready, it reaches an error status,
+// or the wait times out.
+func (s *instanceService) waitForInstance(ctx context.Context, id string, done func(*Instance) bool, opts []WaitOption) (*Instance, error) {
+	cfg := waitConfig{timeout: DefaultWaitTimeout, interval: DefaultWaitInterval}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.timeout)
+	defer cancel()
+
+	for {
+		instance, err := s.Get(ctx, id, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if done(instance) {
+			return instance, nil
+		}
+
+		if strings.Contains(instance.Status, "error") {
+			return instance, &InstanceFailedError{ID: id, Status: instance.Status, Reason: instance.Error}
+		}
+
+		if err := s.client.clock.Sleep(ctx, cfg.interval); err != nil {
+			return instance, fmt.Errorf("waiting for instance %s: %w", id, err)
+		}
+	}
+}
+
+// isRunning reports whether an instance finished provisioning and is running.
+func isRunning(instance *Instance) bool {
+	return instance.State == InstanceStateRunning && instance.Status == InstanceStatusCompleted
+}
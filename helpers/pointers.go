@@ -55,3 +55,12 @@ func Uint32Ptr(u uint32) *uint32 {
 func Uint64Ptr(u uint64) *uint64 {
 	return &u
 }
+
+// Deref returns the value p points to, or the zero value of T if p is nil.
+func Deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
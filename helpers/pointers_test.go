@@ -155,3 +155,18 @@ func TestUint64Ptr(t *testing.T) {
 		t.Errorf("Expected %v, got %v", value, *ptr)
 	}
 }
+
+func TestDeref(t *testing.T) {
+	if got := Deref[string](nil); got != "" {
+		t.Errorf("Expected empty string, got %q", got)
+	}
+	if got := Deref(StrPtr("value")); got != "value" {
+		t.Errorf("Expected value, got %q", got)
+	}
+	if got := Deref[[]string](nil); got != nil {
+		t.Errorf("Expected nil slice, got %v", got)
+	}
+	if got := Deref(IntPtr(7)); got != 7 {
+		t.Errorf("Expected 7, got %d", got)
+	}
+}
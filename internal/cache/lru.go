@@ -0,0 +1,120 @@
+// Package cache provides the size-bounded LRU cache shared by the client response cache
+// and the compute image cache.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU is a size-bounded cache whose entries optionally expire after a TTL. When full,
+// adding an entry evicts the least recently used one. It is safe for concurrent use.
+type LRU[V any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type entry[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+// New creates an empty cache holding at most size entries, each kept for ttl.
+// Entries never expire when ttl is not positive, and nothing is stored when size is not positive.
+func New[V any](size int, ttl time.Duration) *LRU[V] {
+	return &LRU[V]{
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get returns the value stored under key, unless it has expired at now.
+func (c *LRU[V]) Get(key string, now time.Time) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	e := elem.Value.(*entry[V])
+	if c.ttl > 0 && !now.Before(e.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return e.value, true
+}
+
+// Add stores value under key, replacing any previous one, until now plus the TTL.
+func (c *LRU[V]) Add(key string, value V, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.size <= 0 {
+		return
+	}
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*entry[V])
+		e.value = value
+		e.expires = now.Add(c.ttl)
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&entry[V]{key: key, value: value, expires: now.Add(c.ttl)})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry[V]).key)
+	}
+}
+
+// Remove drops the entry stored under key, if any.
+func (c *LRU[V]) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// RemoveFunc drops every entry whose key matches.
+func (c *LRU[V]) RemoveFunc(match func(key string) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, elem := range c.entries {
+		if match(key) {
+			c.order.Remove(elem)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Purge drops every entry.
+func (c *LRU[V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+	c.order.Init()
+}
+
+// Len returns the number of stored entries, including expired ones not yet dropped.
+func (c *LRU[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	t.Run("expires entries after the TTL", func(t *testing.T) {
+		c := New[int](2, time.Minute)
+		c.Add("a", 1, now)
+
+		if v, ok := c.Get("a", now.Add(59*time.Second)); !ok || v != 1 {
+			t.Errorf("Get before TTL = %v, %v; want 1, true", v, ok)
+		}
+		if _, ok := c.Get("a", now.Add(time.Minute)); ok {
+			t.Error("Get after TTL should miss")
+		}
+		if c.Len() != 0 {
+			t.Errorf("expired entry kept, len = %d", c.Len())
+		}
+	})
+
+	t.Run("evicts the least recently used entry", func(t *testing.T) {
+		c := New[int](2, time.Minute)
+		c.Add("a", 1, now)
+		c.Add("b", 2, now)
+		c.Get("a", now)
+		c.Add("c", 3, now)
+
+		if _, ok := c.Get("b", now); ok {
+			t.Error("b should have been evicted")
+		}
+		for _, key := range []string{"a", "c"} {
+			if _, ok := c.Get(key, now); !ok {
+				t.Errorf("%s should be cached", key)
+			}
+		}
+	})
+
+	t.Run("Add replaces and refreshes an entry", func(t *testing.T) {
+		c := New[int](2, time.Minute)
+		c.Add("a", 1, now)
+		c.Add("a", 2, now.Add(30*time.Second))
+
+		if v, ok := c.Get("a", now.Add(80*time.Second)); !ok || v != 2 {
+			t.Errorf("Get = %v, %v; want 2, true", v, ok)
+		}
+		if c.Len() != 1 {
+			t.Errorf("len = %d, want 1", c.Len())
+		}
+	})
+
+	t.Run("Remove and Purge", func(t *testing.T) {
+		c := New[int](2, time.Minute)
+		c.Add("a", 1, now)
+		c.Add("b", 2, now)
+
+		c.Remove("a")
+		if _, ok := c.Get("a", now); ok {
+			t.Error("a should have been removed")
+		}
+		c.Purge()
+		if _, ok := c.Get("b", now); ok {
+			t.Error("b should have been purged")
+		}
+	})
+
+	t.Run("RemoveFunc drops matching keys", func(t *testing.T) {
+		c := New[int](3, time.Minute)
+		c.Add("list 1", 1, now)
+		c.Add("list 2", 2, now)
+		c.Add("custom a", 3, now)
+
+		c.RemoveFunc(func(key string) bool { return strings.HasPrefix(key, "list ") })
+		if c.Len() != 1 {
+			t.Errorf("len = %d, want 1", c.Len())
+		}
+		if _, ok := c.Get("custom a", now); !ok {
+			t.Error("custom a should be cached")
+		}
+	})
+
+	t.Run("zero TTL never expires", func(t *testing.T) {
+		c := New[int](1, 0)
+		c.Add("a", 1, now)
+
+		if _, ok := c.Get("a", now.Add(24*time.Hour)); !ok {
+			t.Error("a should not expire without a TTL")
+		}
+	})
+
+	t.Run("zero size stores nothing", func(t *testing.T) {
+		c := New[int](0, time.Minute)
+		c.Add("a", 1, now)
+
+		if c.Len() != 0 {
+			t.Errorf("len = %d, want 0", c.Len())
+		}
+	})
+}
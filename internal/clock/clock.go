@@ -0,0 +1,93 @@
+// Package clock abstracts time so that polling, backoff and jitter can be tested
+// deterministically without real sleeps.
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and context-aware sleeping.
+type Clock interface {
+	Now() time.Time
+	// Sleep blocks for d or until ctx is done, in which case it returns ctx.Err().
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying c, so that code reached through ctx waits
+// on c instead of the real clock. Being internal, it is only a hook for tests.
+func NewContext(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the clock carried by ctx, or Real when there is none.
+func FromContext(ctx context.Context) Clock {
+	if c, ok := ctx.Value(contextKey{}).(Clock); ok {
+		return c
+	}
+	return Real{}
+}
+
+// Real is the Clock backed by the time package.
+type Real struct{}
+
+// Now returns time.Now().
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep waits for d using a timer that is released if ctx is done first.
+func (Real) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Fake is a Clock for tests. Sleep returns immediately, advancing Now by the
+// requested duration and recording it.
+type Fake struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+// NewFake returns a Fake clock set to now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the fake current time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Sleep advances the fake time by d, unless ctx is already done.
+func (f *Fake) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	f.sleeps = append(f.sleeps, d)
+	return nil
+}
+
+// Sleeps returns the durations passed to Sleep, in call order.
+func (f *Fake) Sleeps() []time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]time.Duration(nil), f.sleeps...)
+}
@@ -0,0 +1,60 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRealSleep(t *testing.T) {
+	if err := (Real{}).Sleep(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("Sleep() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := (Real{}).Sleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("Sleep() expected context.Canceled, got %v", err)
+	}
+}
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+
+	if err := f.Sleep(context.Background(), time.Second); err != nil {
+		t.Fatalf("Sleep() unexpected error: %v", err)
+	}
+	if err := f.Sleep(context.Background(), 2*time.Second); err != nil {
+		t.Fatalf("Sleep() unexpected error: %v", err)
+	}
+
+	if got := f.Now(); !got.Equal(start.Add(3 * time.Second)) {
+		t.Errorf("Now() = %v, want %v", got, start.Add(3*time.Second))
+	}
+	if got, want := f.Sleeps(), []time.Duration{time.Second, 2 * time.Second}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Sleeps() = %v, want %v", got, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := f.Sleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("Sleep() expected context.Canceled, got %v", err)
+	}
+	if len(f.Sleeps()) != 2 {
+		t.Errorf("Sleep() with done context should not be recorded")
+	}
+}
+
+func TestContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()).(Real); !ok {
+		t.Errorf("FromContext() without a clock = %T, want Real", FromContext(context.Background()))
+	}
+
+	f := NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if got := FromContext(NewContext(context.Background(), f)); got != f {
+		t.Errorf("FromContext() = %v, want the fake clock", got)
+	}
+}
@@ -2,16 +2,21 @@ package mgc_http
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
-	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/clock"
 	"github.com/MagaluCloud/mgc-sdk-go/internal/retry"
 	"gopkg.in/yaml.v3"
 )
@@ -20,6 +25,8 @@ import (
 type NewRequestFunc func(ctx context.Context, method, path string, body any) (*http.Request, error)
 
 // NewRequest creates a new HTTP request with the given method, path, and body.
+// With client.RequestCompression, bodies above its threshold are gzip-compressed once the
+// server has advertised support for it.
 // It returns the request and an error if the request creation fails.
 func NewRequest[T any](c *client.Config, ctx context.Context, method, path string, body *T) (*http.Request, error) {
 
@@ -36,6 +43,7 @@ func NewRequest[T any](c *client.Config, ctx context.Context, method, path strin
 	url := c.BaseURL.String() + path
 
 	var bodyReader io.Reader
+	var compress bool
 	if body != nil {
 		bodyBytes, err := json.Marshal(body)
 		if err != nil {
@@ -45,6 +53,11 @@ func NewRequest[T any](c *client.Config, ctx context.Context, method, path strin
 				"path", path)
 			return nil, fmt.Errorf("error marshalling body: %w", err)
 		}
+		if compress = c.RequestCompression != nil && c.RequestCompression.ShouldCompress(len(bodyBytes)); compress {
+			if bodyBytes, err = gzipBody(bodyBytes); err != nil {
+				return nil, err
+			}
+		}
 		bodyReader = io.NopCloser(bytes.NewReader(bodyBytes))
 	}
 
@@ -57,18 +70,20 @@ func NewRequest[T any](c *client.Config, ctx context.Context, method, path strin
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	if requestIDVal := ctx.Value(client.RequestIDKey); requestIDVal != nil {
-		if requestID, ok := requestIDVal.(string); ok {
-			c.Logger.Info("X-Request-ID found in context", "requestID", requestID)
-			req.Header.Set("X-Request-ID", requestID)
-		} else {
+	requestID, ok := client.RequestIDFromContext(ctx)
+	if ok {
+		c.Logger.Info("X-Request-ID found in context", "requestID", requestID)
+	} else {
+		if ctx.Value(client.RequestIDKey) != nil {
 			c.Logger.Warn("X-Request-ID in context is not a string")
 		}
+		requestID = client.NewRequestID()
 	}
+	req.Header.Set(client.RequestIDHeader, requestID)
 
 	c.Logger.Debug("setting request headers",
 		"apiKey", "redacted",
-		"userAgent", c.UserAgent)
+		"userAgent", c.FullUserAgent())
 
 	if c.JWToken != "" {
 		req.Header.Set("Authorization", c.JWToken)
@@ -76,8 +91,11 @@ func NewRequest[T any](c *client.Config, ctx context.Context, method, path strin
 	if c.APIKey != "" {
 		req.Header.Set("X-API-Key", c.APIKey)
 	}
-	req.Header.Set("User-Agent", c.UserAgent)
+	req.Header.Set("User-Agent", c.FullUserAgent())
 	req.Header.Set("Content-Type", c.ContentType)
+	if compress {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
 
 	if c.CustomHeaders != nil {
 		for k, v := range c.CustomHeaders {
@@ -86,11 +104,18 @@ func NewRequest[T any](c *client.Config, ctx context.Context, method, path strin
 		}
 	}
 
+	SetDefaultHeaders(req, c.DefaultHeaders)
+
 	return req, nil
 }
 
 // Do executes an HTTP request and processes the response.
-// If v is provided, the response body will be JSON decoded into it.
+// If v is provided, the response body will be JSON decoded into it, unless the
+// status is 204 No Content. An empty body on any other 2xx status returns
+// client.ErrEmptyResponse.
+// With a client.ResponseCache, GET requests decoding into v are revalidated with the
+// ETag or Last-Modified stored for the same URL and request headers, and a 304 Not
+// Modified is decoded from the stored body.
 // Returns the parsed response and an error if the request fails,
 // the response status is not 2xx, or if there are JSON decoding issues.
 func Do[T any](c *client.Config, ctx context.Context, req *http.Request, v *T) (*T, error) {
@@ -103,6 +128,8 @@ func Do[T any](c *client.Config, ctx context.Context, req *http.Request, v *T) (
 		return nil, fmt.Errorf("HTTP client is nil")
 	}
 
+	clk := clock.FromContext(ctx)
+
 	var bodyBytes []byte
 	if req.Body != nil {
 		var err error
@@ -119,16 +146,25 @@ func Do[T any](c *client.Config, ctx context.Context, req *http.Request, v *T) (
 		defer cancel()
 	}
 
+	var cacheKey string
+	var cached *client.CachedResponse
+	if c.ResponseCache != nil && v != nil && req.Method == http.MethodGet {
+		cacheKey = responseCacheKey(req)
+		if entry, ok := c.ResponseCache.Get(cacheKey); ok {
+			cached = &entry
+		}
+	}
+
 	var lastError error
 	for attempt := range c.RetryConfig.MaxAttempts {
 		if attempt > 0 {
+			if c.RetryBudget != nil && !c.RetryBudget.Withdraw() {
+				c.Logger.Warn("retry budget exhausted, not retrying", "error", lastError)
+				return nil, fmt.Errorf("%w: %w", client.ErrRetryBudgetExhausted, lastError)
+			}
 			backoff := retry.GetNextBackoff(attempt-1, c.RetryConfig.BackoffFactor, c.RetryConfig.InitialInterval, c.RetryConfig.MaxInterval)
-			timer := time.NewTimer(backoff)
-			select {
-			case <-ctx.Done():
-				timer.Stop()
-				return nil, ctx.Err()
-			case <-timer.C:
+			if err := clk.Sleep(ctx, backoff); err != nil {
+				return nil, err
 			}
 		}
 
@@ -136,13 +172,40 @@ func Do[T any](c *client.Config, ctx context.Context, req *http.Request, v *T) (
 		if len(bodyBytes) > 0 {
 			clonedReq.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 		}
+		if cached != nil {
+			setConditionalHeaders(clonedReq, cached)
+		}
 
 		c.Logger.Info("making request",
 			"method", clonedReq.Method,
 			"url", clonedReq.URL.String(),
 			"attempt", attempt+1)
 
+		for _, intercept := range c.RequestInterceptors {
+			if err := intercept(clonedReq); err != nil {
+				return nil, fmt.Errorf("request interceptor: %w", err)
+			}
+		}
+
+		if c.CircuitBreaker != nil {
+			if err := c.CircuitBreaker.Allow(); err != nil {
+				if lastError != nil {
+					return nil, fmt.Errorf("%w: %w", err, lastError)
+				}
+				return nil, err
+			}
+		}
+
+		start := clk.Now()
 		resp, err := c.HTTPClient.Do(clonedReq)
+		recordCircuitBreaker(ctx, c, resp, err)
+		if c.Metrics != nil {
+			statusClass := client.StatusClassError
+			if err == nil {
+				statusClass = client.StatusClass(resp.StatusCode)
+			}
+			c.Metrics.ObserveRequest(serviceName(c.BaseURL, clonedReq.URL), clonedReq.Method, statusClass, clk.Now().Sub(start))
+		}
 		if err != nil {
 			lastError = err
 			continue
@@ -150,6 +213,26 @@ func Do[T any](c *client.Config, ctx context.Context, req *http.Request, v *T) (
 
 		defer resp.Body.Close()
 
+		if c.RateLimit != nil {
+			c.RateLimit.Observe(resp.Header, clk.Now())
+		}
+		if c.RequestCompression != nil {
+			c.RequestCompression.Observe(resp.Header)
+		}
+
+		if resp.StatusCode == http.StatusNotModified && cached != nil {
+			c.Logger.Debug("serving cached response", "url", clonedReq.URL.String())
+			serveCachedResponse(resp, cached)
+		} else if err := decompressBody(resp); err != nil {
+			return nil, err
+		}
+
+		for _, intercept := range c.ResponseInterceptors {
+			if err := intercept(resp); err != nil {
+				return nil, fmt.Errorf("response interceptor: %w", err)
+			}
+		}
+
 		if xRequestID := resp.Header.Get("X-Request-ID"); xRequestID != "" {
 			c.Logger.Info("X-Request-ID received in response", "requestID", xRequestID)
 		} else {
@@ -166,18 +249,30 @@ func Do[T any](c *client.Config, ctx context.Context, req *http.Request, v *T) (
 			lastError = client.NewHTTPError(resp)
 
 			if !retry.ShouldRetry(resp.StatusCode) {
+				depositRetryBudget(c)
 				return nil, lastError
 			}
 			continue
 		}
 
+		depositRetryBudget(c)
+
+		if err := checkAPIVersion(c, resp); err != nil {
+			return nil, err
+		}
+
 		if v != nil && resp.StatusCode != http.StatusNoContent {
+			if cacheKey != "" {
+				if err := storeCachedResponse(c.ResponseCache, cacheKey, req, resp); err != nil {
+					return nil, err
+				}
+			}
 			ct := resp.Header.Get("Content-Type")
 			if strings.Contains(ct, "application/x-yaml") || strings.Contains(ct, "application/yaml") {
 				return decodeYamlResponse(resp, v)
 			}
 			// JSON is the default
-			return decodeJsonResponse(resp, v)
+			return decodeJsonResponse(resp, v, c.StrictDecoding)
 		}
 
 		return nil, nil
@@ -186,11 +281,236 @@ func Do[T any](c *client.Config, ctx context.Context, req *http.Request, v *T) (
 	return nil, &client.RetryError{LastError: lastError, Retries: c.RetryConfig.MaxAttempts}
 }
 
+// gzipBody compresses a marshalled request body.
+func gzipBody(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, fmt.Errorf("error compressing body: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("error compressing body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressBody replaces a gzip or deflate encoded response body with its
+// decoded content and removes the encoding headers that no longer apply.
+// http.Transport already decodes the gzip responses it asked for, so this only
+// applies to transports that leave the body encoded.
+func decompressBody(resp *http.Response) error {
+	if resp.Uncompressed {
+		return nil
+	}
+
+	var decoded io.ReadCloser
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("error reading gzip response: %w", err)
+		}
+		decoded = zr
+	case "deflate":
+		zr, err := zlib.NewReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("error reading deflate response: %w", err)
+		}
+		decoded = zr
+	default:
+		return nil
+	}
+
+	resp.Body = &decodedBody{ReadCloser: decoded, raw: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// decodedBody closes both the decompressor and the underlying response body.
+type decodedBody struct {
+	io.ReadCloser
+	raw io.ReadCloser
+}
+
+func (b *decodedBody) Close() error {
+	b.ReadCloser.Close()
+	return b.raw.Close()
+}
+
+// setConditionalHeaders asks the server to answer 304 Not Modified when the cached
+// response is still current.
+func setConditionalHeaders(req *http.Request, cached *client.CachedResponse) {
+	if cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+}
+
+// serveCachedResponse turns a 304 Not Modified response into a 200 OK carrying the
+// cached body. Headers of the 304 update the cached ones, except those describing
+// the body, which was stored decompressed.
+func serveCachedResponse(resp *http.Response, cached *client.CachedResponse) {
+	header := cached.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	for k, v := range resp.Header {
+		if k == "Content-Encoding" || k == "Content-Length" {
+			continue
+		}
+		header[k] = v
+	}
+	resp.StatusCode = http.StatusOK
+	resp.Status = "200 OK"
+	resp.Header = header
+	resp.Body = io.NopCloser(bytes.NewReader(cached.Body))
+	resp.ContentLength = int64(len(cached.Body))
+}
+
+// storeCachedResponse stores a response carrying an ETag or Last-Modified validator,
+// leaving its body readable for decoding. Responses with a body over
+// client.MaxCachedResponseBytes, or varying on headers outside the cache key, are not stored.
+func storeCachedResponse(cache *client.ResponseCache, key string, req *http.Request, resp *http.Response) error {
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return nil
+	}
+	if resp.ContentLength > client.MaxCachedResponseBytes || !varyCoveredByKey(req, resp.Request, resp.Header) {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, client.MaxCachedResponseBytes+1))
+	if err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+	if len(body) > client.MaxCachedResponseBytes {
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), resp.Body))
+		return nil
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	cache.Add(key, client.CachedResponse{
+		ETag:         etag,
+		LastModified: lastModified,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+	})
+	return nil
+}
+
+// responseCacheIgnoredHeaders are the request headers left out of response cache keys,
+// because they change on every request or only carry the revalidation itself.
+var responseCacheIgnoredHeaders = map[string]bool{
+	http.CanonicalHeaderKey(client.RequestIDHeader): true,
+	"If-None-Match":     true,
+	"If-Modified-Since": true,
+}
+
+// responseCacheKey returns the key the response to a GET request is cached under: its URL
+// followed by the request headers that may select a different response, such as the
+// credentials, the zone or the API version.
+func responseCacheKey(req *http.Request) string {
+	var key strings.Builder
+	key.WriteString(req.URL.String())
+	for _, name := range slices.Sorted(maps.Keys(req.Header)) {
+		if responseCacheIgnoredHeaders[name] {
+			continue
+		}
+		fmt.Fprintf(&key, "\n%s: %s", name, strings.Join(req.Header[name], ", "))
+	}
+	return key.String()
+}
+
+// varyCoveredByKey reports whether every header named by the Vary response header was
+// sent as it appears in the cache key of keyed, so the stored response matches the key.
+// A response varying on "*" or on an ignored header is never covered.
+func varyCoveredByKey(keyed, sent *http.Request, header http.Header) bool {
+	for _, vary := range header.Values("Vary") {
+		for name := range strings.SplitSeq(vary, ",") {
+			name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			if name == "*" || responseCacheIgnoredHeaders[name] {
+				return false
+			}
+			if sent != nil && !slices.Equal(keyed.Header.Values(name), sent.Header.Values(name)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// recordCircuitBreaker reports the outcome of a request to the client circuit breaker, if any.
+// Only transport errors and retryable responses count as failures; a request that failed
+// because ctx was canceled or timed out says nothing about the API and is only released.
+func recordCircuitBreaker(ctx context.Context, c *client.Config, resp *http.Response, err error) {
+	if c.CircuitBreaker == nil {
+		return
+	}
+	if err != nil && ctx.Err() != nil {
+		c.CircuitBreaker.Release()
+		return
+	}
+	if err != nil || retry.ShouldRetry(resp.StatusCode) {
+		c.CircuitBreaker.RecordFailure()
+		return
+	}
+	c.CircuitBreaker.RecordSuccess()
+}
+
+// checkAPIVersion returns an APIVersionError when the client expects an API version
+// and the response reports a different one. A response without the header reports
+// no version, so it passes.
+func checkAPIVersion(c *client.Config, resp *http.Response) error {
+	if c.ExpectedAPIVersion == "" {
+		return nil
+	}
+	actual := resp.Header.Get(client.APIVersionHeader)
+	if actual == "" {
+		c.Logger.Debug("API version not reported", "expected", c.ExpectedAPIVersion)
+		return nil
+	}
+	if actual != c.ExpectedAPIVersion {
+		c.Logger.Warn("API version mismatch", "expected", c.ExpectedAPIVersion, "actual", actual)
+		return &client.APIVersionError{Expected: c.ExpectedAPIVersion, Actual: actual}
+	}
+	return nil
+}
+
+// depositRetryBudget refills the client retry budget, if any, after a request that needs no retry.
+func depositRetryBudget(c *client.Config) {
+	if c.RetryBudget != nil {
+		c.RetryBudget.Deposit()
+	}
+}
+
+// serviceName returns the first path segment after the base URL path,
+// which identifies the product being called (e.g. "compute").
+func serviceName(baseURL client.MgcUrl, u *url.URL) string {
+	p := u.Path
+	if base, err := url.Parse(baseURL.String()); err == nil {
+		p = strings.TrimPrefix(p, strings.TrimSuffix(base.Path, "/"))
+	}
+	service, _, _ := strings.Cut(strings.TrimPrefix(p, "/"), "/")
+	return service
+}
+
 func decodeYamlResponse[T any](resp *http.Response, v *T) (*T, error) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, client.ErrEmptyResponse
+	}
 
 	var checkNull any
 	if err := yaml.Unmarshal(body, &checkNull); err != nil {
@@ -207,9 +527,12 @@ func decodeYamlResponse[T any](resp *http.Response, v *T) (*T, error) {
 	return v, nil
 }
 
-func decodeJsonResponse[T any](resp *http.Response, v *T) (*T, error) {
+func decodeJsonResponse[T any](resp *http.Response, v *T, strict bool) (*T, error) {
 	var raw json.RawMessage
 	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, client.ErrEmptyResponse
+		}
 		return nil, fmt.Errorf("error decoding response: %w", err)
 	}
 
@@ -222,6 +545,9 @@ func decodeJsonResponse[T any](resp *http.Response, v *T) (*T, error) {
 	}
 
 	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
 	if err := decoder.Decode(v); err != nil {
 		return nil, fmt.Errorf("error decoding response: %w", err)
 	}
@@ -283,3 +609,12 @@ func ExecuteSimpleRequest(
 
 	return nil
 }
+
+// SetDefaultHeaders adds the headers the request does not already set.
+func SetDefaultHeaders(req *http.Request, headers map[string]string) {
+	for k, v := range headers {
+		if req.Header.Get(k) == "" {
+			req.Header.Set(k, v)
+		}
+	}
+}
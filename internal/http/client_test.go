@@ -1,8 +1,11 @@
 package mgc_http
 
 import (
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -10,12 +13,16 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/clock"
+	"github.com/google/uuid"
 )
 
 type mockResponse struct {
@@ -83,6 +90,35 @@ func TestCoreClient_NewRequest(t *testing.T) {
 	}
 }
 
+func TestCoreClient_NewRequest_GeneratedRequestID(t *testing.T) {
+	ct := client.NewMgcClient(client.WithAPIKey("test-api-key"))
+
+	first, err := NewRequest[any](ct.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	second, err := NewRequest[any](ct.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	firstID := first.Header.Get(client.RequestIDHeader)
+	if _, err := uuid.Parse(firstID); err != nil {
+		t.Errorf("expected a generated UUID request ID, got %q", firstID)
+	}
+	if firstID == second.Header.Get(client.RequestIDHeader) {
+		t.Error("expected a distinct request ID for each request")
+	}
+
+	withID, err := NewRequest[any](ct.GetConfig(), client.WithRequestID(context.Background(), "trace-123"), http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	if got := withID.Header.Get(client.RequestIDHeader); got != "trace-123" {
+		t.Errorf("expected request ID from context, got %q", got)
+	}
+}
+
 func TestCoreClient_NewRequest_CustomHeaders(t *testing.T) {
 	ct := client.NewMgcClient(client.WithAPIKey("test-api-key"), client.WithCustomHeader("X-Custom-Header", "custom-value"))
 
@@ -97,6 +133,36 @@ func TestCoreClient_NewRequest_CustomHeaders(t *testing.T) {
 	}
 }
 
+func TestCoreClient_NewRequest_DefaultHeaders(t *testing.T) {
+	ct := client.NewMgcClient(
+		client.WithAPIKey("test-api-key"),
+		client.WithCustomHeader("X-Feature", "custom"),
+		client.WithDefaultHeaders(map[string]string{
+			"X-Tenant-ID":  "tenant-1",
+			"X-Feature":    "default",
+			"X-API-Key":    "default-key",
+			"Content-Type": "text/plain",
+		}),
+	)
+
+	req, err := NewRequest[any](ct.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	want := map[string]string{
+		"X-Tenant-ID":  "tenant-1",
+		"X-Feature":    "custom",
+		"X-API-Key":    "test-api-key",
+		"Content-Type": "application/json",
+	}
+	for k, v := range want {
+		if got := req.Header.Get(k); got != v {
+			t.Errorf("header %s = %q, want %q", k, got, v)
+		}
+	}
+}
+
 func TestCoreClient_Do(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -258,6 +324,22 @@ func TestRetryLogic(t *testing.T) {
 	}
 }
 
+func TestRequestHeaders_UserAgentSuffix(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); got != client.DefaultUserAgent+" my-app/1.2.0" {
+			t.Errorf("expected User-Agent with suffix, got %q", got)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient(client.WithBaseURL(client.MgcUrl(server.URL)), client.WithUserAgentSuffix("my-app/1.2.0"))
+	req, _ := NewRequest[any](core.GetConfig(), context.Background(), http.MethodGet, "/test", nil)
+	if _, err := Do[any](core.GetConfig(), context.Background(), req, nil); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
 func TestRequestHeaders(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Header.Get("X-API-Key") != "test-api-key" {
@@ -557,8 +639,8 @@ func TestRequestIDHandling(t *testing.T) {
 		t.Fatalf("Failed to create request: %v", err)
 	}
 
-	if req.Header.Get("X-Request-ID") != "" {
-		t.Error("Expected no X-Request-ID header when invalid type is provided")
+	if _, err := uuid.Parse(req.Header.Get("X-Request-ID")); err != nil {
+		t.Error("Expected a generated X-Request-ID header when invalid type is provided")
 	}
 }
 
@@ -566,7 +648,7 @@ func TestRequestIDHandling_TableDriven(t *testing.T) {
 	tests := []struct {
 		name           string
 		requestIDValue any
-		wantHeader     string
+		wantHeader     string // empty means a generated UUID
 		wantLogMsg     string
 	}{
 		{
@@ -608,7 +690,12 @@ func TestRequestIDHandling_TableDriven(t *testing.T) {
 				t.Fatalf("Failed to create request: %v", err)
 			}
 
-			if got := req.Header.Get("X-Request-ID"); got != tt.wantHeader {
+			got := req.Header.Get("X-Request-ID")
+			if tt.wantHeader == "" {
+				if _, err := uuid.Parse(got); err != nil {
+					t.Errorf("RequestID header = %q, want a generated UUID", got)
+				}
+			} else if got != tt.wantHeader {
 				t.Errorf("RequestID header = %q, want %q", got, tt.wantHeader)
 			}
 		})
@@ -998,7 +1085,7 @@ func TestDo_JSONHandling(t *testing.T) {
 			statusCode: http.StatusOK,
 			want:       nil,
 			wantErr:    true,
-			errMsg:     "error decoding response",
+			errMsg:     "empty response body",
 		},
 		{
 			name:       "malformed json",
@@ -1145,6 +1232,187 @@ func TestDo_NoResponseBody(t *testing.T) {
 	}
 }
 
+func TestDo_EmptyResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		contentType string
+		body        string
+		wantErr     error
+	}{
+		{name: "204 is not decoded", statusCode: http.StatusNoContent, contentType: "application/json"},
+		{name: "empty 200", statusCode: http.StatusOK, contentType: "application/json", wantErr: client.ErrEmptyResponse},
+		{name: "whitespace 200", statusCode: http.StatusOK, contentType: "application/json", body: " \n", wantErr: client.ErrEmptyResponse},
+		{name: "empty yaml 200", statusCode: http.StatusOK, contentType: "application/x-yaml", wantErr: client.ErrEmptyResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cfg := &client.Config{
+				BaseURL:     client.MgcUrl(server.URL),
+				HTTPClient:  &http.Client{},
+				Logger:      slog.Default(),
+				RetryConfig: client.RetryConfig{MaxAttempts: 1},
+			}
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			var response mockResponse
+			got, err := Do(cfg, context.Background(), req, &response)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != nil {
+				t.Errorf("Do() got = %v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestDo_StrictDecoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		strict  bool
+		body    string
+		wantErr bool
+	}{
+		{name: "unknown field ignored by default", body: `{"message": "ok", "extra": 1}`},
+		{name: "unknown field rejected", strict: true, body: `{"message": "ok", "extra": 1}`, wantErr: true},
+		{name: "known fields accepted", strict: true, body: `{"message": "ok"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cfg := &client.Config{
+				BaseURL:        client.MgcUrl(server.URL),
+				HTTPClient:     &http.Client{},
+				Logger:         slog.Default(),
+				RetryConfig:    client.RetryConfig{MaxAttempts: 1},
+				StrictDecoding: tt.strict,
+			}
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			var response mockResponse
+			_, err = Do(cfg, context.Background(), req, &response)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), `unknown field "extra"`) {
+					t.Errorf("Do() error = %v, want unknown field error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			if response.Message != "ok" {
+				t.Errorf("Do() message = %q, want %q", response.Message, "ok")
+			}
+		})
+	}
+}
+
+func TestDo_ExpectedAPIVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		expected   string
+		header     string
+		statusCode int
+		wantErr    error
+	}{
+		{name: "not checked by default", header: "v2", statusCode: http.StatusOK},
+		{name: "matching version", expected: "v1", header: "v1", statusCode: http.StatusOK},
+		{name: "different version", expected: "v1", header: "v2", statusCode: http.StatusOK, wantErr: client.ErrAPIVersionMismatch},
+		{name: "missing header is unknown", expected: "v1", statusCode: http.StatusOK},
+		{name: "no content is checked", expected: "v1", header: "v2", statusCode: http.StatusNoContent, wantErr: client.ErrAPIVersionMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set(client.APIVersionHeader, tt.header)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				if tt.statusCode != http.StatusNoContent {
+					w.Write([]byte(`{"message": "ok"}`))
+				}
+			}))
+			defer server.Close()
+
+			cfg := &client.Config{
+				BaseURL:            client.MgcUrl(server.URL),
+				HTTPClient:         &http.Client{},
+				Logger:             slog.Default(),
+				RetryConfig:        client.RetryConfig{MaxAttempts: 1},
+				ExpectedAPIVersion: tt.expected,
+			}
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			var response mockResponse
+			_, err = Do(cfg, context.Background(), req, &response)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var versionErr *client.APIVersionError
+			if errors.As(err, &versionErr) && (versionErr.Expected != tt.expected || versionErr.Actual != tt.header) {
+				t.Errorf("APIVersionError = %+v, want expected %q and actual %q", versionErr, tt.expected, tt.header)
+			}
+		})
+	}
+}
+
+func TestDo_ExpectedAPIVersionKeepsHTTPErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(client.APIVersionHeader, "v2")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &client.Config{
+		BaseURL:            client.MgcUrl(server.URL),
+		HTTPClient:         &http.Client{},
+		Logger:             slog.Default(),
+		RetryConfig:        client.RetryConfig{MaxAttempts: 1},
+		ExpectedAPIVersion: "v1",
+	}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	_, err = Do[any](cfg, context.Background(), req, nil)
+	var httpErr *client.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Do() error = %v, want HTTPError with status 404", err)
+	}
+}
+
 func TestDo_InvalidContentType(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain")
@@ -1256,7 +1524,7 @@ func TestDo_YAMLHandling(t *testing.T) {
 			statusCode: http.StatusOK,
 			want:       nil,
 			wantErr:    true,
-			errMsg:     "response body is null",
+			errMsg:     "empty response body",
 		},
 		{
 			name:       "malformed yaml",
@@ -1482,3 +1750,719 @@ func TestNewRequest_Authentication(t *testing.T) {
 		})
 	}
 }
+
+func TestDo_Interceptors(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []client.Option
+		wantErr      bool
+		wantRequests int
+		wantHeader   string
+	}{
+		{
+			name: "request interceptors chain in order",
+			opts: []client.Option{
+				client.WithRequestInterceptor(func(r *http.Request) error {
+					r.Header.Set("X-Tenant", "first")
+					return nil
+				}),
+				client.WithRequestInterceptor(func(r *http.Request) error {
+					r.Header.Set("X-Tenant", r.Header.Get("X-Tenant")+",second")
+					return nil
+				}),
+			},
+			wantRequests: 1,
+			wantHeader:   "first,second",
+		},
+		{
+			name: "request interceptor error aborts before sending",
+			opts: []client.Option{
+				client.WithRequestInterceptor(func(*http.Request) error {
+					return fmt.Errorf("denied")
+				}),
+			},
+			wantErr:      true,
+			wantRequests: 0,
+		},
+		{
+			name: "response interceptor error aborts",
+			opts: []client.Option{
+				client.WithResponseInterceptor(func(resp *http.Response) error {
+					if resp.StatusCode != http.StatusOK {
+						t.Errorf("Expected status 200, got %d", resp.StatusCode)
+					}
+					return fmt.Errorf("rejected")
+				}),
+			},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			var gotHeader string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				gotHeader = r.Header.Get("X-Tenant")
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"message":"success"}`))
+			}))
+			defer server.Close()
+
+			opts := append([]client.Option{client.WithBaseURL(client.MgcUrl(server.URL))}, tt.opts...)
+			cfg := client.NewMgcClient(opts...).GetConfig()
+
+			req, err := NewRequest[any](cfg, context.Background(), http.MethodGet, "/test", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			var response mockResponse
+			_, err = Do(cfg, context.Background(), req, &response)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("Expected %d requests, got %d", tt.wantRequests, requests)
+			}
+			if gotHeader != tt.wantHeader {
+				t.Errorf("Expected X-Tenant %q, got %q", tt.wantHeader, gotHeader)
+			}
+		})
+	}
+}
+
+type observation struct {
+	service     string
+	method      string
+	statusClass string
+}
+
+type recordingMetrics struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (m *recordingMetrics) ObserveRequest(service, method, statusClass string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observations = append(m.observations, observation{service, method, statusClass})
+}
+
+func TestDo_Metrics(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":"success"}`))
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetrics{}
+	cfg := client.NewMgcClient(
+		client.WithBaseURL(client.MgcUrl(server.URL+"/br-se1")),
+		client.WithRetryConfig(3, time.Millisecond, time.Millisecond, 1),
+		client.WithMetrics(metrics),
+	).GetConfig()
+
+	req, err := NewRequest[any](cfg, context.Background(), http.MethodGet, "/compute/v1/instances", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	var response mockResponse
+	if _, err := Do(cfg, context.Background(), req, &response); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	want := []observation{
+		{"compute", http.MethodGet, client.StatusClass4xx},
+		{"compute", http.MethodGet, client.StatusClass2xx},
+	}
+	if !reflect.DeepEqual(metrics.observations, want) {
+		t.Errorf("Expected observations %v, got %v", want, metrics.observations)
+	}
+}
+
+func TestDo_MetricsTransportError(t *testing.T) {
+	metrics := &recordingMetrics{}
+	cfg := client.NewMgcClient(
+		client.WithBaseURL(client.MgcUrl("http://127.0.0.1:1")),
+		client.WithRetryConfig(1, time.Millisecond, time.Millisecond, 1),
+		client.WithMetrics(metrics),
+	).GetConfig()
+
+	req, err := NewRequest[any](cfg, context.Background(), http.MethodGet, "/network/v1/vpcs", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	if _, err := Do[any](cfg, context.Background(), req, nil); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	want := []observation{{"network", http.MethodGet, client.StatusClassError}}
+	if !reflect.DeepEqual(metrics.observations, want) {
+		t.Errorf("Expected observations %v, got %v", want, metrics.observations)
+	}
+}
+
+// fakeClockContext returns a context whose requests wait on a fake clock.
+func fakeClockContext() (context.Context, *clock.Fake) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	return clock.NewContext(context.Background(), fake), fake
+}
+
+func TestDo_RetryBackoffUsesClock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := client.NewMgcClient(
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRetryConfig(4, time.Second, 3*time.Second, 2),
+	).GetConfig()
+	ctx, fake := fakeClockContext()
+
+	req, err := NewRequest[any](cfg, ctx, http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	start := time.Now()
+	_, err = Do[any](cfg, ctx, req, nil)
+	if _, ok := err.(*client.RetryError); !ok {
+		t.Fatalf("Expected *client.RetryError, got %T: %v", err, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected retries without real sleeps, took %v", elapsed)
+	}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+	if got := fake.Sleeps(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected backoffs %v, got %v", want, got)
+	}
+}
+
+func TestDo_RetryBudget(t *testing.T) {
+	failing := true
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := client.NewMgcClient(
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRetryConfig(3, time.Second, 3*time.Second, 2),
+		client.WithRetryBudget(0.5),
+	).GetConfig()
+	ctx, _ := fakeClockContext()
+
+	do := func() error {
+		req, err := NewRequest[any](cfg, ctx, http.MethodGet, "/test", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		_, err = Do[any](cfg, ctx, req, nil)
+		return err
+	}
+
+	// Each failing call retries twice until the ten tokens run out
+	for range 5 {
+		if err := do(); errors.Is(err, client.ErrRetryBudgetExhausted) {
+			t.Fatalf("Expected retries while the budget lasts, got %v", err)
+		}
+	}
+	if attempts != 15 {
+		t.Errorf("Expected 15 attempts, got %d", attempts)
+	}
+
+	attempts = 0
+	err := do()
+	if !errors.Is(err, client.ErrRetryBudgetExhausted) {
+		t.Fatalf("Expected ErrRetryBudgetExhausted, got %v", err)
+	}
+	var httpErr *client.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected the last HTTP error to be wrapped, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt once the budget is exhausted, got %d", attempts)
+	}
+
+	// Two successes refill one retry
+	failing = false
+	for range 2 {
+		if err := do(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if got := cfg.RetryBudget.Tokens(); got != 1 {
+		t.Errorf("Expected 1 token after two successes, got %v", got)
+	}
+}
+
+func TestDo_CircuitBreaker(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	cfg := client.NewMgcClient(
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRetryConfig(3, time.Second, 3*time.Second, 2),
+		client.WithCircuitBreaker(2, time.Minute),
+	).GetConfig()
+	ctx, _ := fakeClockContext()
+
+	do := func() error {
+		req, err := NewRequest[any](cfg, ctx, http.MethodGet, "/test", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		_, err = Do[any](cfg, ctx, req, nil)
+		return err
+	}
+
+	// The second failed attempt opens the circuit, stopping the retries
+	err := do()
+	if !errors.Is(err, client.ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	var httpErr *client.HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Errorf("Expected the last HTTP error to be wrapped, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+
+	attempts = 0
+	if err := do(); !errors.Is(err, client.ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if attempts != 0 {
+		t.Errorf("Expected no request while the circuit is open, got %d", attempts)
+	}
+}
+
+func TestDo_CircuitBreakerIgnoresClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	cfg := client.NewMgcClient(
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithCircuitBreaker(1, time.Minute),
+	).GetConfig()
+
+	for range 3 {
+		req, err := NewRequest[any](cfg, context.Background(), http.MethodGet, "/test", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		_, err = Do[any](cfg, context.Background(), req, nil)
+		var httpErr *client.HTTPError
+		if !errors.As(err, &httpErr) || errors.Is(err, client.ErrCircuitOpen) {
+			t.Fatalf("Expected a plain HTTP error, got %v", err)
+		}
+	}
+	if state := cfg.CircuitBreaker.State(); state != client.CircuitClosed {
+		t.Errorf("Expected the circuit to stay closed, got %s", state)
+	}
+}
+
+func TestDo_CircuitBreakerIgnoresCanceledRequests(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	cfg := client.NewMgcClient(
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithCircuitBreaker(1, time.Minute),
+	).GetConfig()
+
+	req, err := NewRequest[any](cfg, ctx, http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if _, err := Do[any](cfg, ctx, req, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if state := cfg.CircuitBreaker.State(); state != client.CircuitClosed {
+		t.Errorf("Expected a canceled request not to open the circuit, got %s", state)
+	}
+}
+
+func TestDo_CompressedResponse(t *testing.T) {
+	compress := map[string]func(io.Writer) io.WriteCloser{
+		"gzip":    func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		"deflate": func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+	}
+
+	tests := []struct {
+		name           string
+		encoding       string
+		statusCode     int
+		customDecoding bool
+		wantErr        bool
+	}{
+		{name: "gzip", encoding: "gzip", statusCode: http.StatusOK},
+		{name: "gzip with custom transport", encoding: "gzip", statusCode: http.StatusOK, customDecoding: true},
+		{name: "deflate with custom transport", encoding: "deflate", statusCode: http.StatusOK, customDecoding: true},
+		{name: "identity", encoding: "", statusCode: http.StatusOK},
+		{name: "gzip error body", encoding: "gzip", statusCode: http.StatusBadRequest, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// http.Transport asks for gzip itself unless compression is disabled.
+				wantAccept := "gzip"
+				if tt.customDecoding {
+					wantAccept = ""
+				}
+				if got := r.Header.Get("Accept-Encoding"); got != wantAccept {
+					t.Errorf("Expected Accept-Encoding %q, got %q", wantAccept, got)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				body := io.Writer(w)
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				w.WriteHeader(tt.statusCode)
+				if tt.encoding != "" {
+					zw := compress[tt.encoding](w)
+					defer zw.Close()
+					body = zw
+				}
+				body.Write([]byte(`{"message":"compressed"}`))
+			}))
+			defer server.Close()
+
+			opts := []client.Option{client.WithBaseURL(client.MgcUrl(server.URL))}
+			if tt.customDecoding {
+				// Without transparent decompression the body reaches Do still encoded.
+				opts = append(opts, client.WithHTTPClient(&http.Client{Transport: &http.Transport{DisableCompression: true}}))
+			}
+			cfg := client.NewMgcClient(opts...).GetConfig()
+			req, err := NewRequest[any](cfg, context.Background(), http.MethodGet, "/test", nil)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+
+			var response mockResponse
+			_, err = Do(cfg, context.Background(), req, &response)
+			if tt.wantErr {
+				httpErr, ok := err.(*client.HTTPError)
+				if !ok {
+					t.Fatalf("Expected *client.HTTPError, got %T: %v", err, err)
+				}
+				if string(httpErr.Body) != `{"message":"compressed"}` {
+					t.Errorf("Expected decompressed error body, got %q", httpErr.Body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Do() unexpected error: %v", err)
+			}
+			if response.Message != "compressed" {
+				t.Errorf("Expected message %q, got %q", "compressed", response.Message)
+			}
+		})
+	}
+}
+
+func TestDo_InvalidGzipResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write([]byte("not gzip"))
+	}))
+	defer server.Close()
+
+	cfg := client.NewMgcClient(
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithHTTPClient(&http.Client{Transport: &http.Transport{DisableCompression: true}}),
+	).GetConfig()
+	req, err := NewRequest[any](cfg, context.Background(), http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	var response mockResponse
+	if _, err := Do(cfg, context.Background(), req, &response); err == nil {
+		t.Fatal("Expected error for invalid gzip body, got nil")
+	}
+}
+
+func TestDo_ResponseCache(t *testing.T) {
+	tests := []struct {
+		name         string
+		etag         string
+		lastModified string
+		gzip         bool
+		strict       bool
+		body         string
+		wantCached   bool
+		wantErr      bool
+	}{
+		{name: "etag", etag: `"v1"`, body: `{"message":"cached"}`, wantCached: true},
+		{name: "last modified", lastModified: "Mon, 02 Jan 2006 15:04:05 GMT", body: `{"message":"cached"}`, wantCached: true},
+		{name: "gzip body stored decompressed", etag: `"v1"`, gzip: true, body: `{"message":"cached"}`, wantCached: true},
+		{name: "no validators", body: `{"message":"cached"}`},
+		{name: "strict decoding applies to cached body", etag: `"v1"`, strict: true, body: `{"message":"cached","extra":1}`, wantCached: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests, notModified int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if (tt.etag != "" && r.Header.Get("If-None-Match") == tt.etag) ||
+					(tt.lastModified != "" && r.Header.Get("If-Modified-Since") == tt.lastModified) {
+					notModified++
+					w.Header().Set("X-Request-ID", "revalidated")
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				if tt.etag != "" {
+					w.Header().Set("ETag", tt.etag)
+				}
+				if tt.lastModified != "" {
+					w.Header().Set("Last-Modified", tt.lastModified)
+				}
+				if tt.gzip {
+					w.Header().Set("Content-Encoding", "gzip")
+					zw := gzip.NewWriter(w)
+					defer zw.Close()
+					zw.Write([]byte(tt.body))
+					return
+				}
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			cfg := client.NewMgcClient(client.WithBaseURL(client.MgcUrl(server.URL)), client.WithResponseCache(10)).GetConfig()
+			cfg.StrictDecoding = tt.strict
+
+			for i := range 2 {
+				req, err := NewRequest[any](cfg, context.Background(), http.MethodGet, "/images", nil)
+				if err != nil {
+					t.Fatalf("Failed to create request: %v", err)
+				}
+				var response mockResponse
+				_, err = Do(cfg, context.Background(), req, &response)
+				if tt.wantErr {
+					if err == nil {
+						t.Errorf("Do() call %d expected error, got nil", i+1)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("Do() call %d unexpected error: %v", i+1, err)
+				}
+				if response.Message != "cached" {
+					t.Errorf("Do() call %d message = %q, want %q", i+1, response.Message, "cached")
+				}
+			}
+
+			if requests != 2 {
+				t.Errorf("Expected 2 requests, got %d", requests)
+			}
+			if tt.wantCached != (notModified == 1) {
+				t.Errorf("Expected cached %v, got %d not modified responses", tt.wantCached, notModified)
+			}
+		})
+	}
+}
+
+func TestDo_ResponseCacheSkipsOtherMethods(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Error("Expected no conditional header on a POST")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"message":"created"}`))
+	}))
+	defer server.Close()
+
+	cfg := client.NewMgcClient(client.WithBaseURL(client.MgcUrl(server.URL)), client.WithResponseCache(10)).GetConfig()
+	for range 2 {
+		req, err := NewRequest(cfg, context.Background(), http.MethodPost, "/images", &mockRequest{})
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		var response mockResponse
+		if _, err := Do(cfg, context.Background(), req, &response); err != nil {
+			t.Fatalf("Do() unexpected error: %v", err)
+		}
+	}
+	if got := cfg.ResponseCache.Len(); got != 0 {
+		t.Errorf("Expected no cached responses, got %d", got)
+	}
+}
+
+func TestDo_ResponseCacheKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		vary      string
+		body      string
+		zones     []string
+		wantLen   int
+		wantConds int
+	}{
+		{name: "request headers select the entry", zones: []string{"br-se1-a", "br-se1-b", "br-se1-a"}, wantLen: 2, wantConds: 1},
+		{name: "vary on a keyed header", vary: "X-Zone", zones: []string{"br-se1-a", "br-se1-a"}, wantLen: 1, wantConds: 1},
+		{name: "vary on every header", vary: "*", zones: []string{"br-se1-a", "br-se1-a"}},
+		{name: "vary on the request ID", vary: "X-Request-ID", zones: []string{"br-se1-a", "br-se1-a"}},
+		{name: "body over the limit", body: strings.Repeat(" ", client.MaxCachedResponseBytes), zones: []string{"br-se1-a", "br-se1-a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var conditional int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("If-None-Match") != "" {
+					conditional++
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("ETag", `"`+r.Header.Get("X-Zone")+`"`)
+				if tt.vary != "" {
+					w.Header().Set("Vary", tt.vary)
+				}
+				w.Write([]byte(`{"message":"` + r.Header.Get("X-Zone") + `"}` + tt.body))
+			}))
+			defer server.Close()
+
+			cfg := client.NewMgcClient(client.WithBaseURL(client.MgcUrl(server.URL)), client.WithResponseCache(10)).GetConfig()
+			for _, zone := range tt.zones {
+				req, err := NewRequest[any](cfg, context.Background(), http.MethodGet, "/vpcs", nil)
+				if err != nil {
+					t.Fatalf("Failed to create request: %v", err)
+				}
+				req.Header.Set("X-Zone", zone)
+				var response mockResponse
+				if _, err := Do(cfg, context.Background(), req, &response); err != nil {
+					t.Fatalf("Do() unexpected error: %v", err)
+				}
+				if response.Message != zone {
+					t.Errorf("Expected message %q, got %q", zone, response.Message)
+				}
+			}
+			if got := cfg.ResponseCache.Len(); got != tt.wantLen {
+				t.Errorf("Expected %d cached responses, got %d", tt.wantLen, got)
+			}
+			if conditional != tt.wantConds {
+				t.Errorf("Expected %d conditional requests, got %d", tt.wantConds, conditional)
+			}
+		})
+	}
+}
+
+func TestDo_RateLimitHeaders(t *testing.T) {
+	remaining := 5
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(client.RateLimitRemainingHeader, strconv.Itoa(remaining))
+		w.Header().Set(client.RateLimitResetHeader, "60")
+		if remaining == 0 {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"rate limited"}`))
+			return
+		}
+		w.Write([]byte(`{"message":"ok"}`))
+	}))
+	defer server.Close()
+
+	core := client.NewMgcClient(client.WithBaseURL(client.MgcUrl(server.URL)))
+	cfg := core.GetConfig()
+
+	for _, want := range []int{5, 0} {
+		remaining = want
+		req, err := NewRequest[any](cfg, context.Background(), http.MethodGet, "/test", nil)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		var response mockResponse
+		Do(cfg, context.Background(), req, &response)
+
+		got, reset, ok := core.RateLimitStatus()
+		if !ok || got != want || reset.IsZero() {
+			t.Errorf("RateLimitStatus() = %d, %v, %v; want %d with a reset time", got, reset, ok, want)
+		}
+	}
+}
+
+func TestNewRequest_Compression(t *testing.T) {
+	var gotEncodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotEncodings = append(gotEncodings, r.Header.Get("Content-Encoding"))
+
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("Failed to read gzip body: %v", err)
+				return
+			}
+			body = zr
+		}
+		var payload map[string]string
+		if err := json.NewDecoder(body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+
+		w.Header().Set("Accept-Encoding", "gzip")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":"ok"}`))
+	}))
+	defer server.Close()
+
+	cfg := client.NewMgcClient(
+		client.WithBaseURL(client.MgcUrl(server.URL)),
+		client.WithRequestCompression(64),
+	).GetConfig()
+
+	small := map[string]string{"name": "small"}
+	large := map[string]string{"name": strings.Repeat("a", 128)}
+	for _, body := range []map[string]string{large, small, large} {
+		req, err := NewRequest(cfg, context.Background(), http.MethodPost, "/test", &body)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		var response mockResponse
+		if _, err := Do(cfg, context.Background(), req, &response); err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+	}
+
+	// The first large body is sent uncompressed because the server has not advertised gzip yet.
+	want := []string{"", "", "gzip"}
+	if !slices.Equal(gotEncodings, want) {
+		t.Errorf("Content-Encoding = %q, want %q", gotEncodings, want)
+	}
+}
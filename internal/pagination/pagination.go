@@ -0,0 +1,231 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/internal/clock"
+)
+
+const (
+	// DefaultWorkers is the number of pages fetched concurrently.
+	DefaultWorkers = 4
+	// DefaultMaxJitter is the upper bound of the random delay applied before each concurrent fetch.
+	DefaultMaxJitter = 50 * time.Millisecond
+)
+
+// ErrRepeatedPageToken is returned when the API hands out a page token it already
+// returned during the same listing, which would otherwise loop forever.
+var ErrRepeatedPageToken = errors.New("pagination: repeated page token")
+
+// PageFetcher fetches the page starting at offset.
+// It returns the items of the page and the total number of items reported by the API,
+// or zero when the API does not report it.
+type PageFetcher[T any] func(ctx context.Context, offset, limit int) ([]T, int, error)
+
+// CursorFetcher fetches the page following token, or the page starting at offset when
+// token is empty. Besides the items and the total, it returns the token of the next
+// page, or an empty string on the last page or when the API does not return tokens.
+type CursorFetcher[T any] func(ctx context.Context, token string, offset, limit int) ([]T, int, string, error)
+
+// FetchAll retrieves every page of a listing.
+// The first page is fetched to learn the total count; the remaining pages are then
+// fetched concurrently by a bounded worker pool, each after a small random delay to
+// avoid bursts against the API, waited for on clk. When no total is reported, pages are
+// fetched sequentially until a short page is returned. Results keep the API ordering.
+func FetchAll[T any](ctx context.Context, clk clock.Clock, limit int, fetch PageFetcher[T]) ([]T, error) {
+	all, err := FetchAllPartial(ctx, clk, limit, fetch)
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// FetchAllPartial is FetchAll, except that on error it also returns the items fetched
+// before the first failed page, in API order.
+func FetchAllPartial[T any](ctx context.Context, clk clock.Clock, limit int, fetch PageFetcher[T]) ([]T, error) {
+	first, total, err := fetch(ctx, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetchRemaining(ctx, clk, limit, first, total, fetch)
+}
+
+// FetchAllCursor retrieves every page of a listing, following page tokens when the
+// API returns them. Unlike offsets, tokens keep the results consistent when items are
+// created or deleted during the iteration, so pages are then fetched sequentially.
+// When the first page carries no token, the remaining pages are fetched by offset as
+// in FetchAll. A token returned twice stops the listing with ErrRepeatedPageToken.
+func FetchAllCursor[T any](ctx context.Context, clk clock.Clock, limit int, fetch CursorFetcher[T]) ([]T, error) {
+	all, err := FetchAllCursorPartial(ctx, clk, limit, fetch)
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// FetchAllCursorPartial is FetchAllCursor, except that on error it also returns the
+// items fetched before the first failed page, in API order.
+func FetchAllCursorPartial[T any](ctx context.Context, clk clock.Clock, limit int, fetch CursorFetcher[T]) ([]T, error) {
+	first, total, next, err := fetch(ctx, "", 0, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if next == "" {
+		return fetchRemaining(ctx, clk, limit, first, total, func(ctx context.Context, offset, limit int) ([]T, int, error) {
+			page, total, _, err := fetch(ctx, "", offset, limit)
+			return page, total, err
+		})
+	}
+
+	all := first
+	seen := make(map[string]bool)
+	for next != "" {
+		if seen[next] {
+			return all, fmt.Errorf("%w: %q", ErrRepeatedPageToken, next)
+		}
+		seen[next] = true
+
+		var page []T
+		page, _, next, err = fetch(ctx, next, 0, limit)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, page...)
+	}
+
+	return all, nil
+}
+
+// fetchRemaining fetches the pages following first by offset.
+// On error, it returns the items of the pages fetched before the first failed one.
+func fetchRemaining[T any](ctx context.Context, clk clock.Clock, limit int, first []T, total int, fetch PageFetcher[T]) ([]T, error) {
+	if total <= 0 {
+		return fetchSequential(ctx, limit, first, fetch)
+	}
+
+	if len(first) >= total {
+		return first, nil
+	}
+
+	offsets := make([]int, 0, total/limit)
+	for offset := limit; offset < total; offset += limit {
+		offsets = append(offsets, offset)
+	}
+
+	return fetchConcurrent(ctx, clk, limit, total, first, offsets, fetch)
+}
+
+// fetchSequential follows pages one by one until a short page is returned.
+func fetchSequential[T any](ctx context.Context, limit int, first []T, fetch PageFetcher[T]) ([]T, error) {
+	all := first
+	page := first
+	for offset := limit; len(page) >= limit; offset += limit {
+		var err error
+		page, _, err = fetch(ctx, offset, limit)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, page...)
+	}
+
+	return all, nil
+}
+
+// fetchConcurrent fetches the pages at the given offsets with a bounded worker pool.
+// An error stops the fetches of the following pages, while the preceding ones complete.
+// The error of the first failed page is returned with the items of the pages before it.
+func fetchConcurrent[T any](ctx context.Context, clk clock.Clock, limit, total int, first []T, offsets []int, fetch PageFetcher[T]) ([]T, error) {
+	pages := make([][]T, len(offsets))
+	cancels := make([]context.CancelFunc, len(offsets))
+	jobs := make(chan int)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failed   = len(offsets)
+		firstErr error
+	)
+
+	// start returns the context of page i, or false when an earlier page has failed.
+	start := func(i int) (context.Context, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if i > failed {
+			return nil, false
+		}
+		pageCtx, cancel := context.WithCancel(ctx)
+		cancels[i] = cancel
+		return pageCtx, true
+	}
+
+	// fail records the error of page i and cancels the following pages.
+	fail := func(i int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if i > failed {
+			return
+		}
+		failed, firstErr = i, err
+		for _, cancel := range cancels[i+1:] {
+			if cancel != nil {
+				cancel()
+			}
+		}
+	}
+
+	for range min(DefaultWorkers, len(offsets)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				pageCtx, ok := start(i)
+				if !ok {
+					continue
+				}
+
+				page, err := fetchPage(pageCtx, clk, offsets[i], limit, fetch)
+				if err != nil {
+					fail(i, err)
+					continue
+				}
+				pages[i] = page
+			}
+		}()
+	}
+
+	for i := range offsets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, cancel := range cancels {
+		if cancel != nil {
+			cancel()
+		}
+	}
+
+	all := make([]T, 0, total)
+	all = append(all, first...)
+	for _, page := range pages[:failed] {
+		all = append(all, page...)
+	}
+
+	return all, firstErr
+}
+
+// fetchPage fetches the page at offset after a small random delay, waited on clk.
+func fetchPage[T any](ctx context.Context, clk clock.Clock, offset, limit int, fetch PageFetcher[T]) ([]T, error) {
+	if err := clk.Sleep(ctx, rand.N(DefaultMaxJitter)); err != nil {
+		return nil, err
+	}
+	page, _, err := fetch(ctx, offset, limit)
+	return page, err
+}
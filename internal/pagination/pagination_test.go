@@ -0,0 +1,273 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/internal/clock"
+)
+
+func newFetcher(items []int, reportTotal bool, calls *atomic.Int32) PageFetcher[int] {
+	return func(ctx context.Context, offset, limit int) ([]int, int, error) {
+		calls.Add(1)
+		total := 0
+		if reportTotal {
+			total = len(items)
+		}
+		if offset >= len(items) {
+			return []int{}, total, nil
+		}
+		end := min(offset+limit, len(items))
+		return items[offset:end], total, nil
+	}
+}
+
+func sequence(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+func TestFetchAll(t *testing.T) {
+	tests := []struct {
+		name        string
+		items       int
+		reportTotal bool
+		wantCalls   int32
+	}{
+		{name: "single page", items: 10, reportTotal: true, wantCalls: 1},
+		{name: "multiple pages with total", items: 125, reportTotal: true, wantCalls: 3},
+		{name: "exact pages with total", items: 100, reportTotal: true, wantCalls: 2},
+		{name: "multiple pages without total", items: 125, reportTotal: false, wantCalls: 3},
+		{name: "exact pages without total", items: 100, reportTotal: false, wantCalls: 3},
+		{name: "empty", items: 0, reportTotal: true, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			items := sequence(tt.items)
+
+			got, err := FetchAll(context.Background(), clock.Real{}, 50, newFetcher(items, tt.reportTotal, &calls))
+			if err != nil {
+				t.Fatalf("FetchAll() error = %v", err)
+			}
+
+			if len(got) != len(items) {
+				t.Fatalf("FetchAll() returned %d items, want %d", len(got), len(items))
+			}
+
+			for i, v := range got {
+				if v != i {
+					t.Fatalf("FetchAll() item %d = %d, results out of order", i, v)
+				}
+			}
+
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("FetchAll() made %d calls, want %d", calls.Load(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestFetchAll_Error(t *testing.T) {
+	wantErr := errors.New("page failed")
+
+	t.Run("first page", func(t *testing.T) {
+		_, err := FetchAll(context.Background(), clock.Real{}, 50, func(ctx context.Context, offset, limit int) ([]int, int, error) {
+			return nil, 0, wantErr
+		})
+		if !errors.Is(err, wantErr) {
+			t.Errorf("FetchAll() error = %v, want %v", err, wantErr)
+		}
+	})
+
+	t.Run("concurrent page", func(t *testing.T) {
+		_, err := FetchAll(context.Background(), clock.Real{}, 10, func(ctx context.Context, offset, limit int) ([]int, int, error) {
+			if offset == 30 {
+				return nil, 0, wantErr
+			}
+			return sequence(limit), 100, nil
+		})
+		if !errors.Is(err, wantErr) {
+			t.Errorf("FetchAll() error = %v, want %v", err, wantErr)
+		}
+	})
+}
+
+func TestFetchAllPartial(t *testing.T) {
+	wantErr := errors.New("page failed")
+	items := sequence(100)
+
+	failAt := func(failOffset int, reportTotal bool) PageFetcher[int] {
+		return func(ctx context.Context, offset, limit int) ([]int, int, error) {
+			if offset == failOffset {
+				return nil, 0, wantErr
+			}
+			total := 0
+			if reportTotal {
+				total = len(items)
+			}
+			return items[offset:min(offset+limit, len(items))], total, nil
+		}
+	}
+
+	tests := []struct {
+		name        string
+		failOffset  int
+		reportTotal bool
+		want        []int
+	}{
+		{name: "first page", failOffset: 0, reportTotal: true, want: nil},
+		{name: "concurrent page", failOffset: 30, reportTotal: true, want: sequence(30)},
+		{name: "sequential page", failOffset: 20, reportTotal: false, want: sequence(20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FetchAllPartial(context.Background(), clock.Real{}, 10, failAt(tt.failOffset, tt.reportTotal))
+			if !errors.Is(err, wantErr) {
+				t.Errorf("FetchAllPartial() error = %v, want %v", err, wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FetchAllPartial() = %v, want %v", got, tt.want)
+			}
+
+			got, err = FetchAll(context.Background(), clock.Real{}, 10, failAt(tt.failOffset, tt.reportTotal))
+			if !errors.Is(err, wantErr) || got != nil {
+				t.Errorf("FetchAll() = %v, %v; want nil, %v", got, err, wantErr)
+			}
+		})
+	}
+}
+
+func TestFetchAllCursorPartial(t *testing.T) {
+	wantErr := errors.New("page failed")
+	items := sequence(125)
+
+	got, err := FetchAllCursorPartial(context.Background(), clock.Real{}, 50, func(ctx context.Context, token string, offset, limit int) ([]int, int, string, error) {
+		start := 0
+		if token != "" {
+			start, _ = strconv.Atoi(token)
+		}
+		if start == 100 {
+			return nil, 0, "", wantErr
+		}
+		end := min(start+limit, len(items))
+		return items[start:end], len(items), strconv.Itoa(end), nil
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("FetchAllCursorPartial() error = %v, want %v", err, wantErr)
+	}
+	if !slices.Equal(got, sequence(100)) {
+		t.Errorf("FetchAllCursorPartial() returned %d items, want the first 100", len(got))
+	}
+}
+
+func TestFetchAllCursor(t *testing.T) {
+	items := sequence(125)
+
+	t.Run("follows tokens", func(t *testing.T) {
+		var tokens []string
+		got, err := FetchAllCursor(context.Background(), clock.Real{}, 50, func(ctx context.Context, token string, offset, limit int) ([]int, int, string, error) {
+			tokens = append(tokens, token)
+			start := 0
+			if token != "" {
+				start, _ = strconv.Atoi(token)
+			}
+			end := min(start+limit, len(items))
+			next := ""
+			if end < len(items) {
+				next = strconv.Itoa(end)
+			}
+			return items[start:end], len(items), next, nil
+		})
+		if err != nil {
+			t.Fatalf("FetchAllCursor() error = %v", err)
+		}
+		if len(got) != len(items) || got[124] != 124 {
+			t.Errorf("FetchAllCursor() returned %d items, want %d in order", len(got), len(items))
+		}
+		if want := []string{"", "50", "100"}; !slices.Equal(tokens, want) {
+			t.Errorf("FetchAllCursor() tokens = %v, want %v", tokens, want)
+		}
+	})
+
+	t.Run("falls back to offsets", func(t *testing.T) {
+		var calls atomic.Int32
+		offsets := newFetcher(items, true, &calls)
+		got, err := FetchAllCursor(context.Background(), clock.Real{}, 50, func(ctx context.Context, token string, offset, limit int) ([]int, int, string, error) {
+			if token != "" {
+				t.Errorf("FetchAllCursor() sent token %q", token)
+			}
+			page, total, err := offsets(ctx, offset, limit)
+			return page, total, "", err
+		})
+		if err != nil {
+			t.Fatalf("FetchAllCursor() error = %v", err)
+		}
+		if len(got) != len(items) || calls.Load() != 3 {
+			t.Errorf("FetchAllCursor() returned %d items in %d calls, want %d in 3", len(got), calls.Load(), len(items))
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		wantErr := errors.New("page failed")
+		_, err := FetchAllCursor(context.Background(), clock.Real{}, 50, func(ctx context.Context, token string, offset, limit int) ([]int, int, string, error) {
+			if token == "next" {
+				return nil, 0, "", wantErr
+			}
+			return sequence(limit), 0, "next", nil
+		})
+		if !errors.Is(err, wantErr) {
+			t.Errorf("FetchAllCursor() error = %v, want %v", err, wantErr)
+		}
+	})
+
+	t.Run("repeated token", func(t *testing.T) {
+		var calls int
+		got, err := FetchAllCursorPartial(context.Background(), clock.Real{}, 50, func(ctx context.Context, token string, offset, limit int) ([]int, int, string, error) {
+			calls++
+			if token == "b" {
+				return sequence(limit), 0, "a", nil
+			}
+			return sequence(limit), 0, "b", nil
+		})
+		if !errors.Is(err, ErrRepeatedPageToken) {
+			t.Errorf("FetchAllCursorPartial() error = %v, want %v", err, ErrRepeatedPageToken)
+		}
+		if calls != 3 || len(got) != 150 {
+			t.Errorf("FetchAllCursorPartial() made %d calls returning %d items, want 3 calls and 150 items", calls, len(got))
+		}
+	})
+}
+
+func TestFetchAllJitterUsesClock(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	var calls atomic.Int32
+	got, err := FetchAllPartial(context.Background(), fake, 10, newFetcher(sequence(45), true, &calls))
+	if err != nil {
+		t.Fatalf("FetchAllPartial() unexpected error: %v", err)
+	}
+	if len(got) != 45 {
+		t.Fatalf("FetchAllPartial() returned %d items, want 45", len(got))
+	}
+
+	sleeps := fake.Sleeps()
+	if len(sleeps) != 4 {
+		t.Fatalf("expected one jitter delay per concurrent page, got %d", len(sleeps))
+	}
+	for _, d := range sleeps {
+		if d < 0 || d >= DefaultMaxJitter {
+			t.Errorf("jitter %v out of range [0, %v)", d, DefaultMaxJitter)
+		}
+	}
+}
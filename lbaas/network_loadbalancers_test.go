@@ -316,8 +316,8 @@ func TestNetworkLoadBalancerService_Get(t *testing.T) {
 			if tt.wantErr {
 				assertError(t, err)
 				if tt.name == "nil response body" {
-					// The nil response body test should trigger JSON parsing error, not the custom error
-					assertEqual(t, true, strings.Contains(err.Error(), "unexpected end of JSON input") || strings.Contains(err.Error(), "EOF"))
+					// An empty body is reported as such, not as an HTTP error
+					assertEqual(t, true, strings.Contains(err.Error(), "empty response body"))
 				} else {
 					assertEqual(t, true, strings.Contains(err.Error(), strconv.Itoa(tt.statusCode)))
 				}
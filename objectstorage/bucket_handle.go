@@ -0,0 +1,394 @@
+package objectstorage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+)
+
+// BucketHandle provides object and bucket configuration operations scoped to a single bucket,
+// for applications that work against one bucket and would otherwise repeat its name on every call.
+type BucketHandle struct {
+	name    string
+	err     error
+	objects ObjectService
+	buckets BucketService
+}
+
+// validateBucketName checks a bucket name against the S3 naming rules.
+func validateBucketName(name string) error {
+	if err := s3utils.CheckValidBucketNameStrict(name); err != nil {
+		return &InvalidBucketNameError{Name: name}
+	}
+	return nil
+}
+
+// Bucket returns a handle to the named bucket.
+// The bucket name is validated once here; when it is invalid, every operation
+// of the handle returns the validation error, which is also reported by Err.
+func (c *ObjectStorageClient) Bucket(name string) *BucketHandle {
+	return &BucketHandle{
+		name:    name,
+		err:     validateBucketName(name),
+		objects: c.Objects(),
+		buckets: c.Buckets(),
+	}
+}
+
+// DefaultBucket returns a handle to the bucket set with WithDefaultBucket.
+func (c *ObjectStorageClient) DefaultBucket() (*BucketHandle, error) {
+	if c.defaultBucket == "" {
+		return nil, &InvalidBucketNameError{Name: c.defaultBucket}
+	}
+
+	h := c.Bucket(c.defaultBucket)
+	if h.err != nil {
+		return nil, h.err
+	}
+
+	return h, nil
+}
+
+// Name returns the name of the bucket.
+func (h *BucketHandle) Name() string {
+	return h.name
+}
+
+// Err returns the validation error of the bucket name, if any.
+func (h *BucketHandle) Err() error {
+	return h.err
+}
+
+// Upload uploads an object to the bucket.
+func (h *BucketHandle) Upload(ctx context.Context, objectKey string, data []byte, contentType string) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.objects.Upload(ctx, h.name, objectKey, data, contentType)
+}
+
+// UploadStream uploads an object of known size to the bucket from a reader.
+func (h *BucketHandle) UploadStream(ctx context.Context, objectKey string, data io.Reader, size int64, contentType string) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.objects.UploadStream(ctx, h.name, objectKey, data, size, contentType)
+}
+
+// UploadFromReader uploads an object of unknown size to the bucket from a reader.
+func (h *BucketHandle) UploadFromReader(ctx context.Context, objectKey string, data io.Reader, opts StreamOptions) (*UploadInfo, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.objects.UploadFromReader(ctx, h.name, objectKey, data, opts)
+}
+
+// Download downloads an object from the bucket.
+func (h *BucketHandle) Download(ctx context.Context, objectKey string, opts *DownloadOptions) ([]byte, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.objects.Download(ctx, h.name, objectKey, opts)
+}
+
+// DownloadStream downloads an object from the bucket as a stream.
+func (h *BucketHandle) DownloadStream(ctx context.Context, objectKey string, opts *DownloadStreamOptions) (io.Reader, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.objects.DownloadStream(ctx, h.name, objectKey, opts)
+}
+
+// DownloadRange writes a byte range of an object in the bucket to w.
+// See ObjectService.DownloadRange.
+func (h *BucketHandle) DownloadRange(ctx context.Context, objectKey string, start int64, end int64, w io.Writer) (ObjectMetadata, error) {
+	if h.err != nil {
+		return ObjectMetadata{}, h.err
+	}
+	return h.objects.DownloadRange(ctx, h.name, objectKey, start, end, w)
+}
+
+// GetAfterPut downloads an object that was just written once it has the expected ETag.
+// See ObjectService.GetAfterPut.
+func (h *BucketHandle) GetAfterPut(ctx context.Context, objectKey string, etag string) (io.Reader, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.objects.GetAfterPut(ctx, h.name, objectKey, etag)
+}
+
+// Copy duplicates an object within the bucket. See ObjectService.Copy.
+func (h *BucketHandle) Copy(ctx context.Context, srcKey string, dstKey string, opts CopyOptions) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.objects.Copy(ctx, h.name, srcKey, h.name, dstKey, opts)
+}
+
+// Compose creates an object in the bucket by concatenating sources. See ObjectService.Compose.
+func (h *BucketHandle) Compose(ctx context.Context, dstKey string, sources []ComposeSource) (*UploadInfo, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.objects.Compose(ctx, h.name, dstKey, sources)
+}
+
+// Move relocates an object within the bucket. See ObjectService.Move.
+func (h *BucketHandle) Move(ctx context.Context, srcKey string, dstKey string, opts MoveOptions) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.objects.Move(ctx, h.name, srcKey, h.name, dstKey, opts)
+}
+
+// List retrieves a page of the objects in the bucket.
+func (h *BucketHandle) List(ctx context.Context, opts ObjectListOptions) ([]Object, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.objects.List(ctx, h.name, opts)
+}
+
+// ListAll retrieves all objects in the bucket.
+func (h *BucketHandle) ListAll(ctx context.Context, opts ObjectFilterOptions) ([]Object, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.objects.ListAll(ctx, h.name, opts)
+}
+
+// Iter returns an iterator over the objects in the bucket.
+// An invalid bucket name is reported by the iterator's Err.
+func (h *BucketHandle) Iter(ctx context.Context, opts ObjectListOptions) *ObjectIterator {
+	if h.err != nil {
+		return &ObjectIterator{err: h.err}
+	}
+	return h.objects.Iter(ctx, h.name, opts)
+}
+
+// Delete removes an object from the bucket.
+func (h *BucketHandle) Delete(ctx context.Context, objectKey string, opts *DeleteOptions) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.objects.Delete(ctx, h.name, objectKey, opts)
+}
+
+// RemoveObjectsStream deletes the objects whose keys are received from objectKeys,
+// emitting failures as they occur. See ObjectService.RemoveObjectsStream.
+func (h *BucketHandle) RemoveObjectsStream(ctx context.Context, objectKeys <-chan string) <-chan ObjectError {
+	if h.err != nil {
+		errCh := make(chan ObjectError, 1)
+		errCh <- ObjectError{Operation: "delete", Bucket: h.name, Message: h.err.Error()}
+		close(errCh)
+		return errCh
+	}
+	return h.objects.RemoveObjectsStream(ctx, h.name, objectKeys)
+}
+
+// RemovePrefix deletes every object of the bucket whose key starts with prefix.
+// See ObjectService.RemovePrefix.
+func (h *BucketHandle) RemovePrefix(ctx context.Context, prefix string) (int, []ObjectError, error) {
+	if h.err != nil {
+		return 0, nil, h.err
+	}
+	return h.objects.RemovePrefix(ctx, h.name, prefix)
+}
+
+// PreviewRemovePrefix lists the objects RemovePrefix would delete, without deleting them.
+// See ObjectService.PreviewRemovePrefix.
+func (h *BucketHandle) PreviewRemovePrefix(ctx context.Context, prefix string) (*PrefixRemovalPreview, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.objects.PreviewRemovePrefix(ctx, h.name, prefix)
+}
+
+// ListIncompleteUploads lists the incomplete multipart uploads of the bucket whose keys start with prefix.
+func (h *BucketHandle) ListIncompleteUploads(ctx context.Context, prefix string) ([]IncompleteUpload, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.objects.ListIncompleteUploads(ctx, h.name, prefix)
+}
+
+// AbortIncompleteUpload aborts a multipart upload of an object in the bucket.
+func (h *BucketHandle) AbortIncompleteUpload(ctx context.Context, objectKey string, uploadID string) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.objects.AbortIncompleteUpload(ctx, h.name, objectKey, uploadID)
+}
+
+// AbortUploadsOlderThan aborts the incomplete multipart uploads of the bucket started more than age ago.
+// See ObjectService.AbortUploadsOlderThan.
+func (h *BucketHandle) AbortUploadsOlderThan(ctx context.Context, age time.Duration) (int, error) {
+	if h.err != nil {
+		return 0, h.err
+	}
+	return h.objects.AbortUploadsOlderThan(ctx, h.name, age)
+}
+
+// Metadata retrieves the basic metadata of an object in the bucket.
+func (h *BucketHandle) Metadata(ctx context.Context, objectKey string) (*Object, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.objects.Metadata(ctx, h.name, objectKey)
+}
+
+// Stat retrieves the complete metadata of an object in the bucket.
+func (h *BucketHandle) Stat(ctx context.Context, objectKey string) (*ObjectMetadata, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.objects.Stat(ctx, h.name, objectKey)
+}
+
+// GetPresignedURL generates a presigned URL for an object in the bucket.
+func (h *BucketHandle) GetPresignedURL(ctx context.Context, objectKey string, opts GetPresignedURLOptions) (*PresignedURL, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.objects.GetPresignedURL(ctx, h.name, objectKey, opts)
+}
+
+// Exists checks if the bucket exists.
+func (h *BucketHandle) Exists(ctx context.Context) (bool, error) {
+	if h.err != nil {
+		return false, h.err
+	}
+	return h.buckets.Exists(ctx, h.name)
+}
+
+// GetPolicy retrieves the policy of the bucket.
+func (h *BucketHandle) GetPolicy(ctx context.Context) (*Policy, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.buckets.GetPolicy(ctx, h.name)
+}
+
+// SetPolicy sets the policy of the bucket.
+func (h *BucketHandle) SetPolicy(ctx context.Context, policy *Policy) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.buckets.SetPolicy(ctx, h.name, policy)
+}
+
+// DeletePolicy removes the policy of the bucket.
+func (h *BucketHandle) DeletePolicy(ctx context.Context) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.buckets.DeletePolicy(ctx, h.name)
+}
+
+// MakePublic allows anyone to read the objects of the bucket. See BucketService.MakePublic.
+func (h *BucketHandle) MakePublic(ctx context.Context, opts PublicOptions) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.buckets.MakePublic(ctx, h.name, opts)
+}
+
+// MakePrivate removes the public read access granted by MakePublic.
+func (h *BucketHandle) MakePrivate(ctx context.Context) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.buckets.MakePrivate(ctx, h.name)
+}
+
+// IsPublic reports whether the bucket policy grants anonymous access. See BucketService.IsPublic.
+func (h *BucketHandle) IsPublic(ctx context.Context) (bool, string, error) {
+	if h.err != nil {
+		return false, "", h.err
+	}
+	return h.buckets.IsPublic(ctx, h.name)
+}
+
+// GetCORS retrieves the CORS configuration of the bucket.
+func (h *BucketHandle) GetCORS(ctx context.Context) (*CORSConfiguration, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.buckets.GetCORS(ctx, h.name)
+}
+
+// SetCORS sets the CORS configuration of the bucket.
+func (h *BucketHandle) SetCORS(ctx context.Context, corsConfig *CORSConfiguration) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.buckets.SetCORS(ctx, h.name, corsConfig)
+}
+
+// DeleteCORS removes the CORS configuration of the bucket.
+func (h *BucketHandle) DeleteCORS(ctx context.Context) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.buckets.DeleteCORS(ctx, h.name)
+}
+
+// EnableVersioning enables versioning on the bucket.
+func (h *BucketHandle) EnableVersioning(ctx context.Context) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.buckets.EnableVersioning(ctx, h.name)
+}
+
+// SuspendVersioning suspends versioning on the bucket.
+func (h *BucketHandle) SuspendVersioning(ctx context.Context) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.buckets.SuspendVersioning(ctx, h.name)
+}
+
+// GetVersioningStatus retrieves the versioning configuration of the bucket.
+func (h *BucketHandle) GetVersioningStatus(ctx context.Context) (*BucketVersioningConfiguration, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.buckets.GetVersioningStatus(ctx, h.name)
+}
+
+// Usage computes the total size and object count of the bucket, optionally restricted to a prefix.
+func (h *BucketHandle) Usage(ctx context.Context, prefix string) (BucketUsage, error) {
+	if h.err != nil {
+		return BucketUsage{}, h.err
+	}
+	return h.buckets.Usage(ctx, h.name, prefix)
+}
+
+// Region returns the region where the bucket lives. See BucketService.Region.
+func (h *BucketHandle) Region(ctx context.Context) (string, error) {
+	if h.err != nil {
+		return "", h.err
+	}
+	return h.buckets.Region(ctx, h.name)
+}
+
+// AssertRegion checks that the bucket lives in the region of the client endpoint.
+// See BucketService.AssertRegion.
+func (h *BucketHandle) AssertRegion(ctx context.Context) error {
+	if h.err != nil {
+		return h.err
+	}
+	return h.buckets.AssertRegion(ctx, h.name)
+}
+
+// ListenBucketNotification streams the events that occur on the objects of the bucket.
+// See BucketService.ListenBucketNotification.
+func (h *BucketHandle) ListenBucketNotification(ctx context.Context, prefix, suffix string, events []string) (<-chan NotificationEvent, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.buckets.ListenBucketNotification(ctx, h.name, prefix, suffix, events)
+}
@@ -0,0 +1,272 @@
+package objectstorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestWithDefaultBucket(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		bucket   string
+		wantErr  bool
+		errField string
+	}{
+		{name: "valid bucket", bucket: "my-bucket"},
+		{name: "invalid bucket", bucket: "My_Bucket", wantErr: true, errField: "defaultBucket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin",
+				WithMinioClientInterface(newMockMinioClient()), WithDefaultBucket(tt.bucket))
+
+			if tt.wantErr {
+				validationErr, ok := err.(*client.ValidationError)
+				if !ok {
+					t.Fatalf("New() expected *client.ValidationError, got %T", err)
+				}
+				if validationErr.Field != tt.errField {
+					t.Errorf("New() error field = %q, want %q", validationErr.Field, tt.errField)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			handle, err := osClient.DefaultBucket()
+			if err != nil {
+				t.Fatalf("DefaultBucket() error = %v", err)
+			}
+			if handle.Name() != tt.bucket {
+				t.Errorf("DefaultBucket() name = %q, want %q", handle.Name(), tt.bucket)
+			}
+		})
+	}
+}
+
+func TestDefaultBucket_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(newMockMinioClient()))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := osClient.DefaultBucket(); err == nil {
+		t.Error("DefaultBucket() expected error without a default bucket")
+	}
+}
+
+func TestBucket_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		bucket  string
+		wantErr bool
+	}{
+		{name: "valid", bucket: "test-bucket"},
+		{name: "empty", bucket: "", wantErr: true},
+		{name: "too short", bucket: "ab", wantErr: true},
+		{name: "invalid characters", bucket: "bucket!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(newMockMinioClient()))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			err = osClient.Bucket(tt.bucket).Err()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bucket().Err() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if _, ok := err.(*InvalidBucketNameError); !ok {
+					t.Errorf("Bucket().Err() expected InvalidBucketNameError, got %T", err)
+				}
+			}
+		})
+	}
+}
+
+func TestBucketHandleObjectOperations(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockMinioClient()
+	mock.buckets["test-bucket"] = &mockBucket{
+		name:         "test-bucket",
+		creationDate: time.Now(),
+		objects:      make(map[string]*mockObject),
+	}
+
+	osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin",
+		WithMinioClientInterface(mock), WithDefaultBucket("test-bucket"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	handle, err := osClient.DefaultBucket()
+	if err != nil {
+		t.Fatalf("DefaultBucket() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := handle.Upload(ctx, "hello.txt", []byte("hello"), "text/plain"); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	if _, ok := mock.buckets["test-bucket"].objects["hello.txt"]; !ok {
+		t.Fatal("Upload() expected object to be stored in the default bucket")
+	}
+
+	objects, err := handle.ListAll(ctx, ObjectFilterOptions{})
+	if err != nil {
+		t.Fatalf("ListAll() error = %v", err)
+	}
+	if len(objects) != 1 || objects[0].Key != "hello.txt" {
+		t.Errorf("ListAll() = %v, want [hello.txt]", objects)
+	}
+
+	metadata, err := handle.Stat(ctx, "hello.txt")
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if metadata.ContentType != "text/plain" {
+		t.Errorf("Stat() content type = %q, want text/plain", metadata.ContentType)
+	}
+
+	if err := handle.Move(ctx, "hello.txt", "moved.txt", MoveOptions{}); err != nil {
+		t.Fatalf("Move() error = %v", err)
+	}
+	if _, ok := mock.buckets["test-bucket"].objects["moved.txt"]; !ok {
+		t.Fatal("Move() expected object to be stored under the new key")
+	}
+
+	if err := handle.Delete(ctx, "moved.txt", nil); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := mock.buckets["test-bucket"].objects["moved.txt"]; ok {
+		t.Error("Delete() expected object to be removed")
+	}
+}
+
+func TestBucket_InvalidNameFailsEveryOperation(t *testing.T) {
+	t.Parallel()
+
+	osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(newMockMinioClient()))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	handle := osClient.Bucket("Invalid_Bucket")
+	if _, ok := handle.Err().(*InvalidBucketNameError); !ok {
+		t.Fatalf("Err() expected InvalidBucketNameError, got %T", handle.Err())
+	}
+
+	ctx := context.Background()
+	if err := handle.Upload(ctx, "key", []byte("data"), ""); err != handle.Err() {
+		t.Errorf("Upload() error = %v, want %v", err, handle.Err())
+	}
+	if _, err := handle.Download(ctx, "key", nil); err != handle.Err() {
+		t.Errorf("Download() error = %v, want %v", err, handle.Err())
+	}
+	if _, err := handle.Exists(ctx); err != handle.Err() {
+		t.Errorf("Exists() error = %v, want %v", err, handle.Err())
+	}
+	if _, err := handle.Usage(ctx, ""); err != handle.Err() {
+		t.Errorf("Usage() error = %v, want %v", err, handle.Err())
+	}
+}
+
+func TestBucketHandleIncompleteUploads(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	mock := newMockMinioClient()
+	mock.buckets["test-bucket"] = &mockBucket{
+		name:    "test-bucket",
+		objects: make(map[string]*mockObject),
+		uploads: []minio.ObjectMultipartInfo{
+			{Key: "a.bin", UploadID: "old", Initiated: now.Add(-48 * time.Hour)},
+			{Key: "b.bin", UploadID: "recent", Initiated: now},
+		},
+	}
+	osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	handle := osClient.Bucket("test-bucket")
+	ctx := context.Background()
+
+	aborted, err := handle.AbortUploadsOlderThan(ctx, 24*time.Hour)
+	if err != nil || aborted != 1 {
+		t.Fatalf("AbortUploadsOlderThan() = %d, %v, want 1", aborted, err)
+	}
+	if err := handle.AbortIncompleteUpload(ctx, "b.bin", "recent"); err != nil {
+		t.Fatalf("AbortIncompleteUpload() error = %v", err)
+	}
+	uploads, err := handle.ListIncompleteUploads(ctx, "")
+	if err != nil || len(uploads) != 0 {
+		t.Errorf("ListIncompleteUploads() = %v, %v, want none", uploads, err)
+	}
+}
+
+func TestBucketHandleBucketOperations(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockMinioClient()
+	mock.buckets["test-bucket"] = &mockBucket{
+		name:         "test-bucket",
+		creationDate: time.Now(),
+		objects: map[string]*mockObject{
+			"logs/a.log": {key: "logs/a.log", size: 10},
+			"logs/b.log": {key: "logs/b.log", size: 20},
+		},
+	}
+
+	osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	handle := osClient.Bucket("test-bucket")
+	if handle.Err() != nil {
+		t.Fatalf("Err() = %v", handle.Err())
+	}
+
+	ctx := context.Background()
+	exists, err := handle.Exists(ctx)
+	if err != nil || !exists {
+		t.Errorf("Exists() = %v, %v, want true, nil", exists, err)
+	}
+
+	if err := handle.EnableVersioning(ctx); err != nil {
+		t.Fatalf("EnableVersioning() error = %v", err)
+	}
+	status, err := handle.GetVersioningStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetVersioningStatus() error = %v", err)
+	}
+	if status.Status != VersioningStatusEnabled {
+		t.Errorf("GetVersioningStatus() = %v, want %v", status.Status, VersioningStatusEnabled)
+	}
+
+	usage, err := handle.Usage(ctx, "logs/")
+	if err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+	if usage.TotalSize != 30 || usage.ObjectCount != 2 {
+		t.Errorf("Usage() = %+v, want 30 bytes in 2 objects", usage)
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/cors"
+	"github.com/minio/minio-go/v7/pkg/notification"
 )
 
 type LockConfig struct {
@@ -26,6 +30,9 @@ type BucketService interface {
 	GetPolicy(ctx context.Context, bucketName string) (*Policy, error)
 	SetPolicy(ctx context.Context, bucketName string, policy *Policy) error
 	DeletePolicy(ctx context.Context, bucketName string) error
+	MakePublic(ctx context.Context, bucketName string, opts PublicOptions) error
+	MakePrivate(ctx context.Context, bucketName string) error
+	IsPublic(ctx context.Context, bucketName string) (bool, string, error)
 	LockBucket(ctx context.Context, bucketName string, validity uint, unit string) error
 	UnlockBucket(ctx context.Context, bucketName string) error
 	GetBucketLockStatus(ctx context.Context, bucketName string) (bool, error)
@@ -36,6 +43,10 @@ type BucketService interface {
 	EnableVersioning(ctx context.Context, bucketName string) error
 	SuspendVersioning(ctx context.Context, bucketName string) error
 	GetVersioningStatus(ctx context.Context, bucketName string) (*BucketVersioningConfiguration, error)
+	Usage(ctx context.Context, bucketName string, prefix string) (BucketUsage, error)
+	Region(ctx context.Context, bucketName string) (string, error)
+	AssertRegion(ctx context.Context, bucketName string) error
+	ListenBucketNotification(ctx context.Context, bucketName, prefix, suffix string, events []string) (<-chan NotificationEvent, error)
 }
 
 // bucketService implements the BucketService interface.
@@ -49,11 +60,17 @@ func (s *bucketService) Create(ctx context.Context, bucketName string) error {
 		return &InvalidBucketNameError{Name: bucketName}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	return s.client.minioClient.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{})
 }
 
 // List retrieves all buckets.
 func (s *bucketService) List(ctx context.Context) ([]Bucket, error) {
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	buckets, err := s.client.minioClient.ListBuckets(ctx)
 	if err != nil {
 		return nil, err
@@ -76,6 +93,9 @@ func (s *bucketService) Exists(ctx context.Context, bucketName string) (bool, er
 		return false, &InvalidBucketNameError{Name: bucketName}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	return s.client.minioClient.BucketExists(ctx, bucketName)
 }
 
@@ -89,6 +109,9 @@ func (s *bucketService) Delete(ctx context.Context, bucketName string, recursive
 		ctx = WithForceDelete(ctx)
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	return s.client.minioClient.RemoveBucket(ctx, bucketName)
 }
 
@@ -98,6 +121,9 @@ func (s *bucketService) GetPolicy(ctx context.Context, bucketName string) (*Poli
 		return nil, &InvalidBucketNameError{Name: bucketName}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	policyStr, err := s.client.minioClient.GetBucketPolicy(ctx, bucketName)
 	if err != nil {
 		return nil, err
@@ -135,6 +161,9 @@ func (s *bucketService) SetPolicy(ctx context.Context, bucketName string, policy
 		return err
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	return s.client.minioClient.SetBucketPolicy(ctx, bucketName, policyStr)
 }
 
@@ -144,9 +173,211 @@ func (s *bucketService) DeletePolicy(ctx context.Context, bucketName string) err
 		return &InvalidBucketNameError{Name: bucketName}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	return s.client.minioClient.SetBucketPolicy(ctx, bucketName, "")
 }
 
+const (
+	// policyVersion is the version of the S3 bucket policy language.
+	policyVersion = "2012-10-17"
+	// publicReadSid identifies the statement managed by MakePublic and MakePrivate.
+	publicReadSid = "PublicRead"
+)
+
+// MakePublic allows anyone to download the objects of a bucket, or only those under
+// opts.Prefix, by adding a public read statement to the bucket policy. Other statements
+// of the policy are kept, and calling it again replaces the previous public read statement.
+// Returns a BucketError if the bucket does not exist.
+func (s *bucketService) MakePublic(ctx context.Context, bucketName string, opts PublicOptions) error {
+	policy, err := s.policyWithoutPublicRead(ctx, "make public", bucketName)
+	if err != nil {
+		return err
+	}
+
+	policy.Statement = append(policy.Statement, Statement{
+		Sid:       publicReadSid,
+		Effect:    "Allow",
+		Principal: map[string][]string{"AWS": {"*"}},
+		Action:    []string{"s3:GetObject"},
+		Resource:  []string{fmt.Sprintf("arn:aws:s3:::%s/%s*", bucketName, opts.Prefix)},
+	})
+
+	return s.SetPolicy(ctx, bucketName, policy)
+}
+
+// MakePrivate removes the public read statement added by MakePublic, keeping the other
+// statements of the bucket policy. The policy is deleted when no statement is left.
+// Returns a BucketError if the bucket does not exist.
+func (s *bucketService) MakePrivate(ctx context.Context, bucketName string) error {
+	policy, err := s.policyWithoutPublicRead(ctx, "make private", bucketName)
+	if err != nil {
+		return err
+	}
+
+	if len(policy.Statement) == 0 {
+		return s.DeletePolicy(ctx, bucketName)
+	}
+
+	return s.SetPolicy(ctx, bucketName, policy)
+}
+
+// IsPublic reports whether the bucket policy grants anonymous users read or write access,
+// with a human-readable reason naming the statement that does. It is meant to catch
+// accidentally public buckets before exposing data. Allow statements with a NotPrincipal
+// count as anonymous grants unless they exclude everyone. Deny statements are not evaluated,
+// so a bucket may be reported as public even though a deny statement restricts the grant.
+func (s *bucketService) IsPublic(ctx context.Context, bucketName string) (bool, string, error) {
+	if bucketName == "" {
+		return false, "", &InvalidBucketNameError{Name: bucketName}
+	}
+
+	policy, err := s.GetPolicy(ctx, bucketName)
+	if err != nil && minio.ToErrorResponse(err).Code != "NoSuchBucketPolicy" {
+		return false, "", err
+	}
+	if policy == nil {
+		return false, "bucket has no policy", nil
+	}
+
+	for i, statement := range policy.Statement {
+		if statement.Effect != "Allow" || !grantsAnonymous(statement) {
+			continue
+		}
+
+		var granted []string
+		for _, action := range policyValues(statement.Action) {
+			if isPublicAccessAction(action) {
+				granted = append(granted, action)
+			}
+		}
+		if len(granted) == 0 {
+			continue
+		}
+
+		name := statement.Sid
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+		return true, fmt.Sprintf("statement %s allows anyone to %s on %s", name,
+			strings.Join(granted, ", "), strings.Join(policyValues(statement.Resource), ", ")), nil
+	}
+
+	return false, "no statement grants access to anonymous users", nil
+}
+
+// grantsAnonymous reports whether a statement applies to anonymous users, either through
+// an anonymous Principal or through a NotPrincipal that does not exclude everyone.
+func grantsAnonymous(statement Statement) bool {
+	if statement.NotPrincipal != nil {
+		return !isAnonymousPrincipal(statement.NotPrincipal)
+	}
+	return isAnonymousPrincipal(statement.Principal)
+}
+
+// isAnonymousPrincipal reports whether a policy principal matches every user, either
+// as "*" or as {"AWS": "*"}.
+func isAnonymousPrincipal(principal any) bool {
+	if m, ok := principal.(map[string]any); ok {
+		principal = m["AWS"]
+	}
+	if m, ok := principal.(map[string][]string); ok {
+		principal = m["AWS"]
+	}
+	return slices.Contains(policyValues(principal), "*")
+}
+
+// publicAccessActions are the actions that read, list or change objects when granted
+// to anonymous users.
+var publicAccessActions = []string{
+	"s3:GetObject", "s3:GetObjectVersion",
+	"s3:ListBucket", "s3:ListBucketVersions",
+	"s3:PutObject", "s3:PutObjectAcl",
+	"s3:DeleteObject", "s3:DeleteObjectVersion",
+}
+
+// isPublicAccessAction reports whether a policy action grants reading, listing or
+// changing objects. Actions are matched as S3 does: case-insensitively, with "*"
+// matching any run of characters and "?" any single one, so "s3:Get*" is reported.
+func isPublicAccessAction(action string) bool {
+	pattern := strings.ToLower(action)
+	return slices.ContainsFunc(publicAccessActions, func(name string) bool {
+		return matchAction(pattern, strings.ToLower(name))
+	})
+}
+
+// matchAction reports whether name matches a policy action pattern using the "*" and
+// "?" wildcards.
+func matchAction(pattern, name string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := len(name); i >= 0; i-- {
+				if matchAction(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if name == "" {
+				return false
+			}
+		default:
+			if name == "" || pattern[0] != name[0] {
+				return false
+			}
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return name == ""
+}
+
+// policyValues returns the values of a policy element, which may be a single string or a list.
+func policyValues(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// policyWithoutPublicRead checks the bucket exists and returns its policy without the
+// public read statement, or an empty policy when the bucket has none.
+func (s *bucketService) policyWithoutPublicRead(ctx context.Context, operation string, bucketName string) (*Policy, error) {
+	exists, err := s.Exists(ctx, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &BucketError{Operation: operation, Bucket: bucketName, Message: "bucket does not exist"}
+	}
+
+	policy, err := s.GetPolicy(ctx, bucketName)
+	if err != nil && minio.ToErrorResponse(err).Code != "NoSuchBucketPolicy" {
+		return nil, err
+	}
+	if policy == nil {
+		policy = &Policy{Version: policyVersion}
+	}
+
+	policy.Statement = slices.DeleteFunc(policy.Statement, func(statement Statement) bool {
+		return statement.Sid == publicReadSid
+	})
+
+	return policy, nil
+}
+
 // marshalPolicy converts a Policy struct to a JSON string.
 func marshalPolicy(policy *Policy) (string, error) {
 	data, err := json.Marshal(policy)
@@ -184,6 +415,9 @@ func (s *bucketService) LockBucket(ctx context.Context, bucketName string, valid
 		minioUnit = minio.Years
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	return s.client.minioClient.SetObjectLockConfig(ctx, bucketName, &complianceMode, &validity, &minioUnit)
 }
 
@@ -193,6 +427,9 @@ func (s *bucketService) UnlockBucket(ctx context.Context, bucketName string) err
 		return &InvalidBucketNameError{Name: bucketName}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	// Remove lock configuration by setting all parameters to nil
 	return s.client.minioClient.SetObjectLockConfig(ctx, bucketName, nil, nil, nil)
 }
@@ -203,6 +440,9 @@ func (s *bucketService) GetBucketLockStatus(ctx context.Context, bucketName stri
 		return false, &InvalidBucketNameError{Name: bucketName}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	objectLock, mode, validity, unit, err := s.client.minioClient.GetObjectLockConfig(ctx, bucketName)
 	if err != nil {
 		return false, err
@@ -220,6 +460,9 @@ func (s *bucketService) GetBucketLockConfig(ctx context.Context, bucketName stri
 		return nil, &InvalidBucketNameError{Name: bucketName}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	objectLock, mode, validity, unit, err := s.client.minioClient.GetObjectLockConfig(ctx, bucketName)
 	if err != nil {
 		return nil, err
@@ -251,17 +494,14 @@ func (s *bucketService) GetBucketLockConfig(ctx context.Context, bucketName stri
 }
 
 // SetCORS sets the CORS configuration for a bucket.
+// The configuration is checked with ValidateCORS before it is sent.
 func (s *bucketService) SetCORS(ctx context.Context, bucketName string, corsConfig *CORSConfiguration) error {
 	if bucketName == "" {
 		return &InvalidBucketNameError{Name: bucketName}
 	}
 
-	if corsConfig == nil {
-		return &InvalidPolicyError{Message: "CORS configuration cannot be nil"}
-	}
-
-	if len(corsConfig.CORSRules) == 0 {
-		return &InvalidPolicyError{Message: "CORS configuration must have at least one rule"}
+	if err := ValidateCORS(corsConfig); err != nil {
+		return err
 	}
 
 	// Convert to MinIO CORS config
@@ -276,6 +516,9 @@ func (s *bucketService) SetCORS(ctx context.Context, bucketName string, corsConf
 		})
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	return s.client.minioClient.SetBucketCors(ctx, bucketName, minioCORSConfig)
 }
 
@@ -285,6 +528,9 @@ func (s *bucketService) GetCORS(ctx context.Context, bucketName string) (*CORSCo
 		return nil, &InvalidBucketNameError{Name: bucketName}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	minioCORSConfig, err := s.client.minioClient.GetBucketCors(ctx, bucketName)
 	if err != nil {
 		return nil, err
@@ -317,6 +563,9 @@ func (s *bucketService) DeleteCORS(ctx context.Context, bucketName string) error
 		return &InvalidBucketNameError{Name: bucketName}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	// Set empty CORS config to delete
 	return s.client.minioClient.SetBucketCors(ctx, bucketName, nil)
 }
@@ -327,6 +576,9 @@ func (s *bucketService) EnableVersioning(ctx context.Context, bucketName string)
 		return &InvalidBucketNameError{Name: bucketName}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	return s.client.minioClient.EnableVersioning(ctx, bucketName)
 }
 
@@ -336,6 +588,9 @@ func (s *bucketService) SuspendVersioning(ctx context.Context, bucketName string
 		return &InvalidBucketNameError{Name: bucketName}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	return s.client.minioClient.SuspendVersioning(ctx, bucketName)
 }
 
@@ -345,6 +600,9 @@ func (s *bucketService) GetVersioningStatus(ctx context.Context, bucketName stri
 		return nil, &InvalidBucketNameError{Name: bucketName}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	minioConfig, err := s.client.minioClient.GetBucketVersioning(ctx, bucketName)
 	if err != nil {
 		return nil, err
@@ -356,3 +614,149 @@ func (s *bucketService) GetVersioningStatus(ctx context.Context, bucketName stri
 
 	return config, nil
 }
+
+// Usage computes the total size and object count of a bucket, optionally restricted to a prefix.
+// Objects are consumed as they are listed, so memory usage does not grow with the bucket size.
+func (s *bucketService) Usage(ctx context.Context, bucketName string, prefix string) (BucketUsage, error) {
+	usage := BucketUsage{Prefixes: make(map[string]PrefixUsage)}
+
+	if bucketName == "" {
+		return usage, &InvalidBucketNameError{Name: bucketName}
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	listOpts := minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	}
+
+	err := s.client.listObjects(ctx, bucketName, listOpts, func(object minio.ObjectInfo) bool {
+		folder, _, found := strings.Cut(strings.TrimPrefix(object.Key, prefix), "/")
+		if !found {
+			folder = ""
+		}
+
+		prefixUsage := usage.Prefixes[folder]
+		prefixUsage.TotalSize += object.Size
+		prefixUsage.ObjectCount++
+		usage.Prefixes[folder] = prefixUsage
+
+		usage.TotalSize += object.Size
+		usage.ObjectCount++
+		return true
+	})
+
+	return usage, err
+}
+
+// Region returns the region where a bucket lives, such as client.RegionBrNe1.
+func (s *bucketService) Region(ctx context.Context, bucketName string) (string, error) {
+	if err := validateBucket(bucketName); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	return s.client.minioClient.GetBucketLocation(ctx, bucketName)
+}
+
+// AssertRegion checks that a bucket lives in the region of the client endpoint, returning
+// a BucketRegionMismatchError when it does not. Requests for a bucket of another region
+// fail in ways that do not point at the region, so this is meant to be called early, such
+// as when an application starts. It returns an error when the endpoint, such as one set
+// with WithBaseURL, is not a known MagaluObjects endpoint.
+func (s *bucketService) AssertRegion(ctx context.Context, bucketName string) error {
+	expected, ok := RegionForEndpoint(s.client.endpoint)
+	if !ok {
+		return fmt.Errorf("endpoint %s has no known region", s.client.endpoint)
+	}
+
+	region, err := s.Region(ctx, bucketName)
+	if err != nil {
+		return err
+	}
+
+	if region != expected {
+		return &BucketRegionMismatchError{Bucket: bucketName, Region: region, ExpectedRegion: expected}
+	}
+	return nil
+}
+
+// DefaultNotificationEvents are the events listened to when none are given.
+var DefaultNotificationEvents = []string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"}
+
+// ListenBucketNotification streams the events that occur on the objects of a bucket whose keys
+// match prefix and suffix (either may be empty), as they occur. When events is empty,
+// DefaultNotificationEvents are listened to. The returned channel is closed when ctx is done,
+// which also tears down the underlying connection, or after an event carrying Err is sent.
+func (s *bucketService) ListenBucketNotification(ctx context.Context, bucketName, prefix, suffix string, events []string) (<-chan NotificationEvent, error) {
+	if bucketName == "" {
+		return nil, &InvalidBucketNameError{Name: bucketName}
+	}
+
+	if len(events) == 0 {
+		events = DefaultNotificationEvents
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	infoCh := s.client.minioClient.ListenBucketNotification(ctx, bucketName, prefix, suffix, events)
+
+	eventCh := make(chan NotificationEvent)
+	go func() {
+		defer close(eventCh)
+		defer cancel()
+
+		send := func(event NotificationEvent) bool {
+			select {
+			case eventCh <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case info, ok := <-infoCh:
+				if !ok {
+					return
+				}
+				if info.Err != nil {
+					send(NotificationEvent{Bucket: bucketName, Err: info.Err})
+					return
+				}
+				for _, record := range info.Records {
+					if !send(toNotificationEvent(record)) {
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return eventCh, nil
+}
+
+// toNotificationEvent converts a MinIO notification record, whose object key is URL-encoded.
+func toNotificationEvent(record notification.Event) NotificationEvent {
+	eventTime, _ := time.Parse(time.RFC3339Nano, record.EventTime)
+	key, err := url.QueryUnescape(record.S3.Object.Key)
+	if err != nil {
+		key = record.S3.Object.Key
+	}
+	return NotificationEvent{
+		EventName:   record.EventName,
+		EventTime:   eventTime,
+		Bucket:      record.S3.Bucket.Name,
+		Key:         key,
+		Size:        record.S3.Object.Size,
+		ETag:        record.S3.Object.ETag,
+		ContentType: record.S3.Object.ContentType,
+		VersionID:   record.S3.Object.VersionID,
+	}
+}
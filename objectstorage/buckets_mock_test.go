@@ -2,12 +2,15 @@ package objectstorage
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/cors"
+	"github.com/minio/minio-go/v7/pkg/notification"
 )
 
 // TestBucketServiceList_WithMockSuccess tests List with mock MinIO returning buckets
@@ -616,3 +619,464 @@ func TestBucketServiceDelete_WithObjects(t *testing.T) {
 		t.Fatalf("expected bucket to be deleted, but it still exists")
 	}
 }
+
+func TestBucketServiceUsage_WithMockSuccess(t *testing.T) {
+	t.Parallel()
+	mock := newMockMinioClient()
+	mock.buckets["test-bucket"] = &mockBucket{
+		name:         "test-bucket",
+		creationDate: time.Now(),
+		objects: map[string]*mockObject{
+			"root.txt":             {key: "root.txt", size: 10},
+			"logs/a.log":           {key: "logs/a.log", size: 100},
+			"logs/2024/b.log":      {key: "logs/2024/b.log", size: 200},
+			"images/photo.png":     {key: "images/photo.png", size: 1000},
+			"images/thumbs/ph.png": {key: "images/thumbs/ph.png", size: 50},
+		},
+	}
+
+	core := client.NewMgcClient()
+	osClient, _ := New(core, "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+	svc := osClient.Buckets()
+
+	usage, err := svc.Usage(context.Background(), "test-bucket", "")
+	if err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+
+	if usage.TotalSize != 1360 || usage.ObjectCount != 5 {
+		t.Errorf("Usage() totals = %d bytes / %d objects, want 1360 / 5", usage.TotalSize, usage.ObjectCount)
+	}
+
+	want := map[string]PrefixUsage{
+		"":       {TotalSize: 10, ObjectCount: 1},
+		"logs":   {TotalSize: 300, ObjectCount: 2},
+		"images": {TotalSize: 1050, ObjectCount: 2},
+	}
+	for folder, prefixUsage := range want {
+		if usage.Prefixes[folder] != prefixUsage {
+			t.Errorf("Usage() prefix %q = %+v, want %+v", folder, usage.Prefixes[folder], prefixUsage)
+		}
+	}
+
+	usage, err = svc.Usage(context.Background(), "test-bucket", "images/")
+	if err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+
+	if usage.ObjectCount != 2 || usage.Prefixes["thumbs"].ObjectCount != 1 {
+		t.Errorf("Usage() with prefix = %+v, want 2 objects and a thumbs folder", usage)
+	}
+}
+
+func TestBucketServiceUsage_InvalidBucketName(t *testing.T) {
+	t.Parallel()
+
+	core := client.NewMgcClient()
+	osClient, _ := New(core, "minioadmin", "minioadmin", WithMinioClientInterface(newMockMinioClient()))
+
+	_, err := osClient.Buckets().Usage(context.Background(), "", "")
+	if _, ok := err.(*InvalidBucketNameError); !ok {
+		t.Errorf("Usage() expected InvalidBucketNameError, got %T", err)
+	}
+}
+
+func TestBucketServiceUsage_CancelledContext(t *testing.T) {
+	t.Parallel()
+	mock := newMockMinioClient()
+	mock.listObjectsFunc = func(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+		ch := make(chan minio.ObjectInfo, 1)
+		ch <- minio.ObjectInfo{Key: "file.txt", Size: 1}
+		close(ch)
+		return ch
+	}
+
+	core := client.NewMgcClient()
+	osClient, _ := New(core, "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := osClient.Buckets().Usage(ctx, "test-bucket", ""); err != context.Canceled {
+		t.Errorf("Usage() error = %v, want context.Canceled", err)
+	}
+}
+
+// TestBucketServiceListenBucketNotification tests events are streamed and converted
+func TestBucketServiceListenBucketNotification(t *testing.T) {
+	t.Parallel()
+
+	var record notification.Event
+	record.EventName = "s3:ObjectCreated:Put"
+	record.EventTime = "2024-05-01T10:00:00.000Z"
+	record.S3.Bucket.Name = "my-bucket"
+	record.S3.Object.Key = "images%2Fcat+photo.png"
+	record.S3.Object.Size = 1024
+
+	var gotEvents []string
+	mock := newMockMinioClient()
+	mock.listenNotificationFunc = func(ctx context.Context, bucketName, prefix, suffix string, events []string) <-chan notification.Info {
+		gotEvents = events
+		infoCh := make(chan notification.Info, 2)
+		infoCh <- notification.Info{Records: []notification.Event{record}}
+		infoCh <- notification.Info{Err: errors.New("connection reset")}
+		close(infoCh)
+		return infoCh
+	}
+
+	osClient, _ := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+	eventCh, err := osClient.Buckets().ListenBucketNotification(context.Background(), "my-bucket", "images/", ".png", nil)
+	if err != nil {
+		t.Fatalf("ListenBucketNotification() error = %v", err)
+	}
+
+	var got []NotificationEvent
+	for event := range eventCh {
+		got = append(got, event)
+	}
+
+	if !reflect.DeepEqual(gotEvents, DefaultNotificationEvents) {
+		t.Errorf("events = %v, want %v", gotEvents, DefaultNotificationEvents)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(got))
+	}
+	if got[0].Key != "images/cat photo.png" || got[0].Bucket != "my-bucket" || got[0].Size != 1024 || got[0].EventTime.IsZero() {
+		t.Errorf("unexpected event: %+v", got[0])
+	}
+	if got[1].Err == nil {
+		t.Error("expected the last event to carry the stream error")
+	}
+}
+
+// TestBucketServiceListenBucketNotification_ContextCanceled tests cancellation closes the stream and the connection
+func TestBucketServiceListenBucketNotification_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	connClosed := make(chan struct{})
+	mock := newMockMinioClient()
+	mock.listenNotificationFunc = func(ctx context.Context, bucketName, prefix, suffix string, events []string) <-chan notification.Info {
+		infoCh := make(chan notification.Info)
+		go func() {
+			defer close(infoCh)
+			<-ctx.Done()
+			close(connClosed)
+		}()
+		return infoCh
+	}
+
+	osClient, _ := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+	ctx, cancel := context.WithCancel(context.Background())
+	eventCh, err := osClient.Buckets().ListenBucketNotification(ctx, "my-bucket", "", "", []string{"s3:ObjectCreated:*"})
+	if err != nil {
+		t.Fatalf("ListenBucketNotification() error = %v", err)
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-eventCh:
+		if ok {
+			t.Error("expected the event channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("event channel was not closed after cancellation")
+	}
+
+	select {
+	case <-connClosed:
+	case <-time.After(time.Second):
+		t.Fatal("underlying listener was not torn down")
+	}
+}
+
+// TestBucketServiceListenBucketNotification_InvalidBucket tests an empty bucket name is rejected
+func TestBucketServiceListenBucketNotification_InvalidBucket(t *testing.T) {
+	t.Parallel()
+
+	osClient, _ := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(newMockMinioClient()))
+	if _, err := osClient.Buckets().ListenBucketNotification(context.Background(), "", "", "", nil); err == nil {
+		t.Error("expected error for empty bucket name")
+	}
+}
+
+// TestBucketServiceMakePublic tests a public read statement is added and removed alongside existing statements
+func TestBucketServiceMakePublic(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockMinioClient()
+	mock.buckets["assets"] = &mockBucket{
+		name:    "assets",
+		policy:  `{"Version":"2012-10-17","Statement":[{"Sid":"Uploader","Effect":"Allow","Principal":{"AWS":["arn:aws:iam:::user/ci"]},"Action":["s3:PutObject"],"Resource":["arn:aws:s3:::assets/*"]}]}`,
+		objects: make(map[string]*mockObject),
+	}
+	osClient, _ := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+	svc := osClient.Buckets()
+	ctx := context.Background()
+
+	// Called twice to check the public statement is replaced rather than duplicated
+	if err := svc.MakePublic(ctx, "assets", PublicOptions{}); err != nil {
+		t.Fatalf("MakePublic() error = %v", err)
+	}
+	if err := svc.MakePublic(ctx, "assets", PublicOptions{Prefix: "static/"}); err != nil {
+		t.Fatalf("MakePublic() error = %v", err)
+	}
+
+	policy, err := svc.GetPolicy(ctx, "assets")
+	if err != nil {
+		t.Fatalf("GetPolicy() error = %v", err)
+	}
+	if len(policy.Statement) != 2 || policy.Statement[0].Sid != "Uploader" {
+		t.Fatalf("MakePublic() policy = %+v, want the uploader and one public statement", policy)
+	}
+	public := policy.Statement[1]
+	if public.Sid != "PublicRead" || !reflect.DeepEqual(public.Action, []any{"s3:GetObject"}) ||
+		!reflect.DeepEqual(public.Resource, []any{"arn:aws:s3:::assets/static/*"}) {
+		t.Errorf("MakePublic() statement = %+v", public)
+	}
+
+	if err := svc.MakePrivate(ctx, "assets"); err != nil {
+		t.Fatalf("MakePrivate() error = %v", err)
+	}
+	policy, _ = svc.GetPolicy(ctx, "assets")
+	if len(policy.Statement) != 1 || policy.Statement[0].Sid != "Uploader" {
+		t.Errorf("MakePrivate() policy = %+v, want only the uploader statement", policy)
+	}
+}
+
+// TestBucketServiceMakePrivate_DeletesEmptyPolicy tests the policy is deleted once the public statement was its only one
+func TestBucketServiceMakePrivate_DeletesEmptyPolicy(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockMinioClient()
+	mock.buckets["assets"] = &mockBucket{name: "assets", objects: make(map[string]*mockObject)}
+	osClient, _ := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+	svc := osClient.Buckets()
+	ctx := context.Background()
+
+	if err := svc.MakePublic(ctx, "assets", PublicOptions{}); err != nil {
+		t.Fatalf("MakePublic() error = %v", err)
+	}
+	if err := svc.MakePrivate(ctx, "assets"); err != nil {
+		t.Fatalf("MakePrivate() error = %v", err)
+	}
+
+	if mock.buckets["assets"].policy != "" {
+		t.Errorf("MakePrivate() left policy %q, want none", mock.buckets["assets"].policy)
+	}
+}
+
+// TestBucketServiceMakePublic_MissingBucket tests a BucketError is returned for buckets that do not exist
+func TestBucketServiceMakePublic_MissingBucket(t *testing.T) {
+	t.Parallel()
+
+	osClient, _ := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(newMockMinioClient()))
+	svc := osClient.Buckets()
+
+	var bucketErr *BucketError
+	if err := svc.MakePublic(context.Background(), "missing", PublicOptions{}); !errors.As(err, &bucketErr) || bucketErr.Operation != "make public" {
+		t.Errorf("MakePublic() error = %v, want make public BucketError", err)
+	}
+	if err := svc.MakePrivate(context.Background(), "missing"); !errors.As(err, &bucketErr) || bucketErr.Operation != "make private" {
+		t.Errorf("MakePrivate() error = %v, want make private BucketError", err)
+	}
+}
+
+// TestBucketServiceIsPublic tests anonymous grants are detected in bucket policies
+func TestBucketServiceIsPublic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		policy     string
+		wantPublic bool
+		wantReason string
+	}{
+		{name: "no policy", wantReason: "bucket has no policy"},
+		{
+			name:       "named principal",
+			policy:     `{"Version":"2012-10-17","Statement":[{"Sid":"Uploader","Effect":"Allow","Principal":{"AWS":["arn:aws:iam:::user/ci"]},"Action":["s3:PutObject"],"Resource":["arn:aws:s3:::assets/*"]}]}`,
+			wantReason: "no statement grants access to anonymous users",
+		},
+		{
+			name:       "anonymous deny",
+			policy:     `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:*","Resource":"arn:aws:s3:::assets/*"}]}`,
+			wantReason: "no statement grants access to anonymous users",
+		},
+		{
+			name:       "anonymous write",
+			policy:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":["s3:PutObject","s3:GetBucketLocation"],"Resource":"arn:aws:s3:::assets/*"}]}`,
+			wantPublic: true,
+			wantReason: "statement #1 allows anyone to s3:PutObject on arn:aws:s3:::assets/*",
+		},
+		{
+			name:       "anonymous read",
+			policy:     `{"Version":"2012-10-17","Statement":[{"Sid":"Read","Effect":"Allow","Principal":{"AWS":"*"},"Action":"s3:GetObject","Resource":["arn:aws:s3:::assets/*"]}]}`,
+			wantPublic: true,
+			wantReason: "statement Read allows anyone to s3:GetObject on arn:aws:s3:::assets/*",
+		},
+		{
+			name:       "anonymous wildcard read",
+			policy:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:Get*","Resource":"arn:aws:s3:::assets/*"}]}`,
+			wantPublic: true,
+			wantReason: "statement #1 allows anyone to s3:Get* on arn:aws:s3:::assets/*",
+		},
+		{
+			name:       "not principal",
+			policy:     `{"Version":"2012-10-17","Statement":[{"Sid":"AllButCI","Effect":"Allow","NotPrincipal":{"AWS":["arn:aws:iam:::user/ci"]},"Action":"s3:ListBucketVersions","Resource":"arn:aws:s3:::assets"}]}`,
+			wantPublic: true,
+			wantReason: "statement AllButCI allows anyone to s3:ListBucketVersions on arn:aws:s3:::assets",
+		},
+		{
+			name:       "not principal excludes everyone",
+			policy:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","NotPrincipal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::assets/*"}]}`,
+			wantReason: "no statement grants access to anonymous users",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockMinioClient()
+			mock.buckets["assets"] = &mockBucket{name: "assets", policy: tt.policy, objects: make(map[string]*mockObject)}
+			osClient, _ := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+
+			public, reason, err := osClient.Buckets().IsPublic(context.Background(), "assets")
+			if err != nil {
+				t.Fatalf("IsPublic() error = %v", err)
+			}
+			if public != tt.wantPublic || reason != tt.wantReason {
+				t.Errorf("IsPublic() = %v, %q, want %v, %q", public, reason, tt.wantPublic, tt.wantReason)
+			}
+		})
+	}
+}
+
+// TestIsPublicAccessAction tests policy actions are matched with S3 wildcards
+func TestIsPublicAccessAction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		action string
+		want   bool
+	}{
+		{"*", true},
+		{"s3:*", true},
+		{"s3:Get*", true},
+		{"s3:get*", true},
+		{"s3:GetObject*", true},
+		{"s3:GetObjectVersion", true},
+		{"s3:List*", true},
+		{"s3:ListBucketVersions", true},
+		{"s3:PutObjectAcl", true},
+		{"s3:?utObject", true},
+		{"s3:DeleteObject", true},
+		{"s3:GetBucketLocation", false},
+		{"s3:GetBucket*", false},
+		{"s3:PutBucketPolicy", false},
+		{"s3:GetObjectX", false},
+		{"iam:*", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPublicAccessAction(tt.action); got != tt.want {
+			t.Errorf("isPublicAccessAction(%q) = %v, want %v", tt.action, got, tt.want)
+		}
+	}
+}
+
+// TestBucketServiceIsPublic_AfterMakePublic tests the statement added by MakePublic is reported
+func TestBucketServiceIsPublic_AfterMakePublic(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockMinioClient()
+	mock.buckets["assets"] = &mockBucket{name: "assets", objects: make(map[string]*mockObject)}
+	osClient, _ := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+	svc := osClient.Buckets()
+	ctx := context.Background()
+
+	if err := svc.MakePublic(ctx, "assets", PublicOptions{}); err != nil {
+		t.Fatalf("MakePublic() error = %v", err)
+	}
+	public, reason, err := svc.IsPublic(ctx, "assets")
+	if err != nil || !public || reason != "statement PublicRead allows anyone to s3:GetObject on arn:aws:s3:::assets/*" {
+		t.Errorf("IsPublic() = %v, %q, %v after MakePublic", public, reason, err)
+	}
+
+	if err := svc.MakePrivate(ctx, "assets"); err != nil {
+		t.Fatalf("MakePrivate() error = %v", err)
+	}
+	if public, _, err := svc.IsPublic(ctx, "assets"); err != nil || public {
+		t.Errorf("IsPublic() = %v, %v after MakePrivate", public, err)
+	}
+}
+
+// TestBucketServiceRegion tests the region of a bucket is checked against the client endpoint
+func TestBucketServiceRegion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		opts       []ClientOption
+		location   string
+		bucket     string
+		wantRegion string
+		wantErr    func(error) bool
+	}{
+		{name: "same region", location: "br-se1", bucket: "test-bucket", wantRegion: "br-se1"},
+		{name: "other region", location: "br-ne1", bucket: "test-bucket", wantRegion: "br-ne1", wantErr: func(err error) bool {
+			var mismatch *BucketRegionMismatchError
+			return errors.As(err, &mismatch) && mismatch.Region == "br-ne1" && mismatch.ExpectedRegion == "br-se1"
+		}},
+		{name: "client in other region", opts: []ClientOption{WithEndpoint(BrNe1)}, location: "br-ne1", bucket: "test-bucket", wantRegion: "br-ne1"},
+		{name: "missing bucket", bucket: "missing", wantErr: func(err error) bool {
+			return minio.ToErrorResponse(err).Code == "NoSuchBucket"
+		}},
+		{name: "invalid bucket", bucket: "", wantErr: func(err error) bool {
+			var nameErr *InvalidBucketNameError
+			return errors.As(err, &nameErr)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockMinioClient()
+			mock.buckets["test-bucket"] = &mockBucket{name: "test-bucket", location: tt.location, objects: make(map[string]*mockObject)}
+			osClient, err := New(createMockCoreClient(), "minioadmin", "minioadmin", append(tt.opts, WithMinioClientInterface(mock))...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			region, err := osClient.Buckets().Region(context.Background(), tt.bucket)
+			if tt.wantRegion != "" && (err != nil || region != tt.wantRegion) {
+				t.Errorf("Region() = %q, %v; want %q", region, err, tt.wantRegion)
+			}
+
+			err = osClient.Buckets().AssertRegion(context.Background(), tt.bucket)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("AssertRegion() error = %v", err)
+				}
+				return
+			}
+			if !tt.wantErr(err) {
+				t.Errorf("AssertRegion() error = %v", err)
+			}
+		})
+	}
+}
+
+// TestBucketServiceAssertRegion_UnknownEndpoint tests a custom endpoint has no region to compare
+func TestBucketServiceAssertRegion_UnknownEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockMinioClient()
+	mock.buckets["test-bucket"] = &mockBucket{name: "test-bucket", objects: make(map[string]*mockObject)}
+	osClient, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithBaseURL("http://localhost:9000"), WithMinioClientInterface(mock))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := osClient.Buckets().AssertRegion(context.Background(), "test-bucket"); err == nil {
+		t.Error("AssertRegion() expected error for an endpoint without a known region")
+	}
+}
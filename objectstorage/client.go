@@ -1,10 +1,19 @@
 package objectstorage
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/clock"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
@@ -13,8 +22,25 @@ import (
 // It encapsulates functionality to access buckets and objects using MinIO as the backend.
 type ObjectStorageClient struct {
 	*client.CoreClient
-	minioClient minioClientInterface
-	endpoint    Endpoint
+	minioClient         minioClientInterface
+	endpoint            Endpoint
+	region              string
+	autoEndpoint        bool
+	baseURL             string
+	credentialsChain    bool
+	sessionToken        string
+	credentialsProvider CredentialsProviderFunc
+	operationTimeout    time.Duration
+	transferTimeout     time.Duration
+	bucketLookup        minio.BucketLookupType
+	clock               clock.Clock
+	defaultBucket       string
+	uploadOptions       UploadOptions
+	transportOptions    TransportOptions
+	skipAppInfo         bool
+	maxRetries          *int
+	logger              *slog.Logger
+	presignParams       url.Values
 }
 
 // ClientOption allows customizing the object storage client configuration.
@@ -28,10 +54,46 @@ func WithEndpoint(endpoint Endpoint) ClientOption {
 	}
 }
 
+// WithRegion sets the endpoint from a region identifier shared with the other regional
+// services, such as client.RegionBrNe1, so a compute and an object storage client can be
+// pointed at the same region. It takes precedence over WithEndpoint, and New returns a
+// client.ValidationError when the region has no object storage endpoint.
+func WithRegion(region string) ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.region = region
+	}
+}
+
+// WithAutoEndpoint selects the MagaluObjects endpoint with the lowest latency when the
+// client is created, by probing every known endpoint at once and keeping the first to
+// answer. Probing is bounded by DefaultAutoEndpointTimeout; when no endpoint answers in
+// time, the endpoint set by WithEndpoint, BR-SE1 by default, is used. It has no effect
+// with WithRegion, WithBaseURL or a custom MinIO client.
+func WithAutoEndpoint() ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.autoEndpoint = true
+	}
+}
+
+// WithBaseURL points the client at rawURL, such as "http://localhost:9000",
+// without validating it against the MagaluObjects endpoints. The URL scheme
+// decides whether TLS is used. It takes precedence over WithEndpoint.
+//
+// This option exists for integration tests and local development against
+// MinIO or other S3-compatible servers. Production code should use WithEndpoint.
+func WithBaseURL(rawURL string) ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.baseURL = rawURL
+	}
+}
+
 // WithMinioClient sets a custom MinIO client.
+// The app info configured on the client is kept; New does not call SetAppInfo on it.
+// The client retries requests according to its own minio.Options.MaxRetries.
 func WithMinioClient(minioClient *minio.Client) ClientOption {
 	return func(c *ObjectStorageClient) {
-		c.minioClient = minioClient
+		c.minioClient = minioAdapter{minioClient}
+		c.skipAppInfo = true
 	}
 }
 
@@ -42,6 +104,141 @@ func WithMinioClientInterface(minioClient minioClientInterface) ClientOption {
 	}
 }
 
+// WithCredentialsChain resolves credentials from a chain of providers instead of
+// requiring explicit keys. Explicit keys passed to New are tried first, followed by the
+// MGC_ACCESS_KEY/MGC_SECRET_KEY environment variables, the shared credentials files
+// and the IAM metadata service. When this option is used, the keys given to New may be empty.
+func WithCredentialsChain() ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.credentialsChain = true
+	}
+}
+
+// WithSessionToken sets the session token of temporary (STS-style) credentials.
+// The access and secret keys issued along with the token must be passed to New.
+func WithSessionToken(token string) ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.sessionToken = token
+	}
+}
+
+// WithCredentialsProvider sets a function used to fetch and refresh credentials.
+// The function is called again every DefaultCredentialsRefreshInterval, so long-running
+// services pick up rotated credentials transparently. When this option is used, the keys
+// given to New may be empty.
+func WithCredentialsProvider(provider CredentialsProviderFunc) ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.credentialsProvider = provider
+	}
+}
+
+// WithOperationTimeout bounds every object storage call by the given timeout,
+// unless the caller's context already carries a deadline. Uploads and downloads
+// of object data are exempt; use WithTransferTimeout to bound them.
+func WithOperationTimeout(timeout time.Duration) ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.operationTimeout = timeout
+	}
+}
+
+// WithTransferTimeout bounds uploads and downloads of object data by the given timeout,
+// unless the caller's context already carries a deadline. It is usually larger than the
+// operation timeout to accommodate large files.
+func WithTransferTimeout(timeout time.Duration) ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.transferTimeout = timeout
+	}
+}
+
+// WithDefaultBucket sets the bucket returned by DefaultBucket, so single-bucket
+// applications can operate on objects without repeating the bucket name.
+// The name is validated when the client is created.
+func WithDefaultBucket(name string) ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.defaultBucket = name
+	}
+}
+
+// WithUploadOptions tunes the multipart threshold and part size of uploads,
+// trading memory and request count for throughput. Larger parts suit high-latency links.
+// It also enables the Content-MD5 verification of uploads by the server.
+// The options are validated when the client is created.
+func WithUploadOptions(opts UploadOptions) ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.uploadOptions = opts
+	}
+}
+
+// WithTransportOptions tunes the connection pool of the HTTP transport, raising the
+// limits for heavily concurrent workloads such as parallel uploads.
+// The options are validated when the client is created. The option has no effect
+// when a client is given with WithMinioClient.
+func WithTransportOptions(opts TransportOptions) ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.transportOptions = opts
+	}
+}
+
+// WithMaxRetries sets how many times the MinIO client retries a failed request, using its
+// own backoff. When unset, it retries as many times as the core client's retry configuration
+// allows, so compute and object storage calls behave alike. With zero, requests are not
+// retried. The option has no effect when a client is given with WithMinioClient.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.maxRetries = &n
+	}
+}
+
+// WithoutAppInfo keeps New from setting the MinIO app info, which otherwise adds the
+// user agent suffix (or "wrapper") and the core client's user agent to the MinIO User-Agent.
+func WithoutAppInfo() ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.skipAppInfo = true
+	}
+}
+
+// WithDefaultPresignParams sets request params, such as response-content-disposition,
+// signed into every presigned GET URL. Params given in GetPresignedURLOptions.ReqParams
+// replace the defaults with the same name. They are ignored for PUT, which does not
+// support request params.
+func WithDefaultPresignParams(params url.Values) ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.presignParams = maps.Clone(params)
+	}
+}
+
+// WithLogger logs every MinIO operation made by the client to logger, with its
+// operation, bucket, key, duration and error. Successful operations are logged at
+// debug level and failed ones at warn level. Pass the core client's logger,
+// core.GetConfig().Logger, to log storage operations next to the other services.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.logger = logger
+	}
+}
+
+// withClock replaces the clock used to wait between the Stat polls of GetAfterPut and
+// to read the current time for retention and upload age checks (for testing).
+func withClock(c clock.Clock) ClientOption {
+	return func(osClient *ObjectStorageClient) {
+		osClient.clock = c
+	}
+}
+
+// WithVirtualHostStyle addresses buckets as a subdomain of the endpoint
+// (https://bucket.br-se1.magaluobjects.com/key) instead of the default path style
+// (https://br-se1.magaluobjects.com/bucket/key). It applies to every request,
+// including presigned URLs, which is what CDNs in front of object storage usually expect.
+// Bucket names containing dots cannot be used with this option over HTTPS.
+//
+// The option has no effect when a client is given with WithMinioClient; set
+// minio.Options.BucketLookup to minio.BucketLookupDNS on that client instead.
+func WithVirtualHostStyle() ClientOption {
+	return func(c *ObjectStorageClient) {
+		c.bucketLookup = minio.BucketLookupDNS
+	}
+}
+
 // New creates a new instance of ObjectStorageClient.
 // The default endpoint is BR-SE1. Use WithEndpoint option to specify a different region.
 // If the core client is nil, returns an error.
@@ -53,30 +250,97 @@ func New(core *client.CoreClient, accessKey string, secretKey string, opts ...Cl
 		}
 	}
 
-	if accessKey == "" {
-		return nil, &client.ValidationError{
-			Field:   "accessKey",
-			Message: "access key cannot be empty",
+	osClient := &ObjectStorageClient{
+		CoreClient: core,
+		endpoint:   BrSe1,
+		clock:      clock.Real{},
+	}
+
+	for _, opt := range opts {
+		opt(osClient)
+	}
+
+	if osClient.region != "" {
+		endpoint, ok := EndpointForRegion(osClient.region)
+		if !ok {
+			return nil, &client.ValidationError{
+				Field:   "region",
+				Message: fmt.Sprintf("no object storage endpoint for region %q", osClient.region),
+			}
+		}
+		osClient.endpoint = endpoint
+	}
+
+	if osClient.autoEndpoint && osClient.region == "" && osClient.baseURL == "" && osClient.minioClient == nil {
+		httpClient := core.GetConfig().HTTPClient
+		if httpClient == nil {
+			httpClient = http.DefaultClient
+		}
+		if endpoint, ok := probeEndpoints(httpClient, knownEndpoints(), DefaultAutoEndpointTimeout); ok {
+			osClient.endpoint = endpoint
+		}
+	}
+
+	if !osClient.credentialsChain && osClient.credentialsProvider == nil {
+		if accessKey == "" {
+			return nil, &client.ValidationError{
+				Field:   "accessKey",
+				Message: "access key cannot be empty",
+			}
+		}
+
+		if secretKey == "" {
+			return nil, &client.ValidationError{
+				Field:   "secretKey",
+				Message: "secret key cannot be empty",
+			}
 		}
 	}
 
-	if secretKey == "" {
+	if osClient.sessionToken != "" && osClient.credentialsProvider == nil && (accessKey == "" || secretKey == "") {
 		return nil, &client.ValidationError{
-			Field:   "secretKey",
-			Message: "secret key cannot be empty",
+			Field:   "sessionToken",
+			Message: "session token requires access and secret keys",
 		}
 	}
 
-	osClient := &ObjectStorageClient{
-		CoreClient: core,
-		endpoint:   BrSe1,
+	if osClient.defaultBucket != "" {
+		if err := validateBucketName(osClient.defaultBucket); err != nil {
+			return nil, &client.ValidationError{
+				Field:   "defaultBucket",
+				Message: err.Error(),
+			}
+		}
 	}
 
-	for _, opt := range opts {
-		opt(osClient)
+	if err := validateUploadOptions(osClient.uploadOptions); err != nil {
+		return nil, err
+	}
+
+	if err := validateTransportOptions(osClient.transportOptions); err != nil {
+		return nil, err
+	}
+
+	if osClient.maxRetries != nil && *osClient.maxRetries < 0 {
+		return nil, &client.ValidationError{
+			Field:   "maxRetries",
+			Message: "cannot be negative",
+		}
 	}
 
-	if err := ValidateEndpoint(osClient.endpoint); err != nil {
+	// MinIO requires just the hostname, not the full URL
+	minioEndpoint, secure := parseEndpoint(osClient.endpoint), true
+	if osClient.baseURL != "" {
+		host, isSecure, err := parseBaseURL(osClient.baseURL)
+		if err != nil {
+			return nil, &client.ValidationError{
+				Field:   "baseURL",
+				Message: err.Error(),
+			}
+		}
+		minioEndpoint, secure = host, isSecure
+		osClient.endpoint = Endpoint(osClient.baseURL)
+	} else if err := ValidateEndpoint(osClient.endpoint); err != nil {
 		return nil, &client.ValidationError{
 			Field:   "endpoint",
 			Message: err.Error(),
@@ -85,27 +349,100 @@ func New(core *client.CoreClient, accessKey string, secretKey string, opts ...Cl
 
 	// Only create a new MinIO client if one wasn't provided via options
 	if osClient.minioClient == nil {
-		// MinIO requires just the hostname, not the full URL
-		minioEndpoint := parseEndpoint(osClient.endpoint)
+		creds := credentials.NewStaticV4(accessKey, secretKey, osClient.sessionToken)
+		switch {
+		case osClient.credentialsProvider != nil:
+			creds = credentials.New(&funcCredentials{
+				fn:              osClient.credentialsProvider,
+				refreshInterval: DefaultCredentialsRefreshInterval,
+			})
+		case osClient.credentialsChain:
+			creds = newCredentialsChain(accessKey, secretKey, osClient.sessionToken)
+		}
 
 		minioClient, err := minio.New(minioEndpoint, &minio.Options{
-			Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
-			Secure: true,
+			Creds:        creds,
+			Secure:       secure,
+			BucketLookup: osClient.bucketLookup,
+			MaxRetries:   minioMaxRetries(core, osClient.maxRetries),
 			Transport: &forceDeleteTransport{
-				base: http.DefaultTransport,
+				base:    newTransport(osClient.transportOptions),
+				headers: core.GetConfig().DefaultHeaders,
 			},
 		})
 		if err != nil {
 			return nil, err
 		}
-		osClient.minioClient = minioClient
+		osClient.minioClient = minioAdapter{minioClient}
 	}
 
-	osClient.minioClient.SetAppInfo("wrapper", core.GetConfig().UserAgent)
+	if !osClient.skipAppInfo {
+		appName := "wrapper"
+		if suffix := core.GetConfig().UserAgentSuffix; suffix != "" {
+			appName = suffix
+		}
+		osClient.minioClient.SetAppInfo(appName, core.GetConfig().UserAgent)
+	}
+
+	var observers []operationObserver
+	if osClient.logger != nil {
+		observers = append(observers, logOperation(osClient.logger))
+	}
+	if recorder, ok := core.GetConfig().Metrics.(client.OperationRecorder); ok {
+		observers = append(observers, recordOperation(recorder))
+	}
+	if len(observers) > 0 {
+		osClient.minioClient = &instrumentedMinioClient{
+			minioClientInterface: osClient.minioClient,
+			observers:            observers,
+		}
+	}
 
 	return osClient, nil
 }
 
+// validateUploadOptions checks the multipart settings against the storage limits.
+func validateUploadOptions(opts UploadOptions) error {
+	if opts.PartSize != 0 && opts.PartSize < MinPartSize {
+		return &client.ValidationError{
+			Field:   "partSize",
+			Message: fmt.Sprintf("must be at least %d bytes", MinPartSize),
+		}
+	}
+
+	if opts.MultipartThreshold < 0 || opts.MultipartThreshold > MaxSinglePutSize {
+		return &client.ValidationError{
+			Field:   "multipartThreshold",
+			Message: fmt.Sprintf("must be between 0 and %d bytes", MaxSinglePutSize),
+		}
+	}
+
+	return nil
+}
+
+// validateTransportOptions checks the connection pool limits are not negative.
+func validateTransportOptions(opts TransportOptions) error {
+	limits := []struct {
+		field string
+		value int
+	}{
+		{"maxIdleConns", opts.MaxIdleConns},
+		{"maxIdleConnsPerHost", opts.MaxIdleConnsPerHost},
+		{"maxConnsPerHost", opts.MaxConnsPerHost},
+	}
+
+	for _, limit := range limits {
+		if limit.value < 0 {
+			return &client.ValidationError{
+				Field:   limit.field,
+				Message: "cannot be negative",
+			}
+		}
+	}
+
+	return nil
+}
+
 // NewWithEndpoint creates a new instance of ObjectStorageClient with a specific endpoint.
 // Deprecated: Use New() with WithEndpoint() option instead.
 func NewWithEndpoint(core *client.CoreClient, endpoint Endpoint, accessKey string, secretKey string, opts ...ClientOption) (*ObjectStorageClient, error) {
@@ -128,6 +465,97 @@ func parseEndpoint(endpoint Endpoint) string {
 	return endpointStr
 }
 
+// parseBaseURL extracts the host from a URL given to WithBaseURL and reports
+// whether it uses TLS.
+// Example: "http://localhost:9000" -> "localhost:9000", false
+func parseBaseURL(rawURL string) (string, bool, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", false, fmt.Errorf("unsupported scheme %q, must be http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return "", false, errors.New("host cannot be empty")
+	}
+	if u.Path != "" && u.Path != "/" {
+		return "", false, errors.New("path is not supported")
+	}
+	return u.Host, u.Scheme == "https", nil
+}
+
+// operationContext derives a context bounded by timeout.
+// The context is returned unchanged when timeout is not positive or ctx already has a deadline.
+func (c *ObjectStorageClient) operationContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// listObjects consumes a MinIO object listing, calling fn for every object until fn
+// returns false, the listing ends, an object carries an error or ctx is done. The listing
+// context is cancelled on return, so MinIO's producer goroutine never stays blocked on a
+// channel that is no longer read.
+func (c *ObjectStorageClient) listObjects(ctx context.Context, bucketName string, opts minio.ListObjectsOptions, fn func(minio.ObjectInfo) bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objectCh := c.minioClient.ListObjects(ctx, bucketName, opts)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case object, ok := <-objectCh:
+			if !ok {
+				return ctx.Err()
+			}
+			if object.Err != nil {
+				return object.Err
+			}
+			if !fn(object) {
+				return nil
+			}
+		}
+	}
+}
+
+// Ping verifies that the endpoint is reachable and the credentials are valid
+// by performing a cheap authenticated call. It returns an InvalidCredentialsError
+// when the credentials are rejected and a ConnectivityError when the endpoint
+// cannot be reached.
+func (c *ObjectStorageClient) Ping(ctx context.Context) error {
+	ctx, cancel := c.operationContext(ctx, c.operationTimeout)
+	defer cancel()
+
+	_, err := c.minioClient.ListBuckets(ctx)
+	if err == nil {
+		return nil
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return &ConnectivityError{Endpoint: c.endpoint, Err: err}
+	}
+
+	resp := minio.ToErrorResponse(err)
+	switch {
+	case resp.StatusCode == http.StatusForbidden,
+		resp.Code == "AccessDenied",
+		resp.Code == "InvalidAccessKeyId",
+		resp.Code == "SignatureDoesNotMatch":
+		return &InvalidCredentialsError{Message: err.Error()}
+	}
+
+	return err
+}
+
 // Buckets returns a service to manage buckets.
 // This method allows access to functionality such as creating, listing, and managing buckets.
 func (c *ObjectStorageClient) Buckets() BucketService {
@@ -139,3 +567,14 @@ func (c *ObjectStorageClient) Buckets() BucketService {
 func (c *ObjectStorageClient) Objects() ObjectService {
 	return &objectService{client: c}
 }
+
+// minioMaxRetries returns the minio.Options.MaxRetries for a client: maxRetries when set,
+// or the retries allowed by the core client retry configuration. MinIO counts attempts,
+// including the first request, so the result is one more than the number of retries.
+func minioMaxRetries(core *client.CoreClient, maxRetries *int) int {
+	retries := max(core.GetConfig().RetryConfig.MaxAttempts-1, 0)
+	if maxRetries != nil {
+		retries = *maxRetries
+	}
+	return retries + 1
+}
@@ -1,9 +1,16 @@
 package objectstorage
 
 import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/minio/minio-go/v7"
 )
 
 func TestNewObjectStorageClient(t *testing.T) {
@@ -58,6 +65,44 @@ func TestNewObjectStorageClient(t *testing.T) {
 			wantErr:   false,
 			wantEp:    BrNe1,
 		},
+		{
+			name:      "valid - empty keys with credentials chain",
+			core:      createMockCoreClient(),
+			accessKey: "",
+			secretKey: "",
+			opts:      []ClientOption{WithCredentialsChain()},
+			wantErr:   false,
+			wantEp:    BrSe1,
+		},
+		{
+			name:      "valid - session token",
+			core:      createMockCoreClient(),
+			accessKey: "minioadmin",
+			secretKey: "minioadmin",
+			opts:      []ClientOption{WithSessionToken("token")},
+			wantErr:   false,
+			wantEp:    BrSe1,
+		},
+		{
+			name:      "invalid - session token without keys",
+			core:      createMockCoreClient(),
+			accessKey: "",
+			secretKey: "",
+			opts:      []ClientOption{WithCredentialsChain(), WithSessionToken("token")},
+			wantErr:   true,
+			errField:  "sessionToken",
+		},
+		{
+			name:      "valid - credentials provider without keys",
+			core:      createMockCoreClient(),
+			accessKey: "",
+			secretKey: "",
+			opts: []ClientOption{WithCredentialsProvider(func() (string, string, string, error) {
+				return "access", "secret", "token", nil
+			})},
+			wantErr: false,
+			wantEp:  BrSe1,
+		},
 	}
 
 	for _, tt := range tests {
@@ -269,6 +314,59 @@ func TestNewSetsAppInfoWithCustomUserAgent(t *testing.T) {
 	}
 }
 
+func TestNewSetsAppInfoWithUserAgentSuffix(t *testing.T) {
+	t.Parallel()
+
+	core := client.NewMgcClient(client.WithUserAgentSuffix("my-app/1.2.0"))
+	mockMinio := newMockMinioClient()
+
+	_, err := New(core, "minioadmin", "minioadmin", WithMinioClientInterface(mockMinio))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if mockMinio.lastAppName != "my-app/1.2.0" {
+		t.Errorf("expected app name 'my-app/1.2.0', got %q", mockMinio.lastAppName)
+	}
+
+	if mockMinio.lastAppVersion != client.DefaultUserAgent {
+		t.Errorf("expected app version %q, got %q", client.DefaultUserAgent, mockMinio.lastAppVersion)
+	}
+}
+
+func TestNewWithoutAppInfo(t *testing.T) {
+	t.Parallel()
+
+	mockMinio := newMockMinioClient()
+
+	_, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mockMinio), WithoutAppInfo())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if mockMinio.setAppInfoCalls != 0 {
+		t.Errorf("expected SetAppInfo not to be called, got %d calls", mockMinio.setAppInfoCalls)
+	}
+}
+
+func TestNewKeepsAppInfoOfUserMinioClient(t *testing.T) {
+	t.Parallel()
+
+	minioClient, err := minio.New("br-se1.magaluobjects.com", &minio.Options{Secure: true})
+	if err != nil {
+		t.Fatalf("minio.New() error = %v", err)
+	}
+
+	osClient, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithMinioClient(minioClient))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if !osClient.skipAppInfo {
+		t.Error("expected app info of a client given with WithMinioClient to be kept")
+	}
+}
+
 func TestNewWithEndpointDeprecated(t *testing.T) {
 	t.Parallel()
 
@@ -306,6 +404,76 @@ func TestNewWithInvalidEndpoint(t *testing.T) {
 	}
 }
 
+func TestWithBaseURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+	}{
+		{name: "plain http", baseURL: "http://localhost:9000", want: "http://localhost:9000"},
+		{name: "https", baseURL: "https://minio.local:9443/", want: "https://minio.local:9443"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			osClient, err := New(createMockCoreClient(), "minioadmin", "minioadmin",
+				WithEndpoint(BrNe1), WithBaseURL(tt.baseURL))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			minioClient, ok := osClient.minioClient.(minioAdapter)
+			if !ok {
+				t.Fatalf("expected a MinIO client to be created, got %T", osClient.minioClient)
+			}
+			if got := minioClient.EndpointURL().String(); got != tt.want {
+				t.Errorf("EndpointURL() = %s, want %s", got, tt.want)
+			}
+			if osClient.endpoint != Endpoint(tt.baseURL) {
+				t.Errorf("endpoint = %s, want %s", osClient.endpoint, tt.baseURL)
+			}
+		})
+	}
+}
+
+func TestWithRegion(t *testing.T) {
+	t.Parallel()
+
+	osClient, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithRegion(client.RegionBrNe1), WithEndpoint(BrSe1))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if osClient.endpoint != BrNe1 {
+		t.Errorf("expected endpoint %v, got %v", BrNe1, osClient.endpoint)
+	}
+
+	_, err = New(createMockCoreClient(), "minioadmin", "minioadmin", WithRegion("us-east-1"))
+	var validErr *client.ValidationError
+	if !errors.As(err, &validErr) || validErr.Field != "region" {
+		t.Errorf("New() error = %v, want ValidationError for region", err)
+	}
+}
+
+func TestWithBaseURL_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, baseURL := range []string{"localhost:9000", "ftp://localhost:9000", "http://", "http://localhost:9000/bucket", "http://%zz"} {
+		t.Run(baseURL, func(t *testing.T) {
+			_, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithBaseURL(baseURL))
+
+			var validErr *client.ValidationError
+			if !errors.As(err, &validErr) {
+				t.Fatalf("New() expected ValidationError, got %v", err)
+			}
+			if validErr.Field != "baseURL" {
+				t.Errorf("New() error field = %s, want baseURL", validErr.Field)
+			}
+		})
+	}
+}
+
 func createMockCoreClient() *client.CoreClient {
 	return client.NewMgcClient()
 }
@@ -349,3 +517,355 @@ func TestParseEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestOperationContext(t *testing.T) {
+	t.Parallel()
+
+	osClient := &ObjectStorageClient{}
+
+	t.Run("no timeout keeps context", func(t *testing.T) {
+		ctx, cancel := osClient.operationContext(context.Background(), 0)
+		defer cancel()
+
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("operationContext() set a deadline without timeout")
+		}
+	})
+
+	t.Run("timeout sets deadline", func(t *testing.T) {
+		ctx, cancel := osClient.operationContext(context.Background(), time.Minute)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			t.Fatal("operationContext() did not set a deadline")
+		}
+
+		if time.Until(deadline) > time.Minute {
+			t.Errorf("operationContext() deadline too far: %v", deadline)
+		}
+	})
+
+	t.Run("existing deadline is preserved", func(t *testing.T) {
+		parent, parentCancel := context.WithTimeout(context.Background(), time.Hour)
+		defer parentCancel()
+
+		ctx, cancel := osClient.operationContext(parent, time.Minute)
+		defer cancel()
+
+		parentDeadline, _ := parent.Deadline()
+		deadline, _ := ctx.Deadline()
+		if !deadline.Equal(parentDeadline) {
+			t.Errorf("operationContext() deadline = %v, want %v", deadline, parentDeadline)
+		}
+	})
+}
+
+func TestWithOperationTimeout(t *testing.T) {
+	t.Parallel()
+
+	var statHasDeadline, putHasDeadline, listHasDeadline bool
+	mock := newMockMinioClient()
+	mock.listObjectsFunc = func(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+		_, listHasDeadline = ctx.Deadline()
+		ch := make(chan minio.ObjectInfo)
+		close(ch)
+		return ch
+	}
+	mock.statObjectFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
+		_, statHasDeadline = ctx.Deadline()
+		return minio.ObjectInfo{Key: objectName}, nil
+	}
+	mock.putObjectFunc = func(ctx context.Context, bucketName string, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (minio.UploadInfo, error) {
+		_, putHasDeadline = ctx.Deadline()
+		return minio.UploadInfo{}, nil
+	}
+
+	osClient, err := New(createMockCoreClient(), "minioadmin", "minioadmin",
+		WithMinioClientInterface(mock),
+		WithOperationTimeout(time.Minute))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := osClient.Objects().Metadata(context.Background(), "test-bucket", "test-key"); err != nil {
+		t.Fatalf("Metadata() error = %v", err)
+	}
+
+	if err := osClient.Objects().Upload(context.Background(), "test-bucket", "test-key", []byte("data"), "text/plain"); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	if _, err := osClient.Objects().PreviewRemovePrefix(context.Background(), "test-bucket", "logs/"); err != nil {
+		t.Fatalf("PreviewRemovePrefix() error = %v", err)
+	}
+
+	if !listHasDeadline {
+		t.Error("PreviewRemovePrefix() expected operation deadline on context")
+	}
+
+	if !statHasDeadline {
+		t.Error("Metadata() expected operation deadline on context")
+	}
+
+	if putHasDeadline {
+		t.Error("Upload() expected no deadline without a transfer timeout")
+	}
+}
+
+func TestObjectStorageClientPing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr any
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:    "invalid credentials",
+			err:     minio.ErrorResponse{Code: "InvalidAccessKeyId", StatusCode: http.StatusForbidden},
+			wantErr: &InvalidCredentialsError{},
+		},
+		{
+			name:    "unreachable endpoint",
+			err:     &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			wantErr: &ConnectivityError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockMinioClient()
+			mock.listBucketsFunc = func(ctx context.Context) ([]minio.BucketInfo, error) {
+				return nil, tt.err
+			}
+
+			osClient, _ := New(createMockCoreClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+			err := osClient.Ping(context.Background())
+
+			switch tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Errorf("Ping() error = %v, want nil", err)
+				}
+			case *InvalidCredentialsError:
+				if _, ok := err.(*InvalidCredentialsError); !ok {
+					t.Errorf("Ping() expected InvalidCredentialsError, got %T", err)
+				}
+			case *ConnectivityError:
+				if _, ok := err.(*ConnectivityError); !ok {
+					t.Errorf("Ping() expected ConnectivityError, got %T", err)
+				}
+			}
+		})
+	}
+}
+
+func TestWithVirtualHostStyle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []ClientOption
+		want minio.BucketLookupType
+	}{
+		{name: "path style by default", want: minio.BucketLookupAuto},
+		{name: "virtual-hosted style", opts: []ClientOption{WithVirtualHostStyle()}, want: minio.BucketLookupDNS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			osClient, err := New(createMockCoreClient(), "minioadmin", "minioadmin", tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if osClient.bucketLookup != tt.want {
+				t.Errorf("bucketLookup = %v, want %v", osClient.bucketLookup, tt.want)
+			}
+			if _, ok := osClient.minioClient.(minioAdapter); !ok {
+				t.Errorf("expected a MinIO client to be created, got %T", osClient.minioClient)
+			}
+		})
+	}
+}
+
+func TestWithUploadOptions_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		opts      UploadOptions
+		wantField string
+	}{
+		{name: "defaults"},
+		{name: "custom values", opts: UploadOptions{MultipartThreshold: 128 * 1024 * 1024, PartSize: 32 * 1024 * 1024}},
+		{name: "part size below minimum", opts: UploadOptions{PartSize: 1024}, wantField: "partSize"},
+		{name: "negative threshold", opts: UploadOptions{MultipartThreshold: -1}, wantField: "multipartThreshold"},
+		{name: "threshold above single put limit", opts: UploadOptions{MultipartThreshold: MaxSinglePutSize + 1}, wantField: "multipartThreshold"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(createMockCoreClient(), "minioadmin", "minioadmin",
+				WithMinioClientInterface(newMockMinioClient()), WithUploadOptions(tt.opts))
+
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("New() error = %v", err)
+				}
+				return
+			}
+
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.wantField {
+				t.Errorf("New() error = %v, want ValidationError on %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestWithTransportOptions_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		opts      TransportOptions
+		wantField string
+	}{
+		{name: "defaults"},
+		{name: "custom values", opts: TransportOptions{MaxIdleConns: 512, MaxIdleConnsPerHost: 256, MaxConnsPerHost: 64}},
+		{name: "negative max idle conns", opts: TransportOptions{MaxIdleConns: -1}, wantField: "maxIdleConns"},
+		{name: "negative max idle conns per host", opts: TransportOptions{MaxIdleConnsPerHost: -1}, wantField: "maxIdleConnsPerHost"},
+		{name: "negative max conns per host", opts: TransportOptions{MaxConnsPerHost: -1}, wantField: "maxConnsPerHost"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithTransportOptions(tt.opts))
+
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("New() error = %v", err)
+				}
+				return
+			}
+
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.wantField {
+				t.Errorf("New() error = %v, want ValidationError on %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestWithMaxRetries(t *testing.T) {
+	t.Parallel()
+
+	five, zero := 5, 0
+	tests := []struct {
+		name       string
+		core       *client.CoreClient
+		maxRetries *int
+		want       int
+	}{
+		{name: "default retries from core config", core: createMockCoreClient(), want: 3},
+		{name: "core config without retries", core: client.NewMgcClient(client.WithRetryConfig(1, time.Second, time.Second, 2)), want: 1},
+		{name: "custom retries", core: createMockCoreClient(), maxRetries: &five, want: 6},
+		{name: "retries disabled", core: createMockCoreClient(), maxRetries: &zero, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := minioMaxRetries(tt.core, tt.maxRetries); got != tt.want {
+				t.Errorf("minioMaxRetries() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	_, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithMaxRetries(-1))
+	var validationErr *client.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "maxRetries" {
+		t.Errorf("New() error = %v, want ValidationError on maxRetries", err)
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	t.Parallel()
+
+	transport := newTransport(TransportOptions{})
+	if transport.MaxIdleConns != DefaultMaxIdleConns || transport.MaxIdleConnsPerHost != DefaultMaxIdleConnsPerHost || transport.MaxConnsPerHost != 0 {
+		t.Errorf("newTransport() defaults = %d/%d/%d, want %d/%d/0", transport.MaxIdleConns,
+			transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost, DefaultMaxIdleConns, DefaultMaxIdleConnsPerHost)
+	}
+	if transport == http.DefaultTransport {
+		t.Error("newTransport() expected a copy of http.DefaultTransport")
+	}
+
+	transport = newTransport(TransportOptions{MaxIdleConns: 10, MaxIdleConnsPerHost: 5, MaxConnsPerHost: 20})
+	if transport.MaxIdleConns != 10 || transport.MaxIdleConnsPerHost != 5 || transport.MaxConnsPerHost != 20 {
+		t.Errorf("newTransport() = %d/%d/%d, want 10/5/20", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost)
+	}
+}
+
+func TestTransportSetsRequestID(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	transport := &forceDeleteTransport{
+		base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			got = append(got, req.Header.Get(client.RequestIDHeader))
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}),
+	}
+
+	ctx := WithRequestID(context.Background(), "trace-123")
+	for _, reqCtx := range []context.Context{ctx, context.Background()} {
+		req, _ := http.NewRequestWithContext(reqCtx, http.MethodGet, "https://br-se1.magaluobjects.com/bucket", nil)
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatalf("RoundTrip() error = %v", err)
+		}
+	}
+
+	if got[0] != "trace-123" {
+		t.Errorf("expected request ID from context, got %q", got[0])
+	}
+	if got[1] == "" || got[1] == "trace-123" {
+		t.Errorf("expected a generated request ID, got %q", got[1])
+	}
+}
+
+func TestTransportSetsDefaultHeaders(t *testing.T) {
+	t.Parallel()
+
+	var got http.Header
+	transport := &forceDeleteTransport{
+		base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			got = req.Header
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}),
+		headers: map[string]string{"X-Tenant-ID": "tenant-1", "Authorization": "default"},
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://br-se1.magaluobjects.com/bucket", nil)
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 signature")
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+
+	if got.Get("X-Tenant-ID") != "tenant-1" {
+		t.Errorf("X-Tenant-ID = %q, want tenant-1", got.Get("X-Tenant-ID"))
+	}
+	if got.Get("Authorization") != "AWS4-HMAC-SHA256 signature" {
+		t.Errorf("Authorization = %q, want the request's own header", got.Get("Authorization"))
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
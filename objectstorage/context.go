@@ -1,6 +1,10 @@
 package objectstorage
 
-import "context"
+import (
+	"context"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
 
 type forceDeleteKeyType struct{}
 
@@ -14,3 +18,9 @@ func HasForceDelete(ctx context.Context) bool {
 	v, ok := ctx.Value(forceDeleteKey).(bool)
 	return ok && v
 }
+
+// WithRequestID returns a copy of ctx carrying the given request ID, which is sent
+// as the X-Request-ID header of every object storage request made with it.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return client.WithRequestID(ctx, id)
+}
@@ -0,0 +1,127 @@
+package objectstorage
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// corsMethods are the HTTP methods a CORS rule may allow.
+var corsMethods = []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodHead}
+
+// CORSRuleBuilder assembles a CORS rule with readable chained calls.
+//
+//	config, err := objectstorage.NewCORSRule().
+//		AllowOrigins("https://app.example.com").
+//		AllowMethods(http.MethodGet, http.MethodPut).
+//		AllowHeaders("*").
+//		MaxAge(time.Hour).
+//		Build()
+type CORSRuleBuilder struct {
+	rule CORSRule
+}
+
+// NewCORSRule starts a CORS rule with no origins, methods or headers allowed.
+func NewCORSRule() *CORSRuleBuilder {
+	return &CORSRuleBuilder{}
+}
+
+// AllowOrigins adds origins allowed to make cross-origin requests, such as
+// "https://app.example.com". An origin may contain a single "*" wildcard.
+func (b *CORSRuleBuilder) AllowOrigins(origins ...string) *CORSRuleBuilder {
+	b.rule.AllowedOrigins = append(b.rule.AllowedOrigins, origins...)
+	return b
+}
+
+// AllowMethods adds the HTTP methods allowed from the origins: GET, PUT, POST, DELETE or HEAD.
+func (b *CORSRuleBuilder) AllowMethods(methods ...string) *CORSRuleBuilder {
+	b.rule.AllowedMethods = append(b.rule.AllowedMethods, methods...)
+	return b
+}
+
+// AllowHeaders adds the request headers allowed in preflight requests, "*" for any.
+func (b *CORSRuleBuilder) AllowHeaders(headers ...string) *CORSRuleBuilder {
+	b.rule.AllowedHeaders = append(b.rule.AllowedHeaders, headers...)
+	return b
+}
+
+// ExposeHeaders adds the response headers browsers may expose to the page, such as "ETag".
+func (b *CORSRuleBuilder) ExposeHeaders(headers ...string) *CORSRuleBuilder {
+	b.rule.ExposeHeaders = append(b.rule.ExposeHeaders, headers...)
+	return b
+}
+
+// MaxAge sets how long browsers may cache the preflight response, truncated to seconds.
+func (b *CORSRuleBuilder) MaxAge(d time.Duration) *CORSRuleBuilder {
+	b.rule.MaxAgeSeconds = int(d / time.Second)
+	return b
+}
+
+// Rule returns the rule built so far without validating it, to combine several
+// rules in a CORSConfiguration.
+func (b *CORSRuleBuilder) Rule() CORSRule {
+	return b.rule
+}
+
+// Build returns a validated configuration holding the rule.
+func (b *CORSRuleBuilder) Build() (*CORSConfiguration, error) {
+	config := &CORSConfiguration{CORSRules: []CORSRule{b.rule}}
+	if err := ValidateCORS(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// ValidateCORS checks a CORS configuration before it is sent. Every rule must allow
+// at least one origin and one method, methods must be GET, PUT, POST, DELETE or HEAD,
+// origins may contain at most one "*" wildcard and the max age cannot be negative.
+// It returns an InvalidPolicyError naming the first offending rule.
+func ValidateCORS(config *CORSConfiguration) error {
+	if config == nil {
+		return &InvalidPolicyError{Message: "CORS configuration cannot be nil"}
+	}
+
+	if len(config.CORSRules) == 0 {
+		return &InvalidPolicyError{Message: "CORS configuration must have at least one rule"}
+	}
+
+	for i, rule := range config.CORSRules {
+		if err := validateCORSRule(rule); err != nil {
+			return &InvalidPolicyError{Message: fmt.Sprintf("CORS rule %d: %s", i+1, err)}
+		}
+	}
+
+	return nil
+}
+
+// validateCORSRule returns a description of the first problem found in rule.
+func validateCORSRule(rule CORSRule) error {
+	if len(rule.AllowedOrigins) == 0 {
+		return fmt.Errorf("must allow at least one origin")
+	}
+	for _, origin := range rule.AllowedOrigins {
+		if origin == "" {
+			return fmt.Errorf("origins cannot be empty")
+		}
+		if strings.Count(origin, "*") > 1 {
+			return fmt.Errorf("origin %q can contain at most one wildcard", origin)
+		}
+	}
+
+	if len(rule.AllowedMethods) == 0 {
+		return fmt.Errorf("must allow at least one method")
+	}
+	for _, method := range rule.AllowedMethods {
+		if !slices.Contains(corsMethods, method) {
+			return fmt.Errorf("method %q is not one of %s", method, strings.Join(corsMethods, ", "))
+		}
+	}
+
+	if rule.MaxAgeSeconds < 0 {
+		return fmt.Errorf("max age cannot be negative")
+	}
+
+	return nil
+}
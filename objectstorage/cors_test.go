@@ -0,0 +1,142 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/cors"
+)
+
+func TestCORSRuleBuilder(t *testing.T) {
+	t.Parallel()
+
+	config, err := NewCORSRule().
+		AllowOrigins("https://app.example.com").
+		AllowMethods(http.MethodGet, http.MethodPut).
+		AllowHeaders("*").
+		ExposeHeaders("ETag").
+		MaxAge(90 * time.Minute).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if len(config.CORSRules) != 1 {
+		t.Fatalf("Build() rules = %d, want 1", len(config.CORSRules))
+	}
+	rule := config.CORSRules[0]
+	if rule.AllowedOrigins[0] != "https://app.example.com" || len(rule.AllowedMethods) != 2 ||
+		rule.AllowedHeaders[0] != "*" || rule.ExposeHeaders[0] != "ETag" || rule.MaxAgeSeconds != 5400 {
+		t.Errorf("Build() rule = %+v", rule)
+	}
+}
+
+func TestCORSRuleBuilder_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewCORSRule().AllowMethods(http.MethodGet).Build()
+
+	var policyErr *InvalidPolicyError
+	if !errors.As(err, &policyErr) || !strings.Contains(policyErr.Message, "origin") {
+		t.Errorf("Build() error = %v, want InvalidPolicyError about origins", err)
+	}
+}
+
+func TestValidateCORS(t *testing.T) {
+	t.Parallel()
+
+	valid := NewCORSRule().AllowOrigins("https://*.example.com").AllowMethods(http.MethodGet).Rule()
+	withRule := func(modify func(r *CORSRule)) *CORSConfiguration {
+		rule := valid
+		modify(&rule)
+		return &CORSConfiguration{CORSRules: []CORSRule{valid, rule}}
+	}
+
+	tests := []struct {
+		name        string
+		config      *CORSConfiguration
+		wantMessage string
+	}{
+		{name: "valid", config: withRule(func(r *CORSRule) {})},
+		{name: "nil", config: nil, wantMessage: "CORS configuration cannot be nil"},
+		{name: "no rules", config: &CORSConfiguration{}, wantMessage: "CORS configuration must have at least one rule"},
+		{
+			name:        "no origins",
+			config:      withRule(func(r *CORSRule) { r.AllowedOrigins = nil }),
+			wantMessage: "CORS rule 2: must allow at least one origin",
+		},
+		{
+			name:        "empty origin",
+			config:      withRule(func(r *CORSRule) { r.AllowedOrigins = []string{""} }),
+			wantMessage: "CORS rule 2: origins cannot be empty",
+		},
+		{
+			name:        "several wildcards",
+			config:      withRule(func(r *CORSRule) { r.AllowedOrigins = []string{"https://*.*.com"} }),
+			wantMessage: `CORS rule 2: origin "https://*.*.com" can contain at most one wildcard`,
+		},
+		{
+			name:        "no methods",
+			config:      withRule(func(r *CORSRule) { r.AllowedMethods = nil }),
+			wantMessage: "CORS rule 2: must allow at least one method",
+		},
+		{
+			name:        "invalid method",
+			config:      withRule(func(r *CORSRule) { r.AllowedMethods = []string{"PATCH"} }),
+			wantMessage: `CORS rule 2: method "PATCH" is not one of GET, PUT, POST, DELETE, HEAD`,
+		},
+		{
+			name:        "negative max age",
+			config:      withRule(func(r *CORSRule) { r.MaxAgeSeconds = -1 }),
+			wantMessage: "CORS rule 2: max age cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCORS(tt.config)
+
+			if tt.wantMessage == "" {
+				if err != nil {
+					t.Errorf("ValidateCORS() error = %v", err)
+				}
+				return
+			}
+
+			var policyErr *InvalidPolicyError
+			if !errors.As(err, &policyErr) || policyErr.Message != tt.wantMessage {
+				t.Errorf("ValidateCORS() error = %v, want %q", err, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestBucketServiceSetCORS_ValidatesBeforeSending(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockMinioClient()
+	called := false
+	mock.setCorsFunc = func(ctx context.Context, bucketName string, corsConfig *cors.Config) error {
+		called = true
+		return nil
+	}
+	osClient, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	config := &CORSConfiguration{CORSRules: []CORSRule{{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"TRACE"}}}}
+	err = osClient.Buckets().SetCORS(context.Background(), "test-bucket", config)
+
+	var policyErr *InvalidPolicyError
+	if !errors.As(err, &policyErr) {
+		t.Errorf("SetCORS() error = %v, want InvalidPolicyError", err)
+	}
+	if called {
+		t.Error("SetCORS() sent an invalid configuration")
+	}
+}
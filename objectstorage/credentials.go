@@ -0,0 +1,130 @@
+package objectstorage
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// DefaultCredentialsRefreshInterval is how long credentials returned by a
+// CredentialsProviderFunc are cached before the function is called again.
+const DefaultCredentialsRefreshInterval = 5 * time.Minute
+
+// Environment variables read by the credentials chain.
+const (
+	EnvAccessKey    = "MGC_ACCESS_KEY"
+	EnvSecretKey    = "MGC_SECRET_KEY"
+	EnvSessionToken = "MGC_SESSION_TOKEN"
+)
+
+// envCredentials retrieves credentials from the MGC_ACCESS_KEY, MGC_SECRET_KEY and
+// optional MGC_SESSION_TOKEN environment variables. Environment credentials never expire.
+type envCredentials struct {
+	retrieved bool
+}
+
+func (e *envCredentials) retrieve() (credentials.Value, error) {
+	e.retrieved = false
+
+	id := os.Getenv(EnvAccessKey)
+	secret := os.Getenv(EnvSecretKey)
+
+	signerType := credentials.SignatureV4
+	if id == "" || secret == "" {
+		signerType = credentials.SignatureAnonymous
+	}
+
+	e.retrieved = true
+	return credentials.Value{
+		AccessKeyID:     id,
+		SecretAccessKey: secret,
+		SessionToken:    os.Getenv(EnvSessionToken),
+		SignerType:      signerType,
+	}, nil
+}
+
+// Retrieve retrieves the keys from the environment.
+func (e *envCredentials) Retrieve() (credentials.Value, error) {
+	return e.retrieve()
+}
+
+// RetrieveWithCredContext is like Retrieve, ignoring the credentials context.
+func (e *envCredentials) RetrieveWithCredContext(_ *credentials.CredContext) (credentials.Value, error) {
+	return e.retrieve()
+}
+
+// IsExpired returns if the credentials have not been retrieved yet.
+func (e *envCredentials) IsExpired() bool {
+	return !e.retrieved
+}
+
+// newCredentialsChain builds the credentials chain used by WithCredentialsChain.
+// Explicit keys, when given, take precedence over the environment, the shared
+// credentials files and the IAM metadata service, in this order.
+func newCredentialsChain(accessKey string, secretKey string, sessionToken string) *credentials.Credentials {
+	providers := make([]credentials.Provider, 0, 5)
+	if accessKey != "" && secretKey != "" {
+		providers = append(providers, &credentials.Static{
+			Value: credentials.Value{
+				AccessKeyID:     accessKey,
+				SecretAccessKey: secretKey,
+				SessionToken:    sessionToken,
+				SignerType:      credentials.SignatureV4,
+			},
+		})
+	}
+
+	providers = append(providers,
+		&envCredentials{},
+		&credentials.FileAWSCredentials{},
+		&credentials.FileMinioClient{},
+		&credentials.IAM{},
+	)
+
+	return credentials.NewChainCredentials(providers)
+}
+
+// CredentialsProviderFunc returns the current credentials for the object storage client.
+// It is called on first use and again whenever the cached credentials expire, allowing
+// rotated temporary credentials to be picked up without recreating the client.
+type CredentialsProviderFunc func() (accessKey string, secretKey string, token string, err error)
+
+// funcCredentials adapts a CredentialsProviderFunc to MinIO's credentials provider interface.
+type funcCredentials struct {
+	credentials.Expiry
+
+	fn              CredentialsProviderFunc
+	refreshInterval time.Duration
+}
+
+func (f *funcCredentials) retrieve() (credentials.Value, error) {
+	accessKey, secretKey, token, err := f.fn()
+	if err != nil {
+		return credentials.Value{}, err
+	}
+
+	if accessKey == "" || secretKey == "" {
+		return credentials.Value{}, errors.New("credentials provider returned empty keys")
+	}
+
+	f.SetExpiration(time.Now().Add(f.refreshInterval), 0)
+
+	return credentials.Value{
+		AccessKeyID:     accessKey,
+		SecretAccessKey: secretKey,
+		SessionToken:    token,
+		SignerType:      credentials.SignatureV4,
+	}, nil
+}
+
+// Retrieve calls the provider function to fetch fresh credentials.
+func (f *funcCredentials) Retrieve() (credentials.Value, error) {
+	return f.retrieve()
+}
+
+// RetrieveWithCredContext is like Retrieve, ignoring the credentials context.
+func (f *funcCredentials) RetrieveWithCredContext(_ *credentials.CredContext) (credentials.Value, error) {
+	return f.retrieve()
+}
@@ -0,0 +1,159 @@
+package objectstorage
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv(EnvAccessKey, "env-access")
+	t.Setenv(EnvSecretKey, "env-secret")
+	t.Setenv(EnvSessionToken, "env-token")
+
+	provider := &envCredentials{}
+	if !provider.IsExpired() {
+		t.Error("envCredentials.IsExpired() expected true before retrieval")
+	}
+
+	value, err := provider.Retrieve()
+	if err != nil {
+		t.Fatalf("envCredentials.Retrieve() error = %v", err)
+	}
+
+	if value.AccessKeyID != "env-access" || value.SecretAccessKey != "env-secret" {
+		t.Errorf("envCredentials.Retrieve() = %+v, want env keys", value)
+	}
+
+	if value.SessionToken != "env-token" {
+		t.Errorf("envCredentials.Retrieve() session token = %s, want env-token", value.SessionToken)
+	}
+
+	if value.SignerType != credentials.SignatureV4 {
+		t.Errorf("envCredentials.Retrieve() signer = %v, want SignatureV4", value.SignerType)
+	}
+
+	if provider.IsExpired() {
+		t.Error("envCredentials.IsExpired() expected false after retrieval")
+	}
+}
+
+func TestEnvCredentials_Unset(t *testing.T) {
+	t.Setenv(EnvAccessKey, "")
+	t.Setenv(EnvSecretKey, "")
+
+	value, err := (&envCredentials{}).Retrieve()
+	if err != nil {
+		t.Fatalf("envCredentials.Retrieve() error = %v", err)
+	}
+
+	if value.SignerType != credentials.SignatureAnonymous {
+		t.Errorf("envCredentials.Retrieve() signer = %v, want SignatureAnonymous", value.SignerType)
+	}
+}
+
+func TestNewCredentialsChain(t *testing.T) {
+	t.Setenv(EnvAccessKey, "env-access")
+	t.Setenv(EnvSecretKey, "env-secret")
+
+	t.Run("explicit keys take precedence", func(t *testing.T) {
+		value, err := newCredentialsChain("explicit-access", "explicit-secret", "explicit-token").Get()
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+
+		if value.AccessKeyID != "explicit-access" {
+			t.Errorf("Get() access key = %s, want explicit-access", value.AccessKeyID)
+		}
+
+		if value.SessionToken != "explicit-token" {
+			t.Errorf("Get() session token = %s, want explicit-token", value.SessionToken)
+		}
+	})
+
+	t.Run("falls back to environment", func(t *testing.T) {
+		value, err := newCredentialsChain("", "", "").Get()
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+
+		if value.AccessKeyID != "env-access" {
+			t.Errorf("Get() access key = %s, want env-access", value.AccessKeyID)
+		}
+	})
+}
+
+func TestFuncCredentials_Refresh(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	creds := credentials.New(&funcCredentials{
+		fn: func() (string, string, string, error) {
+			calls++
+			return "access-" + strconv.Itoa(calls), "secret", "token", nil
+		},
+		refreshInterval: time.Hour,
+	})
+
+	value, err := creds.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if value.AccessKeyID != "access-1" || value.SessionToken != "token" {
+		t.Errorf("Get() = %+v, want first provided credentials", value)
+	}
+
+	if _, err := creds.Get(); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("provider called %d times, want 1 while credentials are cached", calls)
+	}
+
+	creds.Expire()
+
+	value, err = creds.Get()
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if value.AccessKeyID != "access-2" {
+		t.Errorf("Get() access key = %s, want rotated access-2", value.AccessKeyID)
+	}
+}
+
+func TestFuncCredentials_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fn   CredentialsProviderFunc
+	}{
+		{
+			name: "provider error",
+			fn: func() (string, string, string, error) {
+				return "", "", "", errors.New("broker unavailable")
+			},
+		},
+		{
+			name: "empty keys",
+			fn: func() (string, string, string, error) {
+				return "", "", "", nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &funcCredentials{fn: tt.fn, refreshInterval: time.Hour}
+			if _, err := provider.Retrieve(); err == nil {
+				t.Error("Retrieve() expected error, got nil")
+			}
+		})
+	}
+}
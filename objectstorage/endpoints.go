@@ -1,6 +1,15 @@
 package objectstorage
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
 
 // Endpoint represents a MagaluObjects endpoint.
 type Endpoint string
@@ -15,6 +24,21 @@ const (
 	BrMgl1 Endpoint = "br-se-1.magaluobjects.com"
 )
 
+// DefaultAutoEndpointTimeout bounds the probing of WithAutoEndpoint.
+const DefaultAutoEndpointTimeout = 2 * time.Second
+
+// knownEndpoints returns the MagaluObjects endpoints of the client region registry,
+// in order of preference.
+func knownEndpoints() []Endpoint {
+	var endpoints []Endpoint
+	for _, region := range client.KnownRegions() {
+		if url, ok := region.Endpoints[client.ServiceObjectStorage]; ok {
+			endpoints = append(endpoints, Endpoint(url))
+		}
+	}
+	return endpoints
+}
+
 // String returns the string representation of the endpoint.
 func (e Endpoint) String() string {
 	return string(e)
@@ -22,12 +46,7 @@ func (e Endpoint) String() string {
 
 // IsValid checks if the endpoint is valid.
 func (e Endpoint) IsValid() bool {
-	switch e {
-	case BrSe1, BrNe1:
-		return true
-	default:
-		return false
-	}
+	return slices.Contains(knownEndpoints(), e)
 }
 
 // ValidateEndpoint validates an endpoint and returns an error if invalid.
@@ -37,3 +56,79 @@ func ValidateEndpoint(e Endpoint) error {
 	}
 	return nil
 }
+
+// EndpointForHost returns the known endpoint serving the given host name.
+// Both path-style ("br-se1.magaluobjects.com") and virtual-hosted-style
+// ("bucket.br-se1.magaluobjects.com") hosts are recognized.
+func EndpointForHost(host string) (Endpoint, bool) {
+	host = strings.ToLower(host)
+	for _, e := range knownEndpoints() {
+		endpointHost := parseEndpoint(e)
+		if host == endpointHost || strings.HasSuffix(host, "."+endpointHost) {
+			return e, true
+		}
+	}
+	return "", false
+}
+
+// EndpointForRegion returns the endpoint of a region identifier shared with the other
+// regional services, such as BrNe1 for client.RegionBrNe1, as listed by client.KnownRegions.
+// It returns false for regions without an object storage endpoint.
+func EndpointForRegion(region string) (Endpoint, bool) {
+	for _, r := range client.KnownRegions() {
+		if r.Code == region {
+			url, ok := r.Endpoints[client.ServiceObjectStorage]
+			return Endpoint(url), ok
+		}
+	}
+	return "", false
+}
+
+// RegionForEndpoint returns the region identifier shared with the other regional services,
+// such as client.RegionBrNe1 for BrNe1. It is the inverse of EndpointForRegion.
+func RegionForEndpoint(e Endpoint) (string, bool) {
+	for _, r := range client.KnownRegions() {
+		if url, ok := r.Endpoints[client.ServiceObjectStorage]; ok && Endpoint(url) == e {
+			return r.Code, true
+		}
+	}
+	return "", false
+}
+
+// probeEndpoints sends a HEAD request to every endpoint at once and returns the first one
+// to answer, which is the one with the lowest latency. Any HTTP response counts, since the
+// requests are not authenticated. It returns false when no endpoint answers within timeout.
+func probeEndpoints(httpClient *http.Client, endpoints []Endpoint, timeout time.Duration) (Endpoint, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	answered := make(chan Endpoint, len(endpoints))
+	for _, e := range endpoints {
+		go func() {
+			req, err := http.NewRequestWithContext(ctx, http.MethodHead, e.String(), nil)
+			if err != nil {
+				answered <- ""
+				return
+			}
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				answered <- ""
+				return
+			}
+			resp.Body.Close()
+			answered <- e
+		}()
+	}
+
+	for range endpoints {
+		select {
+		case e := <-answered:
+			if e != "" {
+				return e, true
+			}
+		case <-ctx.Done():
+			return "", false
+		}
+	}
+	return "", false
+}
@@ -1,7 +1,12 @@
 package objectstorage
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
 )
 
 func TestEndpointString(t *testing.T) {
@@ -117,3 +122,107 @@ func TestEndpointConstants(t *testing.T) {
 		t.Errorf("BrNe1 constant has wrong value: %q", BrNe1)
 	}
 }
+
+func TestEndpointForHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		host     string
+		expected Endpoint
+		found    bool
+	}{
+		{name: "br-se1 host", host: "br-se1.magaluobjects.com", expected: BrSe1, found: true},
+		{name: "br-ne1 host", host: "br-ne1.magaluobjects.com", expected: BrNe1, found: true},
+		{name: "virtual-hosted bucket", host: "my-bucket.br-se1.magaluobjects.com", expected: BrSe1, found: true},
+		{name: "upper case host", host: "BR-NE1.MagaluObjects.com", expected: BrNe1, found: true},
+		{name: "unknown host", host: "s3.amazonaws.com", found: false},
+		{name: "lookalike host", host: "evilbr-se1.magaluobjects.com", found: false},
+		{name: "empty host", host: "", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := EndpointForHost(tt.host)
+			if found != tt.found || got != tt.expected {
+				t.Errorf("EndpointForHost(%q) = (%q, %v), want (%q, %v)", tt.host, got, found, tt.expected, tt.found)
+			}
+		})
+	}
+}
+
+func TestEndpointForRegion(t *testing.T) {
+	tests := []struct {
+		region   string
+		expected Endpoint
+		found    bool
+	}{
+		{region: client.RegionBrSe1, expected: BrSe1, found: true},
+		{region: client.RegionBrNe1, expected: BrNe1, found: true},
+		{region: client.RegionBrMgl1, found: false},
+		{region: "us-east-1", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			got, found := EndpointForRegion(tt.region)
+			if found != tt.found || got != tt.expected {
+				t.Errorf("EndpointForRegion(%q) = (%q, %v), want (%q, %v)", tt.region, got, found, tt.expected, tt.found)
+			}
+		})
+	}
+}
+
+func TestProbeEndpoints(t *testing.T) {
+	newServer := func(delay time.Duration) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodHead {
+				t.Errorf("probe method = %s, want HEAD", r.Method)
+			}
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+			}
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	fast := Endpoint(newServer(0).URL)
+	slow := Endpoint(newServer(300 * time.Millisecond).URL)
+	downServer := httptest.NewServer(http.NotFoundHandler())
+	down := Endpoint(downServer.URL)
+	downServer.Close()
+
+	tests := []struct {
+		name      string
+		endpoints []Endpoint
+		timeout   time.Duration
+		want      Endpoint
+		wantOK    bool
+	}{
+		{name: "lowest latency wins", endpoints: []Endpoint{slow, fast}, timeout: time.Second, want: fast, wantOK: true},
+		{name: "unreachable endpoint skipped", endpoints: []Endpoint{down, slow}, timeout: time.Second, want: slow, wantOK: true},
+		{name: "none reachable", endpoints: []Endpoint{down}, timeout: time.Second},
+		{name: "timeout", endpoints: []Endpoint{slow}, timeout: 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := probeEndpoints(http.DefaultClient, tt.endpoints, tt.timeout)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("probeEndpoints() = %q, %v; want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestWithAutoEndpoint_Skipped(t *testing.T) {
+	osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin",
+		WithAutoEndpoint(), WithEndpoint(BrNe1), WithMinioClientInterface(newMockMinioClient()))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if osClient.endpoint != BrNe1 {
+		t.Errorf("endpoint = %s, want %s without probing for a custom MinIO client", osClient.endpoint, BrNe1)
+	}
+}
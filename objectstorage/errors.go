@@ -1,6 +1,9 @@
 package objectstorage
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // InvalidBucketNameError is returned when a bucket name is invalid or empty.
 type InvalidBucketNameError struct {
@@ -66,3 +69,128 @@ type ObjectError struct {
 func (e *ObjectError) Error() string {
 	return fmt.Sprintf("object operation %s on %s/%s failed: %s", e.Operation, e.Bucket, e.Key, e.Message)
 }
+
+// ObjectNotFoundError is returned when an object does not exist in a bucket.
+type ObjectNotFoundError struct {
+	Bucket string
+	Key    string
+}
+
+// Error returns a string representation of the error.
+func (e *ObjectNotFoundError) Error() string {
+	return fmt.Sprintf("object not found: %s/%s", e.Bucket, e.Key)
+}
+
+// BucketNotFoundError is returned when a bucket does not exist.
+type BucketNotFoundError struct {
+	Bucket string
+}
+
+// Error returns a string representation of the error.
+func (e *BucketNotFoundError) Error() string {
+	return fmt.Sprintf("bucket not found: %s", e.Bucket)
+}
+
+// UploadNotFoundError is returned when a multipart upload does not exist,
+// for instance because it was already completed or aborted.
+type UploadNotFoundError struct {
+	Bucket   string
+	Key      string
+	UploadID string
+}
+
+// Error returns a string representation of the error.
+func (e *UploadNotFoundError) Error() string {
+	return fmt.Sprintf("multipart upload not found: %s/%s (%s)", e.Bucket, e.Key, e.UploadID)
+}
+
+// NoRetentionError is returned when an object has no retention set.
+type NoRetentionError struct {
+	Bucket string
+	Key    string
+}
+
+// Error returns a string representation of the error.
+func (e *NoRetentionError) Error() string {
+	return fmt.Sprintf("no retention set: %s/%s", e.Bucket, e.Key)
+}
+
+// ObjectModifiedError is returned when a conditional delete finds the object was
+// modified after the time given in DeleteOptions.IfUnmodifiedSince.
+type ObjectModifiedError struct {
+	Bucket       string
+	Key          string
+	LastModified time.Time
+}
+
+// Error returns a string representation of the error.
+func (e *ObjectModifiedError) Error() string {
+	return fmt.Sprintf("object modified at %s: %s/%s", e.LastModified.Format(time.RFC3339), e.Bucket, e.Key)
+}
+
+// StaleReadError is returned when an object is read with another ETag than the one
+// expected, such as when reading it right after a write that has not propagated yet.
+type StaleReadError struct {
+	Bucket       string
+	Key          string
+	ExpectedETag string
+	ETag         string
+}
+
+// Error returns a string representation of the error.
+func (e *StaleReadError) Error() string {
+	return fmt.Sprintf("stale read of %s/%s: ETag %q, want %q", e.Bucket, e.Key, e.ETag, e.ExpectedETag)
+}
+
+// BucketRegionMismatchError is returned when a bucket lives in another region than the
+// one of the client endpoint, where requests for it fail.
+type BucketRegionMismatchError struct {
+	Bucket         string
+	Region         string
+	ExpectedRegion string
+}
+
+// Error returns a string representation of the error.
+func (e *BucketRegionMismatchError) Error() string {
+	return fmt.Sprintf("bucket %s is in region %s, but the client uses %s", e.Bucket, e.Region, e.ExpectedRegion)
+}
+
+// InvalidCredentialsError is returned when the endpoint rejects the configured credentials.
+type InvalidCredentialsError struct {
+	Message string
+}
+
+// Error returns a string representation of the error.
+func (e *InvalidCredentialsError) Error() string {
+	return fmt.Sprintf("invalid credentials: %s", e.Message)
+}
+
+// ConnectivityError is returned when the object storage endpoint cannot be reached.
+type ConnectivityError struct {
+	Endpoint Endpoint
+	Err      error
+}
+
+// Error returns a string representation of the error.
+func (e *ConnectivityError) Error() string {
+	return fmt.Sprintf("endpoint %s unreachable: %v", e.Endpoint, e.Err)
+}
+
+// Unwrap returns the underlying network error.
+func (e *ConnectivityError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidPresignedURLError is returned when a presigned URL is malformed.
+type InvalidPresignedURLError struct {
+	Param   string
+	Message string
+}
+
+// Error returns a string representation of the error.
+func (e *InvalidPresignedURLError) Error() string {
+	if e.Param == "" {
+		return fmt.Sprintf("invalid presigned URL: %s", e.Message)
+	}
+	return fmt.Sprintf("invalid presigned URL: %s %s", e.Param, e.Message)
+}
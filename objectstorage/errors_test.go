@@ -1,7 +1,9 @@
 package objectstorage
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestInvalidBucketNameError(t *testing.T) {
@@ -85,6 +87,87 @@ func TestObjectError(t *testing.T) {
 	}
 }
 
+func TestObjectNotFoundError(t *testing.T) {
+	t.Parallel()
+
+	err := &ObjectNotFoundError{Bucket: "test-bucket", Key: "test-key"}
+	expectedMsg := "object not found: test-bucket/test-key"
+	if err.Error() != expectedMsg {
+		t.Errorf("ObjectNotFoundError.Error() expected %q, got %q", expectedMsg, err.Error())
+	}
+}
+
+func TestUploadNotFoundError(t *testing.T) {
+	t.Parallel()
+
+	err := &UploadNotFoundError{Bucket: "test-bucket", Key: "test-key", UploadID: "upload-1"}
+	expectedMsg := "multipart upload not found: test-bucket/test-key (upload-1)"
+	if err.Error() != expectedMsg {
+		t.Errorf("UploadNotFoundError.Error() expected %q, got %q", expectedMsg, err.Error())
+	}
+}
+
+func TestInvalidCredentialsError(t *testing.T) {
+	t.Parallel()
+
+	err := &InvalidCredentialsError{Message: "access denied"}
+	expectedMsg := "invalid credentials: access denied"
+	if err.Error() != expectedMsg {
+		t.Errorf("InvalidCredentialsError.Error() expected %q, got %q", expectedMsg, err.Error())
+	}
+}
+
+func TestConnectivityError(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("connection refused")
+	err := &ConnectivityError{Endpoint: BrSe1, Err: cause}
+	expectedMsg := "endpoint https://br-se1.magaluobjects.com unreachable: connection refused"
+	if err.Error() != expectedMsg {
+		t.Errorf("ConnectivityError.Error() expected %q, got %q", expectedMsg, err.Error())
+	}
+
+	if !errors.Is(err, cause) {
+		t.Error("ConnectivityError expected to unwrap to its cause")
+	}
+}
+
+func TestInvalidPresignedURLError(t *testing.T) {
+	t.Parallel()
+
+	err := &InvalidPresignedURLError{Param: "X-Amz-Date", Message: "is not a valid timestamp"}
+	expectedMsg := "invalid presigned URL: X-Amz-Date is not a valid timestamp"
+	if err.Error() != expectedMsg {
+		t.Errorf("InvalidPresignedURLError.Error() expected %q, got %q", expectedMsg, err.Error())
+	}
+
+	err = &InvalidPresignedURLError{Message: "URL is nil"}
+	expectedMsg = "invalid presigned URL: URL is nil"
+	if err.Error() != expectedMsg {
+		t.Errorf("InvalidPresignedURLError.Error() expected %q, got %q", expectedMsg, err.Error())
+	}
+}
+
+func TestNoRetentionError(t *testing.T) {
+	t.Parallel()
+
+	err := &NoRetentionError{Bucket: "my-bucket", Key: "file.txt"}
+	expectedMsg := "no retention set: my-bucket/file.txt"
+	if err.Error() != expectedMsg {
+		t.Errorf("NoRetentionError.Error() expected %q, got %q", expectedMsg, err.Error())
+	}
+}
+
+func TestObjectModifiedError(t *testing.T) {
+	t.Parallel()
+
+	err := &ObjectModifiedError{Bucket: "my-bucket", Key: "file.txt", LastModified: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	expectedMsg := "object modified at 2024-01-01T12:00:00Z: my-bucket/file.txt"
+	if err.Error() != expectedMsg {
+		t.Errorf("ObjectModifiedError.Error() expected %q, got %q", expectedMsg, err.Error())
+	}
+}
+
 func TestErrorImplementsInterface(t *testing.T) {
 	t.Parallel()
 
@@ -93,4 +176,20 @@ func TestErrorImplementsInterface(t *testing.T) {
 	var _ error = (*InvalidObjectDataError)(nil)
 	var _ error = (*BucketError)(nil)
 	var _ error = (*ObjectError)(nil)
+	var _ error = (*ObjectNotFoundError)(nil)
+	var _ error = (*InvalidCredentialsError)(nil)
+	var _ error = (*ConnectivityError)(nil)
+	var _ error = (*InvalidPresignedURLError)(nil)
+	var _ error = (*NoRetentionError)(nil)
+	var _ error = (*ObjectModifiedError)(nil)
+}
+
+func TestBucketNotFoundError(t *testing.T) {
+	t.Parallel()
+
+	err := &BucketNotFoundError{Bucket: "test-bucket"}
+	expectedMsg := "bucket not found: test-bucket"
+	if err.Error() != expectedMsg {
+		t.Errorf("BucketNotFoundError.Error() expected %q, got %q", expectedMsg, err.Error())
+	}
 }
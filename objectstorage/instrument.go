@@ -0,0 +1,294 @@
+package objectstorage
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/cors"
+	"github.com/minio/minio-go/v7/pkg/notification"
+)
+
+// operation describes a MinIO call once it returned.
+type operation struct {
+	name     string
+	bucket   string
+	key      string
+	duration time.Duration
+	err      error
+	// local marks operations computed by the client without sending a request, such
+	// as presigning, which are logged but not recorded as metrics.
+	local bool
+	// attrs holds details specific to the operation, such as the listing prefix.
+	attrs []slog.Attr
+}
+
+// operationObserver is notified of every MinIO call made through an instrumentedMinioClient.
+type operationObserver func(ctx context.Context, op operation)
+
+// instrumentedMinioClient decorates a MinIO client, notifying its observers of every
+// operation, such as logging it or recording metrics. Streaming operations are reported
+// once their channel is closed, with the first error received.
+type instrumentedMinioClient struct {
+	minioClientInterface
+	observers []operationObserver
+}
+
+// observe completes op with the time elapsed since start and notifies the observers.
+func (c *instrumentedMinioClient) observe(ctx context.Context, start time.Time, op operation) {
+	op.duration = time.Since(start)
+	for _, observer := range c.observers {
+		observer(ctx, op)
+	}
+}
+
+// observeStream forwards in and calls done with the first error found once the stream ends.
+// It stops forwarding when ctx is done, leaving the producer to stop on the same context.
+func observeStream[T any](ctx context.Context, in <-chan T, errOf func(T) error, done func(error)) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		var err error
+		for item := range in {
+			if itemErr := errOf(item); itemErr != nil && err == nil {
+				err = itemErr
+			}
+			select {
+			case out <- item:
+			case <-ctx.Done():
+				done(ctx.Err())
+				return
+			}
+		}
+		done(err)
+	}()
+	return out
+}
+
+func (c *instrumentedMinioClient) MakeBucket(ctx context.Context, bucketName string, opts minio.MakeBucketOptions) error {
+	start := time.Now()
+	err := c.minioClientInterface.MakeBucket(ctx, bucketName, opts)
+	c.observe(ctx, start, operation{name: "MakeBucket", bucket: bucketName, err: err})
+	return err
+}
+
+func (c *instrumentedMinioClient) ListBuckets(ctx context.Context) ([]minio.BucketInfo, error) {
+	start := time.Now()
+	buckets, err := c.minioClientInterface.ListBuckets(ctx)
+	c.observe(ctx, start, operation{name: "ListBuckets", err: err})
+	return buckets, err
+}
+
+func (c *instrumentedMinioClient) BucketExists(ctx context.Context, bucketName string) (bool, error) {
+	start := time.Now()
+	exists, err := c.minioClientInterface.BucketExists(ctx, bucketName)
+	c.observe(ctx, start, operation{name: "BucketExists", bucket: bucketName, err: err})
+	return exists, err
+}
+
+func (c *instrumentedMinioClient) RemoveBucket(ctx context.Context, bucketName string) error {
+	start := time.Now()
+	err := c.minioClientInterface.RemoveBucket(ctx, bucketName)
+	c.observe(ctx, start, operation{name: "RemoveBucket", bucket: bucketName, err: err})
+	return err
+}
+
+func (c *instrumentedMinioClient) GetBucketPolicy(ctx context.Context, bucketName string) (string, error) {
+	start := time.Now()
+	policy, err := c.minioClientInterface.GetBucketPolicy(ctx, bucketName)
+	c.observe(ctx, start, operation{name: "GetBucketPolicy", bucket: bucketName, err: err})
+	return policy, err
+}
+
+func (c *instrumentedMinioClient) SetBucketPolicy(ctx context.Context, bucketName string, policy string) error {
+	start := time.Now()
+	err := c.minioClientInterface.SetBucketPolicy(ctx, bucketName, policy)
+	c.observe(ctx, start, operation{name: "SetBucketPolicy", bucket: bucketName, err: err})
+	return err
+}
+
+func (c *instrumentedMinioClient) GetObjectLockConfig(ctx context.Context, bucketName string) (string, *minio.RetentionMode, *uint, *minio.ValidityUnit, error) {
+	start := time.Now()
+	status, mode, validity, unit, err := c.minioClientInterface.GetObjectLockConfig(ctx, bucketName)
+	c.observe(ctx, start, operation{name: "GetObjectLockConfig", bucket: bucketName, err: err})
+	return status, mode, validity, unit, err
+}
+
+func (c *instrumentedMinioClient) SetObjectLockConfig(ctx context.Context, bucketName string, mode *minio.RetentionMode, validity *uint, unit *minio.ValidityUnit) error {
+	start := time.Now()
+	err := c.minioClientInterface.SetObjectLockConfig(ctx, bucketName, mode, validity, unit)
+	c.observe(ctx, start, operation{name: "SetObjectLockConfig", bucket: bucketName, err: err})
+	return err
+}
+
+func (c *instrumentedMinioClient) GetBucketCors(ctx context.Context, bucketName string) (*cors.Config, error) {
+	start := time.Now()
+	config, err := c.minioClientInterface.GetBucketCors(ctx, bucketName)
+	c.observe(ctx, start, operation{name: "GetBucketCors", bucket: bucketName, err: err})
+	return config, err
+}
+
+func (c *instrumentedMinioClient) SetBucketCors(ctx context.Context, bucketName string, corsConfig *cors.Config) error {
+	start := time.Now()
+	err := c.minioClientInterface.SetBucketCors(ctx, bucketName, corsConfig)
+	c.observe(ctx, start, operation{name: "SetBucketCors", bucket: bucketName, err: err})
+	return err
+}
+
+func (c *instrumentedMinioClient) GetBucketLocation(ctx context.Context, bucketName string) (string, error) {
+	start := time.Now()
+	location, err := c.minioClientInterface.GetBucketLocation(ctx, bucketName)
+	c.observe(ctx, start, operation{name: "GetBucketLocation", bucket: bucketName, err: err})
+	return location, err
+}
+
+func (c *instrumentedMinioClient) GetBucketVersioning(ctx context.Context, bucketName string) (minio.BucketVersioningConfiguration, error) {
+	start := time.Now()
+	config, err := c.minioClientInterface.GetBucketVersioning(ctx, bucketName)
+	c.observe(ctx, start, operation{name: "GetBucketVersioning", bucket: bucketName, err: err})
+	return config, err
+}
+
+func (c *instrumentedMinioClient) EnableVersioning(ctx context.Context, bucketName string) error {
+	start := time.Now()
+	err := c.minioClientInterface.EnableVersioning(ctx, bucketName)
+	c.observe(ctx, start, operation{name: "EnableVersioning", bucket: bucketName, err: err})
+	return err
+}
+
+func (c *instrumentedMinioClient) SuspendVersioning(ctx context.Context, bucketName string) error {
+	start := time.Now()
+	err := c.minioClientInterface.SuspendVersioning(ctx, bucketName)
+	c.observe(ctx, start, operation{name: "SuspendVersioning", bucket: bucketName, err: err})
+	return err
+}
+
+func (c *instrumentedMinioClient) ListenBucketNotification(ctx context.Context, bucketName, prefix, suffix string, events []string) <-chan notification.Info {
+	start := time.Now()
+	in := c.minioClientInterface.ListenBucketNotification(ctx, bucketName, prefix, suffix, events)
+	return observeStream(ctx, in, func(info notification.Info) error { return info.Err }, func(err error) {
+		c.observe(ctx, start, operation{name: "ListenBucketNotification", bucket: bucketName, err: err, attrs: []slog.Attr{slog.String("prefix", prefix)}})
+	})
+}
+
+func (c *instrumentedMinioClient) PutObject(ctx context.Context, bucketName string, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (minio.UploadInfo, error) {
+	start := time.Now()
+	info, err := c.minioClientInterface.PutObject(ctx, bucketName, objectName, reader, objectSize, opts)
+	c.observe(ctx, start, operation{name: "PutObject", bucket: bucketName, key: objectName, err: err, attrs: []slog.Attr{slog.Int64("size", info.Size)}})
+	return info, err
+}
+
+// GetObject only opens the object; errors reading it are not reported.
+func (c *instrumentedMinioClient) GetObject(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (io.ReadCloser, minio.ObjectInfo, error) {
+	start := time.Now()
+	object, info, err := c.minioClientInterface.GetObject(ctx, bucketName, objectName, opts)
+	c.observe(ctx, start, operation{name: "GetObject", bucket: bucketName, key: objectName, err: err})
+	return object, info, err
+}
+
+func (c *instrumentedMinioClient) ListObjects(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+	start := time.Now()
+	in := c.minioClientInterface.ListObjects(ctx, bucketName, opts)
+	return observeStream(ctx, in, func(object minio.ObjectInfo) error { return object.Err }, func(err error) {
+		c.observe(ctx, start, operation{name: "ListObjects", bucket: bucketName, err: err, attrs: []slog.Attr{slog.String("prefix", opts.Prefix)}})
+	})
+}
+
+func (c *instrumentedMinioClient) ListIncompleteUploads(ctx context.Context, bucketName string, objectPrefix string, recursive bool) <-chan minio.ObjectMultipartInfo {
+	start := time.Now()
+	in := c.minioClientInterface.ListIncompleteUploads(ctx, bucketName, objectPrefix, recursive)
+	return observeStream(ctx, in, func(upload minio.ObjectMultipartInfo) error { return upload.Err }, func(err error) {
+		c.observe(ctx, start, operation{name: "ListIncompleteUploads", bucket: bucketName, err: err, attrs: []slog.Attr{slog.String("prefix", objectPrefix)}})
+	})
+}
+
+func (c *instrumentedMinioClient) AbortMultipartUpload(ctx context.Context, bucketName string, objectName string, uploadID string) error {
+	start := time.Now()
+	err := c.minioClientInterface.AbortMultipartUpload(ctx, bucketName, objectName, uploadID)
+	c.observe(ctx, start, operation{name: "AbortMultipartUpload", bucket: bucketName, key: objectName, err: err, attrs: []slog.Attr{slog.String("upload_id", uploadID)}})
+	return err
+}
+
+func (c *instrumentedMinioClient) GetObjectRange(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (io.ReadCloser, minio.ObjectInfo, http.Header, error) {
+	start := time.Now()
+	body, info, header, err := c.minioClientInterface.GetObjectRange(ctx, bucketName, objectName, opts)
+	c.observe(ctx, start, operation{name: "GetObjectRange", bucket: bucketName, key: objectName, err: err})
+	return body, info, header, err
+}
+
+func (c *instrumentedMinioClient) RemoveObject(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error {
+	start := time.Now()
+	err := c.minioClientInterface.RemoveObject(ctx, bucketName, objectName, opts)
+	c.observe(ctx, start, operation{name: "RemoveObject", bucket: bucketName, key: objectName, err: err})
+	return err
+}
+
+func (c *instrumentedMinioClient) RemoveObjectsWithResult(ctx context.Context, bucketName string, objectsCh <-chan minio.ObjectInfo, opts minio.RemoveObjectsOptions) <-chan minio.RemoveObjectResult {
+	start := time.Now()
+	in := c.minioClientInterface.RemoveObjectsWithResult(ctx, bucketName, objectsCh, opts)
+	return observeStream(ctx, in, func(result minio.RemoveObjectResult) error { return result.Err }, func(err error) {
+		c.observe(ctx, start, operation{name: "RemoveObjects", bucket: bucketName, err: err})
+	})
+}
+
+func (c *instrumentedMinioClient) CopyObject(ctx context.Context, dst minio.CopyDestOptions, src minio.CopySrcOptions) (minio.UploadInfo, error) {
+	start := time.Now()
+	info, err := c.minioClientInterface.CopyObject(ctx, dst, src)
+	c.observe(ctx, start, operation{name: "CopyObject", bucket: dst.Bucket, key: dst.Object, err: err,
+		attrs: []slog.Attr{slog.String("source_bucket", src.Bucket), slog.String("source_key", src.Object)}})
+	return info, err
+}
+
+func (c *instrumentedMinioClient) ComposeObject(ctx context.Context, dst minio.CopyDestOptions, srcs ...minio.CopySrcOptions) (minio.UploadInfo, error) {
+	start := time.Now()
+	info, err := c.minioClientInterface.ComposeObject(ctx, dst, srcs...)
+	c.observe(ctx, start, operation{name: "ComposeObject", bucket: dst.Bucket, key: dst.Object, err: err,
+		attrs: []slog.Attr{slog.Int("sources", len(srcs))}})
+	return info, err
+}
+
+func (c *instrumentedMinioClient) StatObject(ctx context.Context, bucketName string, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
+	start := time.Now()
+	info, err := c.minioClientInterface.StatObject(ctx, bucketName, objectName, opts)
+	c.observe(ctx, start, operation{name: "StatObject", bucket: bucketName, key: objectName, err: err})
+	return info, err
+}
+
+func (c *instrumentedMinioClient) PutObjectRetention(ctx context.Context, bucketName string, objectName string, opts minio.PutObjectRetentionOptions) error {
+	start := time.Now()
+	err := c.minioClientInterface.PutObjectRetention(ctx, bucketName, objectName, opts)
+	c.observe(ctx, start, operation{name: "PutObjectRetention", bucket: bucketName, key: objectName, err: err})
+	return err
+}
+
+func (c *instrumentedMinioClient) GetObjectRetention(ctx context.Context, bucketName string, objectName string, versionID string) (*minio.RetentionMode, *time.Time, error) {
+	start := time.Now()
+	mode, until, err := c.minioClientInterface.GetObjectRetention(ctx, bucketName, objectName, versionID)
+	c.observe(ctx, start, operation{name: "GetObjectRetention", bucket: bucketName, key: objectName, err: err})
+	return mode, until, err
+}
+
+func (c *instrumentedMinioClient) GetObjectLegalHold(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectLegalHoldOptions) (*minio.LegalHoldStatus, error) {
+	start := time.Now()
+	status, err := c.minioClientInterface.GetObjectLegalHold(ctx, bucketName, objectName, opts)
+	c.observe(ctx, start, operation{name: "GetObjectLegalHold", bucket: bucketName, key: objectName, err: err})
+	return status, err
+}
+
+func (c *instrumentedMinioClient) PresignedGetObject(ctx context.Context, bucketName string, objectName string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
+	start := time.Now()
+	u, err := c.minioClientInterface.PresignedGetObject(ctx, bucketName, objectName, expiry, reqParams)
+	c.observe(ctx, start, operation{name: "PresignedGetObject", bucket: bucketName, key: objectName, err: err, local: true})
+	return u, err
+}
+
+func (c *instrumentedMinioClient) PresignedPutObject(ctx context.Context, bucketName string, objectName string, expiry time.Duration) (*url.URL, error) {
+	start := time.Now()
+	u, err := c.minioClientInterface.PresignedPutObject(ctx, bucketName, objectName, expiry)
+	c.observe(ctx, start, operation{name: "PresignedPutObject", bucket: bucketName, key: objectName, err: err, local: true})
+	return u, err
+}
@@ -0,0 +1,143 @@
+package objectstorage
+
+import (
+	"context"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// ObjectIterator walks the objects of a bucket one at a time, so listings of any size
+// can be processed without holding them in memory.
+//
+//	it := osClient.Objects().Iter(ctx, "my-bucket", objectstorage.ObjectListOptions{})
+//	defer it.Close()
+//	for it.Next() {
+//		fmt.Println(it.Object().Key)
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type ObjectIterator struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	objects <-chan minio.ObjectInfo
+	filter  objectFilter
+	skip    int
+	limit   *int
+	current Object
+	err     error
+}
+
+// Iter returns an iterator over the objects of a bucket matching opts.
+// Offset skips the first matching objects and Limit, when set, stops after that many;
+// unlike List, there is no default limit. The listing is not bounded by the operation
+// timeout, since it advances at the caller's pace. Cancelling ctx or calling Close stops
+// the underlying listing promptly.
+func (s *objectService) Iter(ctx context.Context, bucketName string, opts ObjectListOptions) *ObjectIterator {
+	if bucketName == "" {
+		return &ObjectIterator{err: &InvalidBucketNameError{Name: bucketName}}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	it := &ObjectIterator{
+		ctx:    ctx,
+		cancel: cancel,
+		filter: objectFilter{
+			modifiedAfter:  opts.ModifiedAfter,
+			modifiedBefore: opts.ModifiedBefore,
+			minSize:        opts.MinSize,
+			maxSize:        opts.MaxSize,
+		},
+		limit: opts.Limit,
+	}
+	if opts.Offset != nil {
+		it.skip = *opts.Offset
+	}
+
+	it.objects = s.client.minioClient.ListObjects(ctx, bucketName, minio.ListObjectsOptions{
+		Prefix:    opts.Prefix,
+		Recursive: opts.Delimiter == "",
+	})
+
+	return it
+}
+
+// Next advances to the next object, returning false when the listing ends, fails or
+// its context is done. Err reports the reason once Next returned false.
+func (it *ObjectIterator) Next() bool {
+	if it.err != nil || it.objects == nil {
+		return false
+	}
+
+	if it.limit != nil && *it.limit <= 0 {
+		it.Close()
+		return false
+	}
+
+	for {
+		// Checked first, as select picks randomly when an object is also ready.
+		if err := it.ctx.Err(); err != nil {
+			it.stop(err)
+			return false
+		}
+
+		select {
+		case <-it.ctx.Done():
+			it.stop(it.ctx.Err())
+			return false
+		case object, ok := <-it.objects:
+			if !ok {
+				it.stop(it.ctx.Err())
+				return false
+			}
+			if object.Err != nil {
+				it.stop(object.Err)
+				return false
+			}
+			if !it.filter.matches(object) {
+				continue
+			}
+			if it.skip > 0 {
+				it.skip--
+				continue
+			}
+
+			if it.limit != nil {
+				remaining := *it.limit - 1
+				it.limit = &remaining
+			}
+			it.current = Object{
+				Key:          object.Key,
+				Size:         object.Size,
+				LastModified: object.LastModified,
+				ETag:         object.ETag,
+			}
+			return true
+		}
+	}
+}
+
+// Object returns the object Next advanced to.
+func (it *ObjectIterator) Object() Object {
+	return it.current
+}
+
+// Err returns the error that ended the iteration, if any.
+func (it *ObjectIterator) Err() error {
+	return it.err
+}
+
+// Close stops the underlying listing. It is safe to call more than once and is only
+// needed when stopping before Next returns false.
+func (it *ObjectIterator) Close() {
+	if it.cancel != nil {
+		it.cancel()
+	}
+	it.objects = nil
+}
+
+// stop records err and releases the listing.
+func (it *ObjectIterator) stop(err error) {
+	it.err = err
+	it.Close()
+}
@@ -0,0 +1,155 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// listObjectsFrom returns a listObjectsFunc producing n objects in order, recording when
+// the producer goroutine exits on done.
+func listObjectsFrom(n int, done chan<- struct{}) func(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+	return func(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+		ch := make(chan minio.ObjectInfo)
+		go func() {
+			defer close(ch)
+			if done != nil {
+				defer close(done)
+			}
+			for i := range n {
+				select {
+				case ch <- minio.ObjectInfo{Key: fmt.Sprintf("obj-%03d", i), Size: int64(i)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return ch
+	}
+}
+
+func collectKeys(it *ObjectIterator) []string {
+	var keys []string
+	for it.Next() {
+		keys = append(keys, it.Object().Key)
+	}
+	return keys
+}
+
+func TestObjectIterator(t *testing.T) {
+	t.Parallel()
+
+	minSize := int64(3)
+	tests := []struct {
+		name     string
+		opts     ObjectListOptions
+		wantKeys []string
+	}{
+		{name: "all objects", wantKeys: []string{"obj-000", "obj-001", "obj-002", "obj-003", "obj-004"}},
+		{name: "offset and limit", opts: ObjectListOptions{Offset: intPtr(1), Limit: intPtr(2)}, wantKeys: []string{"obj-001", "obj-002"}},
+		{name: "filtered", opts: ObjectListOptions{MinSize: &minSize}, wantKeys: []string{"obj-003", "obj-004"}},
+		{name: "zero limit", opts: ObjectListOptions{Limit: intPtr(0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mock, _ := newMockObjectService(t)
+			mock.listObjectsFunc = listObjectsFrom(5, nil)
+
+			it := svc.Iter(context.Background(), "test-bucket", tt.opts)
+			keys := collectKeys(it)
+
+			if fmt.Sprint(keys) != fmt.Sprint(tt.wantKeys) {
+				t.Errorf("Iter() keys = %v, want %v", keys, tt.wantKeys)
+			}
+			if err := it.Err(); err != nil {
+				t.Errorf("Err() = %v", err)
+			}
+		})
+	}
+}
+
+func TestObjectIterator_ListingError(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	listErr := errors.New("access denied")
+	mock.listObjectsFunc = func(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+		ch := make(chan minio.ObjectInfo, 2)
+		ch <- minio.ObjectInfo{Key: "a"}
+		ch <- minio.ObjectInfo{Err: listErr}
+		close(ch)
+		return ch
+	}
+
+	it := svc.Iter(context.Background(), "test-bucket", ObjectListOptions{})
+	if keys := collectKeys(it); len(keys) != 1 {
+		t.Errorf("Iter() keys = %v, want [a]", keys)
+	}
+	if !errors.Is(it.Err(), listErr) {
+		t.Errorf("Err() = %v, want %v", it.Err(), listErr)
+	}
+}
+
+func TestObjectIterator_StopsListing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		stop    func(it *ObjectIterator, cancel context.CancelFunc)
+		wantErr error
+	}{
+		{name: "context canceled", stop: func(_ *ObjectIterator, cancel context.CancelFunc) { cancel() }, wantErr: context.Canceled},
+		{name: "closed", stop: func(it *ObjectIterator, _ context.CancelFunc) { it.Close() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mock, _ := newMockObjectService(t)
+			done := make(chan struct{})
+			mock.listObjectsFunc = listObjectsFrom(1_000_000, done)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			it := svc.Iter(ctx, "test-bucket", ObjectListOptions{})
+			if !it.Next() {
+				t.Fatalf("Next() = false, err = %v", it.Err())
+			}
+			tt.stop(it, cancel)
+
+			if it.Next() {
+				t.Error("Next() = true after the iteration was stopped")
+			}
+			if !errors.Is(it.Err(), tt.wantErr) {
+				t.Errorf("Err() = %v, want %v", it.Err(), tt.wantErr)
+			}
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("listing goroutine did not stop")
+			}
+		})
+	}
+}
+
+func TestObjectIterator_InvalidBucket(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _ := newMockObjectService(t)
+	it := svc.Iter(context.Background(), "", ObjectListOptions{})
+
+	if it.Next() {
+		t.Error("Next() = true for an invalid bucket")
+	}
+	var bucketErr *InvalidBucketNameError
+	if !errors.As(it.Err(), &bucketErr) {
+		t.Errorf("Err() = %v, want InvalidBucketNameError", it.Err())
+	}
+	it.Close()
+}
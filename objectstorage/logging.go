@@ -0,0 +1,31 @@
+package objectstorage
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// logOperation returns an observer logging every operation with its operation, bucket,
+// key, duration and error. WithLogger installs it on the instrumentedMinioClient that
+// wraps the MinIO client, next to the metrics observer when one is configured.
+// Successful operations are logged at debug level and failed ones at warn level.
+func logOperation(logger *slog.Logger) operationObserver {
+	return func(ctx context.Context, op operation) {
+		attrs := slices.Concat(op.attrs, []slog.Attr{
+			slog.String("operation", op.name),
+			slog.String("bucket", op.bucket),
+			slog.Duration("duration", op.duration),
+		})
+		if op.key != "" {
+			attrs = append(attrs, slog.String("key", op.key))
+		}
+
+		if op.err != nil {
+			attrs = append(attrs, slog.Any("error", op.err))
+			logger.LogAttrs(ctx, slog.LevelWarn, "object storage operation failed", attrs...)
+			return
+		}
+		logger.LogAttrs(ctx, slog.LevelDebug, "object storage operation", attrs...)
+	}
+}
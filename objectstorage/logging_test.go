@@ -0,0 +1,87 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+func TestWithLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	mock := newMockMinioClient()
+	mock.buckets["test-bucket"] = &mockBucket{name: "test-bucket", creationDate: time.Now(), objects: make(map[string]*mockObject)}
+	osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := osClient.Objects().Upload(ctx, "test-bucket", "a.txt", []byte("hello"), "text/plain"); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if _, err := osClient.Objects().List(ctx, "test-bucket", ObjectListOptions{Prefix: "a"}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if _, err := osClient.Objects().Metadata(ctx, "test-bucket", "missing.txt"); err == nil {
+		t.Fatal("Metadata() error = nil for a missing object")
+	}
+
+	entries := map[string]map[string]any{}
+	for line := range strings.Lines(buf.String()) {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		entries[entry["operation"].(string)] = entry
+	}
+
+	put, ok := entries["PutObject"]
+	if !ok || put["level"] != "DEBUG" || put["bucket"] != "test-bucket" || put["key"] != "a.txt" || put["duration"] == nil {
+		t.Errorf("PutObject log = %v", put)
+	}
+	list, ok := entries["ListObjects"]
+	if !ok || list["prefix"] != "a" || list["error"] != nil {
+		t.Errorf("ListObjects log = %v", list)
+	}
+	stat, ok := entries["StatObject"]
+	if !ok || stat["level"] != "WARN" || stat["key"] != "missing.txt" || stat["error"] == nil {
+		t.Errorf("StatObject log = %v", stat)
+	}
+}
+
+func TestWithLogger_StopsWithListing(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	mock := newMockMinioClient()
+	done := make(chan struct{})
+	mock.listObjectsFunc = listObjectsFrom(1_000_000, done)
+	osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock), WithLogger(logger))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	it := osClient.Objects().Iter(context.Background(), "test-bucket", ObjectListOptions{})
+	if !it.Next() {
+		t.Fatalf("Next() = false, err = %v", it.Err())
+	}
+	it.Close()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("listing goroutine did not stop")
+	}
+}
@@ -0,0 +1,31 @@
+package objectstorage
+
+import (
+	"context"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/minio/minio-go/v7"
+)
+
+// metricsService is the service label of object storage observations.
+const metricsService = "objectstorage"
+
+// recordOperation returns an observer reporting every operation that sends a request
+// to recorder, labeled by its name, such as "PutObject". The status class comes from the
+// S3 error response, or is client.StatusClassError when no response was received.
+func recordOperation(recorder client.OperationRecorder) operationObserver {
+	return func(_ context.Context, op operation) {
+		if op.local {
+			return
+		}
+		recorder.ObserveOperation(metricsService, op.name, operationStatusClass(op.err), op.duration)
+	}
+}
+
+// operationStatusClass returns the status class of an operation that ended with err.
+func operationStatusClass(err error) string {
+	if err == nil {
+		return client.StatusClass2xx
+	}
+	return client.StatusClass(minio.ToErrorResponse(err).StatusCode)
+}
@@ -0,0 +1,110 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/minio/minio-go/v7"
+)
+
+type observation struct {
+	service, operation, statusClass string
+}
+
+type fakeRecorder struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (r *fakeRecorder) ObserveRequest(service, method, statusClass string, _ time.Duration) {}
+
+func (r *fakeRecorder) ObserveOperation(service, operation, statusClass string, _ time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observations = append(r.observations, observation{service, operation, statusClass})
+}
+
+// requestRecorder is a MetricsRecorder that does not implement client.OperationRecorder.
+type requestRecorder struct{}
+
+func (requestRecorder) ObserveRequest(service, method, statusClass string, _ time.Duration) {}
+
+func TestMetricsRecordsOperations(t *testing.T) {
+	t.Parallel()
+
+	recorder := &fakeRecorder{}
+	mock := newMockMinioClient()
+	mock.buckets["test-bucket"] = &mockBucket{name: "test-bucket", creationDate: time.Now(), objects: make(map[string]*mockObject)}
+	mock.removeBucketFunc = func(ctx context.Context, bucketName string) error {
+		return errors.New("connection reset")
+	}
+	core := client.NewMgcClient(client.WithMetrics(recorder))
+	osClient, err := New(core, "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := context.Background()
+	_ = osClient.Objects().Upload(ctx, "test-bucket", "a.txt", []byte("hello"), "text/plain")
+	_, _ = osClient.Objects().Metadata(ctx, "test-bucket", "missing.txt")
+	_ = osClient.Buckets().Delete(ctx, "test-bucket", false)
+	_, _ = osClient.Objects().GetPresignedURL(ctx, "test-bucket", "a.txt", GetPresignedURLOptions{Method: "GET"})
+
+	want := map[string]string{
+		"PutObject":    client.StatusClass2xx,
+		"StatObject":   client.StatusClass4xx,
+		"RemoveBucket": client.StatusClassError,
+	}
+	got := map[string]string{}
+	for _, o := range recorder.observations {
+		if o.service != "objectstorage" {
+			t.Errorf("observation service = %q, want objectstorage", o.service)
+		}
+		got[o.operation] = o.statusClass
+	}
+	for operation, statusClass := range want {
+		if got[operation] != statusClass {
+			t.Errorf("%s status class = %q, want %q (observations %v)", operation, got[operation], statusClass, recorder.observations)
+		}
+	}
+	if _, ok := got["PresignedGetObject"]; ok {
+		t.Errorf("presigning recorded as an operation (observations %v)", recorder.observations)
+	}
+}
+
+func TestMetricsRequiresOperationRecorder(t *testing.T) {
+	t.Parallel()
+
+	core := client.NewMgcClient(client.WithMetrics(requestRecorder{}))
+	osClient, err := New(core, "minioadmin", "minioadmin", WithMinioClientInterface(newMockMinioClient()))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, ok := osClient.minioClient.(*instrumentedMinioClient); ok {
+		t.Error("New() instrumented the client for a recorder without ObserveOperation")
+	}
+}
+
+func TestOperationStatusClass(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: nil, want: client.StatusClass2xx},
+		{err: minio.ErrorResponse{Code: "NoSuchKey", StatusCode: 404}, want: client.StatusClass4xx},
+		{err: minio.ErrorResponse{Code: "InternalError", StatusCode: 500}, want: client.StatusClass5xx},
+		{err: errors.New("dial tcp: timeout"), want: client.StatusClassError},
+	}
+
+	for _, tt := range tests {
+		if got := operationStatusClass(tt.err); got != tt.want {
+			t.Errorf("operationStatusClass(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
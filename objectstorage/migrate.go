@@ -0,0 +1,57 @@
+package objectstorage
+
+import (
+	"context"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/minio/minio-go/v7"
+)
+
+// Migrate copies an object between two clients, such as clients of different regions
+// or accounts, which a server-side Copy cannot do. The object is streamed from src to
+// dst without being buffered in memory or on disk, keeping its content type, user
+// metadata, cache control, content disposition, encoding and language.
+//
+// Objects up to the multipart threshold of dst are sent in a single request; larger
+// ones are uploaded in parts. The transfer timeout of dst bounds the whole operation.
+// Returns an ObjectNotFoundError when the source object does not exist.
+func Migrate(ctx context.Context, src *ObjectStorageClient, srcBucket string, srcKey string, dst *ObjectStorageClient, dstBucket string, dstKey string) error {
+	if src == nil {
+		return &client.ValidationError{Field: "src", Message: "client cannot be nil"}
+	}
+
+	if dst == nil {
+		return &client.ValidationError{Field: "dst", Message: "client cannot be nil"}
+	}
+
+	for _, err := range []error{
+		validateBucket(srcBucket),
+		validateObjectKey(srcKey),
+		validateBucket(dstBucket),
+		validateObjectKey(dstKey),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := dst.operationContext(ctx, dst.transferTimeout)
+	defer cancel()
+
+	object, info, err := src.minioClient.GetObject(ctx, srcBucket, srcKey, minio.GetObjectOptions{})
+	if err != nil {
+		return notFoundError(err, srcBucket, srcKey)
+	}
+	defer object.Close()
+
+	objects := &objectService{client: dst}
+	opts := objects.putOptions(info.Size, info.ContentType)
+	opts.UserMetadata = info.UserMetadata
+	opts.CacheControl = info.Metadata.Get("Cache-Control")
+	opts.ContentDisposition = info.Metadata.Get("Content-Disposition")
+	opts.ContentEncoding = info.Metadata.Get("Content-Encoding")
+	opts.ContentLanguage = info.Metadata.Get("Content-Language")
+
+	_, err = dst.minioClient.PutObject(ctx, dstBucket, dstKey, object, info.Size, opts)
+	return err
+}
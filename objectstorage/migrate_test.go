@@ -0,0 +1,127 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+// newS3Server serves a single object at /src-bucket/report.csv, as an S3 API would.
+func newS3Server(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("location") {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">br-se1</LocationConstraint>`))
+			return
+		}
+
+		if r.URL.Path != "/src-bucket/report.csv" {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Content-Disposition", "attachment")
+		w.Header().Set("X-Amz-Meta-Owner", "finance")
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	server := newS3Server(t, "id,total\n1,10\n")
+	src, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, mock, dst := newMockObjectService(t)
+
+	if err := Migrate(context.Background(), src, "src-bucket", "report.csv", dst, "test-bucket", "archive/report.csv"); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	obj, ok := mock.buckets["test-bucket"].objects["archive/report.csv"]
+	if !ok {
+		t.Fatal("Migrate() did not upload the object")
+	}
+	if string(obj.data) != "id,total\n1,10\n" {
+		t.Errorf("uploaded data = %q, want %q", obj.data, "id,total\n1,10\n")
+	}
+	if mock.lastObjectSize != int64(len(obj.data)) {
+		t.Errorf("uploaded size = %d, want %d", mock.lastObjectSize, len(obj.data))
+	}
+
+	opts := mock.lastPutOptions
+	if opts.ContentType != "text/csv" {
+		t.Errorf("ContentType = %q, want text/csv", opts.ContentType)
+	}
+	if opts.UserMetadata["Owner"] != "finance" {
+		t.Errorf("UserMetadata = %v, want Owner=finance", opts.UserMetadata)
+	}
+	if opts.CacheControl != "max-age=60" || opts.ContentDisposition != "attachment" {
+		t.Errorf("CacheControl = %q, ContentDisposition = %q", opts.CacheControl, opts.ContentDisposition)
+	}
+}
+
+func TestMigrate_SourceNotFound(t *testing.T) {
+	t.Parallel()
+
+	server := newS3Server(t, "")
+	src, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	_, _, dst := newMockObjectService(t)
+
+	err = Migrate(context.Background(), src, "src-bucket", "missing.csv", dst, "test-bucket", "missing.csv")
+
+	var notFound *ObjectNotFoundError
+	if !errors.As(err, &notFound) || notFound.Key != "missing.csv" {
+		t.Errorf("Migrate() error = %v, want ObjectNotFoundError for missing.csv", err)
+	}
+}
+
+func TestMigrate_Validation(t *testing.T) {
+	t.Parallel()
+
+	_, _, osClient := newMockObjectService(t)
+
+	tests := []struct {
+		name      string
+		src, dst  *ObjectStorageClient
+		srcBucket string
+		dstKey    string
+		wantErr   error
+	}{
+		{name: "nil source", dst: osClient, srcBucket: "test-bucket", dstKey: "key", wantErr: &client.ValidationError{}},
+		{name: "nil destination", src: osClient, srcBucket: "test-bucket", dstKey: "key", wantErr: &client.ValidationError{}},
+		{name: "invalid source bucket", src: osClient, dst: osClient, srcBucket: "", dstKey: "key", wantErr: &InvalidBucketNameError{}},
+		{name: "empty destination key", src: osClient, dst: osClient, srcBucket: "test-bucket", dstKey: "", wantErr: &InvalidObjectKeyError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Migrate(context.Background(), tt.src, tt.srcBucket, "key", tt.dst, "test-bucket", tt.dstKey)
+
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("Migrate() error = %v, want %T", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,11 +3,13 @@ package objectstorage
 import (
 	"context"
 	"io"
+	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/cors"
+	"github.com/minio/minio-go/v7/pkg/notification"
 )
 
 // minioClientInterface defines the interface for MinIO client operations
@@ -17,6 +19,7 @@ type minioClientInterface interface {
 	MakeBucket(ctx context.Context, bucketName string, opts minio.MakeBucketOptions) error
 	ListBuckets(ctx context.Context) ([]minio.BucketInfo, error)
 	BucketExists(ctx context.Context, bucketName string) (bool, error)
+	GetBucketLocation(ctx context.Context, bucketName string) (string, error)
 	RemoveBucket(ctx context.Context, bucketName string) error
 	GetBucketPolicy(ctx context.Context, bucketName string) (string, error)
 	SetBucketPolicy(ctx context.Context, bucketName string, policy string) error
@@ -27,19 +30,58 @@ type minioClientInterface interface {
 	GetBucketVersioning(ctx context.Context, bucketName string) (minio.BucketVersioningConfiguration, error)
 	EnableVersioning(ctx context.Context, bucketName string) error
 	SuspendVersioning(ctx context.Context, bucketName string) error
+	ListenBucketNotification(ctx context.Context, bucketName, prefix, suffix string, events []string) <-chan notification.Info
 
 	// Object operations
 	PutObject(ctx context.Context, bucketName string, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (minio.UploadInfo, error)
-	GetObject(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (*minio.Object, error)
+	GetObject(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (io.ReadCloser, minio.ObjectInfo, error)
+	// GetObjectRange reads an object along with its response headers, which *minio.Object
+	// does not expose. The Content-Range header carries the total size of a ranged read.
+	GetObjectRange(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (io.ReadCloser, minio.ObjectInfo, http.Header, error)
 	ListObjects(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo
 	RemoveObject(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error
+	RemoveObjectsWithResult(ctx context.Context, bucketName string, objectsCh <-chan minio.ObjectInfo, opts minio.RemoveObjectsOptions) <-chan minio.RemoveObjectResult
+	CopyObject(ctx context.Context, dst minio.CopyDestOptions, src minio.CopySrcOptions) (minio.UploadInfo, error)
+	ComposeObject(ctx context.Context, dst minio.CopyDestOptions, srcs ...minio.CopySrcOptions) (minio.UploadInfo, error)
+	ListIncompleteUploads(ctx context.Context, bucketName string, objectPrefix string, recursive bool) <-chan minio.ObjectMultipartInfo
+	// AbortMultipartUpload aborts a single multipart upload. *minio.Client only aborts
+	// every upload of an object at once, so minioAdapter goes through minio.Core for it.
+	AbortMultipartUpload(ctx context.Context, bucketName string, objectName string, uploadID string) error
 	StatObject(ctx context.Context, bucketName string, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error)
 	PutObjectRetention(ctx context.Context, bucketName string, objectName string, opts minio.PutObjectRetentionOptions) error
 	GetObjectRetention(ctx context.Context, bucketName string, objectName string, versionID string) (*minio.RetentionMode, *time.Time, error)
+	GetObjectLegalHold(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectLegalHoldOptions) (*minio.LegalHoldStatus, error)
 	SetAppInfo(appName string, appVersion string)
 	PresignedGetObject(ctx context.Context, bucketName string, objectName string, expiry time.Duration, reqParams url.Values) (*url.URL, error)
 	PresignedPutObject(ctx context.Context, bucketName string, objectName string, expiry time.Duration) (*url.URL, error)
 }
 
-// Ensure *minio.Client implements minioClientInterface
-var _ minioClientInterface = (*minio.Client)(nil)
+// Ensure minioAdapter implements minioClientInterface
+var _ minioClientInterface = minioAdapter{}
+
+// minioAdapter adapts *minio.Client to minioClientInterface. Reads return an
+// io.ReadCloser rather than a *minio.Object, which cannot be constructed outside of
+// MinIO, so GetObject and GetObjectRange share one minio.Core read path that the mock
+// reproduces with in-memory data. The operations *minio.Client lacks go through
+// minio.Core as well.
+type minioAdapter struct {
+	*minio.Client
+}
+
+// GetObject returns a reader for an object along with its information. Unlike
+// *minio.Client, the request is sent before returning, so a missing object or a
+// failed precondition is reported here rather than on the first read.
+func (a minioAdapter) GetObject(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (io.ReadCloser, minio.ObjectInfo, error) {
+	body, info, _, err := a.GetObjectRange(ctx, bucketName, objectName, opts)
+	return body, info, err
+}
+
+// AbortMultipartUpload aborts the multipart upload uploadID of an object.
+func (a minioAdapter) AbortMultipartUpload(ctx context.Context, bucketName string, objectName string, uploadID string) error {
+	return minio.Core{Client: a.Client}.AbortMultipartUpload(ctx, bucketName, objectName, uploadID)
+}
+
+// GetObjectRange reads an object with its response headers.
+func (a minioAdapter) GetObjectRange(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (io.ReadCloser, minio.ObjectInfo, http.Header, error) {
+	return minio.Core{Client: a.Client}.GetObject(ctx, bucketName, objectName, opts)
+}
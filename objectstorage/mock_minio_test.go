@@ -1,13 +1,20 @@
 package objectstorage
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/cors"
+	"github.com/minio/minio-go/v7/pkg/notification"
 )
 
 // mockMinioClient is a mock implementation of the MinIO client for testing
@@ -27,28 +34,39 @@ type mockMinioClient struct {
 	getVersioningFunc      func(ctx context.Context, bucketName string) (minio.BucketVersioningConfiguration, error)
 	enableVersioningFunc   func(ctx context.Context, bucketName string) error
 	suspendVersioningFunc  func(ctx context.Context, bucketName string) error
+	listenNotificationFunc func(ctx context.Context, bucketName, prefix, suffix string, events []string) <-chan notification.Info
 	putObjectFunc          func(ctx context.Context, bucketName string, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (minio.UploadInfo, error)
-	getObjectFunc          func(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (*minio.Object, error)
+	getObjectFunc          func(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (io.ReadCloser, minio.ObjectInfo, error)
 	listObjectsFunc        func(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo
 	removeObjectFunc       func(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error
+	removeObjectsFunc      func(ctx context.Context, bucketName string, objectsCh <-chan minio.ObjectInfo, opts minio.RemoveObjectsOptions) <-chan minio.RemoveObjectResult
+	copyObjectFunc         func(ctx context.Context, dst minio.CopyDestOptions, src minio.CopySrcOptions) (minio.UploadInfo, error)
+	composeObjectFunc      func(ctx context.Context, dst minio.CopyDestOptions, srcs ...minio.CopySrcOptions) (minio.UploadInfo, error)
 	statObjectFunc         func(ctx context.Context, bucketName string, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error)
 	putObjectRetentionFunc func(ctx context.Context, bucketName string, objectName string, opts minio.PutObjectRetentionOptions) error
+	getLegalHoldFunc       func(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectLegalHoldOptions) (*minio.LegalHoldStatus, error)
 	getObjectRetentionFunc func(ctx context.Context, bucketName string, objectName string, versionID string) (*minio.RetentionMode, *time.Time, error)
 	presignedGetObjectFunc func(ctx context.Context, bucketName string, objectName string, expiry time.Duration, reqParams url.Values) (*url.URL, error)
+	abortUploadFunc        func(ctx context.Context, bucketName string, objectName string, uploadID string) error
 	presignedPutObjectFunc func(ctx context.Context, bucketName string, objectName string, expiry time.Duration) (*url.URL, error)
 	setAppInfoCalls        int
 	lastAppName            string
 	lastAppVersion         string
+	lastContentType        string
+	lastPutOptions         minio.PutObjectOptions
+	lastObjectSize         int64
 }
 
 type mockBucket struct {
 	name         string
 	creationDate time.Time
+	location     string
 	policy       string
 	corsConfig   *cors.Config
 	versioning   minio.BucketVersioningConfiguration
 	lockConfig   *mockLockConfig
 	objects      map[string]*mockObject
+	uploads      []minio.ObjectMultipartInfo
 }
 
 type mockLockConfig struct {
@@ -64,6 +82,8 @@ type mockObject struct {
 	lastModified time.Time
 	etag         string
 	contentType  string
+	userMetadata map[string]string
+	metadata     http.Header
 	data         []byte
 	retention    *mockObjectRetention
 }
@@ -120,6 +140,18 @@ func (m *mockMinioClient) BucketExists(ctx context.Context, bucketName string) (
 	return exists, nil
 }
 
+// GetBucketLocation mocks the MinIO GetBucketLocation method, reporting br-se1 by default
+func (m *mockMinioClient) GetBucketLocation(ctx context.Context, bucketName string) (string, error) {
+	bucket, exists := m.buckets[bucketName]
+	if !exists {
+		return "", minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound, BucketName: bucketName}
+	}
+	if bucket.location == "" {
+		return "br-se1", nil
+	}
+	return bucket.location, nil
+}
+
 // RemoveBucket mocks the MinIO RemoveBucket method
 func (m *mockMinioClient) RemoveBucket(ctx context.Context, bucketName string) error {
 	if m.removeBucketFunc != nil {
@@ -269,17 +301,33 @@ func (m *mockMinioClient) PutObject(ctx context.Context, bucketName string, obje
 		return m.putObjectFunc(ctx, bucketName, objectName, reader, objectSize, opts)
 	}
 
+	m.lastContentType = opts.ContentType
+	m.lastPutOptions = opts
+	m.lastObjectSize = objectSize
+
 	bucket, exists := m.buckets[bucketName]
 	if !exists {
 		return minio.UploadInfo{}, nil
 	}
 
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return minio.UploadInfo{}, err
+	}
+
+	if objectSize < 0 {
+		objectSize = int64(len(data))
+	}
+
 	bucket.objects[objectName] = &mockObject{
 		key:          objectName,
 		size:         objectSize,
 		lastModified: time.Now(),
 		etag:         "mock-etag",
 		contentType:  opts.ContentType,
+		userMetadata: mockUserMetadata(opts.UserMetadata),
+		metadata:     opts.Header(),
+		data:         data,
 	}
 
 	return minio.UploadInfo{
@@ -290,14 +338,123 @@ func (m *mockMinioClient) PutObject(ctx context.Context, bucketName string, obje
 	}, nil
 }
 
-// GetObject mocks the MinIO GetObject method
-func (m *mockMinioClient) GetObject(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (*minio.Object, error) {
+// mockUserMetadata returns user metadata as MinIO reports it in ObjectInfo,
+// with canonical keys stripped of the x-amz-meta- prefix.
+func mockUserMetadata(metadata map[string]string) map[string]string {
+	if len(metadata) == 0 {
+		return nil
+	}
+	stored := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		k = http.CanonicalHeaderKey(k)
+		stored[strings.TrimPrefix(k, "X-Amz-Meta-")] = v
+	}
+	return stored
+}
+
+// GetObject mocks the MinIO GetObject method, serving the in-memory object data
+func (m *mockMinioClient) GetObject(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (io.ReadCloser, minio.ObjectInfo, error) {
 	if m.getObjectFunc != nil {
 		return m.getObjectFunc(ctx, bucketName, objectName, opts)
 	}
 
-	// Return nil for mock - actual object reading would need more complex mocking
-	return nil, nil
+	body, info, _, err := m.GetObjectRange(ctx, bucketName, objectName, opts)
+	return body, info, err
+}
+
+// GetObjectRange mocks minio.Core GetObject.
+func (m *mockMinioClient) GetObjectRange(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (io.ReadCloser, minio.ObjectInfo, http.Header, error) {
+	body, info, header, err := m.readObject(bucketName, objectName, opts)
+	if err != nil {
+		return nil, minio.ObjectInfo{}, nil, err
+	}
+	return io.NopCloser(body), info, header, nil
+}
+
+// readObject serves the in-memory data of an object, limited to the Range header of opts.
+func (m *mockMinioClient) readObject(bucketName string, objectName string, opts minio.GetObjectOptions) (*bytes.Reader, minio.ObjectInfo, http.Header, error) {
+	bucket, exists := m.buckets[bucketName]
+	if !exists {
+		return nil, minio.ObjectInfo{}, nil, minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound, BucketName: bucketName}
+	}
+	obj, exists := bucket.objects[objectName]
+	if !exists {
+		return nil, minio.ObjectInfo{}, nil, minio.ErrorResponse{Code: "NoSuchKey", StatusCode: http.StatusNotFound, BucketName: bucketName, Key: objectName}
+	}
+
+	data := obj.data
+	header := make(http.Header)
+	if spec, ok := strings.CutPrefix(opts.Header().Get("Range"), "bytes="); ok {
+		first, last, _ := strings.Cut(spec, "-")
+		start, _ := strconv.ParseInt(first, 10, 64)
+		end := int64(len(data)) - 1
+		if last != "" {
+			n, _ := strconv.ParseInt(last, 10, 64)
+			end = min(n, end)
+		}
+		if start > end {
+			return nil, minio.ObjectInfo{}, nil, minio.ErrorResponse{Code: "InvalidRange", StatusCode: http.StatusRequestedRangeNotSatisfiable, BucketName: bucketName, Key: objectName}
+		}
+		header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(data)))
+		data = data[start : end+1]
+	}
+
+	info := minio.ObjectInfo{
+		Key:          obj.key,
+		Size:         int64(len(data)),
+		ETag:         obj.etag,
+		ContentType:  obj.contentType,
+		LastModified: obj.lastModified,
+		UserMetadata: obj.userMetadata,
+		Metadata:     obj.metadata,
+	}
+	return bytes.NewReader(data), info, header, nil
+}
+
+// ListIncompleteUploads mocks the MinIO ListIncompleteUploads method
+func (m *mockMinioClient) ListIncompleteUploads(ctx context.Context, bucketName string, objectPrefix string, recursive bool) <-chan minio.ObjectMultipartInfo {
+	ch := make(chan minio.ObjectMultipartInfo)
+	go func() {
+		defer close(ch)
+		bucket, exists := m.buckets[bucketName]
+		if !exists {
+			ch <- minio.ObjectMultipartInfo{Err: minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound, BucketName: bucketName}}
+			return
+		}
+
+		for _, upload := range bucket.uploads {
+			if !strings.HasPrefix(upload.Key, objectPrefix) {
+				continue
+			}
+			select {
+			case ch <- upload:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// AbortMultipartUpload mocks minio.Core AbortMultipartUpload
+func (m *mockMinioClient) AbortMultipartUpload(ctx context.Context, bucketName string, objectName string, uploadID string) error {
+	if m.abortUploadFunc != nil {
+		return m.abortUploadFunc(ctx, bucketName, objectName, uploadID)
+	}
+
+	bucket, exists := m.buckets[bucketName]
+	if !exists {
+		return minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound, BucketName: bucketName}
+	}
+
+	for i, upload := range bucket.uploads {
+		if upload.Key == objectName && upload.UploadID == uploadID {
+			bucket.uploads = slices.Delete(bucket.uploads, i, i+1)
+			return nil
+		}
+	}
+
+	return minio.ErrorResponse{Code: "NoSuchUpload", StatusCode: http.StatusNotFound, BucketName: bucketName, Key: objectName}
 }
 
 // ListObjects mocks the MinIO ListObjects method
@@ -315,13 +472,26 @@ func (m *mockMinioClient) ListObjects(ctx context.Context, bucketName string, op
 		}
 
 		for _, obj := range bucket.objects {
-			ch <- minio.ObjectInfo{
+			if ctx.Err() != nil {
+				return
+			}
+			if !strings.HasPrefix(obj.key, opts.Prefix) {
+				continue
+			}
+
+			info := minio.ObjectInfo{
 				Key:          obj.key,
 				Size:         obj.size,
 				LastModified: obj.lastModified,
 				ETag:         obj.etag,
 				ContentType:  obj.contentType,
 			}
+
+			select {
+			case ch <- info:
+			case <-ctx.Done():
+				return
+			}
 		}
 	}()
 	return ch
@@ -341,6 +511,98 @@ func (m *mockMinioClient) RemoveObject(ctx context.Context, bucketName string, o
 	return nil
 }
 
+// ListenBucketNotification mocks the MinIO ListenBucketNotification method
+func (m *mockMinioClient) ListenBucketNotification(ctx context.Context, bucketName, prefix, suffix string, events []string) <-chan notification.Info {
+	if m.listenNotificationFunc != nil {
+		return m.listenNotificationFunc(ctx, bucketName, prefix, suffix, events)
+	}
+
+	infoCh := make(chan notification.Info)
+	close(infoCh)
+	return infoCh
+}
+
+// RemoveObjectsWithResult mocks the MinIO RemoveObjectsWithResult method by removing each
+// object in turn and reporting the result of every removal
+func (m *mockMinioClient) RemoveObjectsWithResult(ctx context.Context, bucketName string, objectsCh <-chan minio.ObjectInfo, opts minio.RemoveObjectsOptions) <-chan minio.RemoveObjectResult {
+	if m.removeObjectsFunc != nil {
+		return m.removeObjectsFunc(ctx, bucketName, objectsCh, opts)
+	}
+
+	resultCh := make(chan minio.RemoveObjectResult)
+	go func() {
+		defer close(resultCh)
+		for object := range objectsCh {
+			err := m.RemoveObject(ctx, bucketName, object.Key, minio.RemoveObjectOptions{})
+			resultCh <- minio.RemoveObjectResult{ObjectName: object.Key, Err: err}
+		}
+	}()
+	return resultCh
+}
+
+// CopyObject mocks the MinIO CopyObject method
+func (m *mockMinioClient) CopyObject(ctx context.Context, dst minio.CopyDestOptions, src minio.CopySrcOptions) (minio.UploadInfo, error) {
+	if m.copyObjectFunc != nil {
+		return m.copyObjectFunc(ctx, dst, src)
+	}
+
+	srcBucket, exists := m.buckets[src.Bucket]
+	if !exists {
+		return minio.UploadInfo{}, minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound, BucketName: src.Bucket}
+	}
+	obj, exists := srcBucket.objects[src.Object]
+	if !exists {
+		return minio.UploadInfo{}, minio.ErrorResponse{Code: "NoSuchKey", StatusCode: http.StatusNotFound, BucketName: src.Bucket, Key: src.Object}
+	}
+	dstBucket, exists := m.buckets[dst.Bucket]
+	if !exists {
+		return minio.UploadInfo{}, minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound, BucketName: dst.Bucket}
+	}
+
+	copied := *obj
+	copied.key = dst.Object
+	dstBucket.objects[dst.Object] = &copied
+	return minio.UploadInfo{Bucket: dst.Bucket, Key: dst.Object, ETag: obj.etag, Size: obj.size}, nil
+}
+
+// ComposeObject mocks the MinIO ComposeObject method by concatenating the source data
+func (m *mockMinioClient) ComposeObject(ctx context.Context, dst minio.CopyDestOptions, srcs ...minio.CopySrcOptions) (minio.UploadInfo, error) {
+	if m.composeObjectFunc != nil {
+		return m.composeObjectFunc(ctx, dst, srcs...)
+	}
+
+	var data []byte
+	for i, src := range srcs {
+		srcBucket, exists := m.buckets[src.Bucket]
+		if !exists {
+			return minio.UploadInfo{}, minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound, BucketName: src.Bucket}
+		}
+		obj, exists := srcBucket.objects[src.Object]
+		if !exists {
+			return minio.UploadInfo{}, minio.ErrorResponse{Code: "NoSuchKey", StatusCode: http.StatusNotFound, BucketName: src.Bucket, Key: src.Object}
+		}
+		part := obj.data
+		if src.MatchRange {
+			part = part[src.Start : src.End+1]
+		}
+		if len(part) < MinPartSize && i < len(srcs)-1 {
+			return minio.UploadInfo{}, minio.ErrorResponse{
+				Code:       minio.InvalidArgument,
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("CopySrcOptions %d is too small (%d) and it is not the last part", i, len(part)),
+			}
+		}
+		data = append(data, part...)
+	}
+
+	dstBucket, exists := m.buckets[dst.Bucket]
+	if !exists {
+		return minio.UploadInfo{}, minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound, BucketName: dst.Bucket}
+	}
+	dstBucket.objects[dst.Object] = &mockObject{key: dst.Object, data: data, size: int64(len(data)), etag: "composed-etag"}
+	return minio.UploadInfo{Bucket: dst.Bucket, Key: dst.Object, ETag: "composed-etag", Size: int64(len(data))}, nil
+}
+
 // StatObject mocks the MinIO StatObject method
 func (m *mockMinioClient) StatObject(ctx context.Context, bucketName string, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
 	if m.statObjectFunc != nil {
@@ -349,12 +611,21 @@ func (m *mockMinioClient) StatObject(ctx context.Context, bucketName string, obj
 
 	bucket, exists := m.buckets[bucketName]
 	if !exists {
-		return minio.ObjectInfo{}, nil
+		return minio.ObjectInfo{}, minio.ErrorResponse{
+			Code:       "NoSuchBucket",
+			StatusCode: http.StatusNotFound,
+			BucketName: bucketName,
+		}
 	}
 
 	obj, exists := bucket.objects[objectName]
 	if !exists {
-		return minio.ObjectInfo{}, nil
+		return minio.ObjectInfo{}, minio.ErrorResponse{
+			Code:       "NoSuchKey",
+			StatusCode: http.StatusNotFound,
+			BucketName: bucketName,
+			Key:        objectName,
+		}
 	}
 
 	return minio.ObjectInfo{
@@ -363,6 +634,8 @@ func (m *mockMinioClient) StatObject(ctx context.Context, bucketName string, obj
 		LastModified: obj.lastModified,
 		ETag:         obj.etag,
 		ContentType:  obj.contentType,
+		UserMetadata: obj.userMetadata,
+		Metadata:     obj.metadata,
 	}, nil
 }
 
@@ -408,6 +681,14 @@ func (m *mockMinioClient) GetObjectRetention(ctx context.Context, bucketName str
 	return obj.retention.mode, obj.retention.retainUntilDate, nil
 }
 
+func (m *mockMinioClient) GetObjectLegalHold(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectLegalHoldOptions) (*minio.LegalHoldStatus, error) {
+	if m.getLegalHoldFunc != nil {
+		return m.getLegalHoldFunc(ctx, bucketName, objectName, opts)
+	}
+
+	return nil, nil
+}
+
 func (m *mockMinioClient) PresignedGetObject(ctx context.Context, bucketName string, objectName string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
 	if m.presignedGetObjectFunc != nil {
 		return m.presignedGetObjectFunc(ctx, bucketName, objectName, expiry, reqParams)
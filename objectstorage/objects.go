@@ -3,11 +3,24 @@ package objectstorage
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"maps"
+	"mime"
 	"net/http"
 	"net/url"
+	"path"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/retry"
 	"github.com/minio/minio-go/v7"
 )
 
@@ -15,17 +28,35 @@ import (
 type ObjectService interface {
 	Upload(ctx context.Context, bucketName string, objectKey string, data []byte, contentType string) error
 	UploadStream(ctx context.Context, bucketName string, objectKey string, data io.Reader, size int64, contentType string) error
+	UploadFromReader(ctx context.Context, bucketName string, objectKey string, data io.Reader, opts StreamOptions) (*UploadInfo, error)
 	Download(ctx context.Context, bucketName string, objectKey string, opts *DownloadOptions) ([]byte, error)
 	DownloadStream(ctx context.Context, bucketName string, objectKey string, opts *DownloadStreamOptions) (io.Reader, error)
+	DownloadRange(ctx context.Context, bucketName string, objectKey string, start int64, end int64, w io.Writer) (ObjectMetadata, error)
+	GetAfterPut(ctx context.Context, bucketName string, objectKey string, etag string) (io.Reader, error)
 	List(ctx context.Context, bucketName string, opts ObjectListOptions) ([]Object, error)
 	ListAll(ctx context.Context, bucketName string, opts ObjectFilterOptions) ([]Object, error)
+	Iter(ctx context.Context, bucketName string, opts ObjectListOptions) *ObjectIterator
+	LargestObjects(ctx context.Context, bucketName string, prefix string, n int) ([]Object, error)
 	ListVersions(ctx context.Context, bucketName string, objectKey string, opts *ListVersionsOptions) ([]ObjectVersion, error)
 	Delete(ctx context.Context, bucketName string, objectKey string, opts *DeleteOptions) error
+	RemoveObjectsStream(ctx context.Context, bucketName string, objectKeys <-chan string) <-chan ObjectError
+	RemovePrefix(ctx context.Context, bucketName string, prefix string) (int, []ObjectError, error)
+	PreviewRemovePrefix(ctx context.Context, bucketName string, prefix string) (*PrefixRemovalPreview, error)
+	ListIncompleteUploads(ctx context.Context, bucketName string, prefix string) ([]IncompleteUpload, error)
+	AbortIncompleteUpload(ctx context.Context, bucketName string, objectKey string, uploadID string) error
+	AbortUploadsOlderThan(ctx context.Context, bucketName string, age time.Duration) (int, error)
+	Copy(ctx context.Context, srcBucket string, srcKey string, dstBucket string, dstKey string, opts CopyOptions) error
+	Move(ctx context.Context, srcBucket string, srcKey string, dstBucket string, dstKey string, opts MoveOptions) error
+	Compose(ctx context.Context, dstBucket string, dstKey string, sources []ComposeSource) (*UploadInfo, error)
 	Metadata(ctx context.Context, bucketName string, objectKey string) (*Object, error)
+	Stat(ctx context.Context, bucketName string, objectKey string) (*ObjectMetadata, error)
 	LockObject(ctx context.Context, bucketName string, objectKey string, retainUntilDate time.Time) error
 	UnlockObject(ctx context.Context, bucketName string, objectKey string) error
 	GetObjectLockStatus(ctx context.Context, bucketName string, objectKey string) (bool, error)
+	GetRetention(ctx context.Context, bucketName string, objectKey string) (*RetentionInfo, error)
 	GetPresignedURL(ctx context.Context, bucketName string, objectKey string, opts GetPresignedURLOptions) (*PresignedURL, error)
+	GetPresignedURLs(ctx context.Context, bucketName string, objectKeys []string, opts GetPresignedURLOptions) (map[string]*PresignedURL, []error)
+	RefreshPresignedURL(ctx context.Context, oldURL *url.URL, method string, newExpiry time.Duration) (*url.URL, error)
 }
 
 // objectService implements the ObjectService interface.
@@ -47,6 +78,120 @@ func validateObjectKey(key string) error {
 	return nil
 }
 
+// userMetadataPrefix is the header prefix of user-defined object metadata.
+const userMetadataPrefix = "x-amz-meta-"
+
+// validateUserMetadata checks that user metadata keys are HTTP header names that MinIO sends
+// as x-amz-meta-* headers. Keys naming other headers, which MinIO would send as is, are rejected.
+func validateUserMetadata(metadata map[string]string) error {
+	for _, key := range slices.Sorted(maps.Keys(metadata)) {
+		name := strings.ToLower(key)
+		if !isHeaderToken(name) {
+			return &client.ValidationError{Field: "user_metadata", Message: fmt.Sprintf("key %q is not a valid header name", key)}
+		}
+		if strings.HasPrefix(name, userMetadataPrefix) {
+			if name == userMetadataPrefix {
+				return &client.ValidationError{Field: "user_metadata", Message: fmt.Sprintf("key %q is empty after the prefix", key)}
+			}
+			continue
+		}
+		if strings.HasPrefix(name, "x-amz-") || strings.HasPrefix(name, "x-minio-") ||
+			strings.HasPrefix(name, "content-") || name == "cache-control" || name == "expires" {
+			return &client.ValidationError{Field: "user_metadata", Message: fmt.Sprintf("key %q is a reserved header", key)}
+		}
+	}
+	return nil
+}
+
+// validateCacheControl checks that value is a comma-separated list of Cache-Control
+// directives, each a token optionally followed by "=" and a token or quoted string.
+func validateCacheControl(value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, directive := range splitDirectives(value) {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(directive), "=")
+		valid := isHeaderToken(name)
+		if valid && hasArg {
+			valid = isQuotedString(arg) || isHeaderToken(arg)
+		}
+		if !valid {
+			return &client.ValidationError{Field: "cache_control", Message: fmt.Sprintf("invalid directive %q", strings.TrimSpace(directive))}
+		}
+	}
+	return nil
+}
+
+// splitDirectives splits a header value on the commas that are not inside quoted strings,
+// so that quoted arguments such as private="Set-Cookie, X-Foo" stay in one directive.
+func splitDirectives(value string) []string {
+	var directives []string
+	var quoted, escaped bool
+	start := 0
+	for i, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			directives = append(directives, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(directives, value[start:])
+}
+
+// isQuotedString reports whether s is a quoted string as defined by RFC 9110,
+// with any inner quote or backslash escaped by a backslash.
+func isQuotedString(s string) bool {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return false
+	}
+	inner := s[1 : len(s)-1]
+	for i := 0; i < len(inner); i++ {
+		switch inner[i] {
+		case '\\':
+			i++
+			if i == len(inner) {
+				return false
+			}
+		case '"':
+			return false
+		}
+	}
+	return true
+}
+
+// validateContentDisposition checks that value is a disposition type with optional parameters.
+func validateContentDisposition(value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, _, err := mime.ParseMediaType(value); err != nil {
+		return &client.ValidationError{Field: "content_disposition", Message: err.Error()}
+	}
+	return nil
+}
+
+// isHeaderToken reports whether s is a valid HTTP header name, a token as defined by RFC 9110.
+func isHeaderToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // Upload uploads an object to a bucket.
 func (s *objectService) Upload(ctx context.Context, bucketName string, objectKey string, data []byte, contentType string) error {
 	if bucketName == "" {
@@ -61,9 +206,14 @@ func (s *objectService) Upload(ctx context.Context, bucketName string, objectKey
 		return &InvalidObjectDataError{Message: "object data cannot be empty"}
 	}
 
-	_, err := s.client.minioClient.PutObject(ctx, bucketName, objectKey, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
-		ContentType: contentType,
-	})
+	if contentType == "" {
+		contentType = detectContentType(objectKey, data)
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.transferTimeout)
+	defer cancel()
+
+	_, err := s.client.minioClient.PutObject(ctx, bucketName, objectKey, bytes.NewReader(data), int64(len(data)), s.putOptions(int64(len(data)), contentType))
 
 	return err
 }
@@ -82,13 +232,173 @@ func (s *objectService) UploadStream(ctx context.Context, bucketName string, obj
 		return &InvalidObjectDataError{Message: "object size cannot be zero"}
 	}
 
-	_, err := s.client.minioClient.PutObject(ctx, bucketName, objectKey, data, size, minio.PutObjectOptions{
-		ContentType: contentType,
-	})
+	contentType, data, err := detectStreamContentType(objectKey, contentType, data)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.transferTimeout)
+	defer cancel()
+
+	_, err = s.client.minioClient.PutObject(ctx, bucketName, objectKey, data, size, s.putOptions(size, contentType))
 
 	return err
 }
 
+// Multipart constraints of uploads.
+const (
+	// MinPartSize is the smallest part accepted by multipart uploads, except for the last part.
+	MinPartSize = 5 * 1024 * 1024
+	// DefaultStreamPartSize is the part size used by UploadFromReader when none is given.
+	DefaultStreamPartSize = 16 * 1024 * 1024
+	// MaxPartCount is the maximum number of parts of a multipart upload.
+	MaxPartCount = 10000
+	// DefaultMultipartThreshold is the object size from which uploads use multipart by default.
+	DefaultMultipartThreshold = 64 * 1024 * 1024
+	// MaxSinglePutSize is the largest object that can be uploaded in a single request.
+	MaxSinglePutSize = 5 * 1024 * 1024 * 1024
+)
+
+// putOptions returns the options to upload an object of the given size, choosing
+// a single request below the configured multipart threshold and multipart above it.
+// Content-MD5 is sent when UploadOptions.SendContentMD5 is set.
+func (s *objectService) putOptions(size int64, contentType string) minio.PutObjectOptions {
+	threshold := s.client.uploadOptions.MultipartThreshold
+	if threshold == 0 {
+		threshold = DefaultMultipartThreshold
+	}
+
+	opts := minio.PutObjectOptions{ContentType: contentType, SendContentMd5: s.client.uploadOptions.SendContentMD5}
+	if size < threshold {
+		opts.DisableMultipart = true
+	} else {
+		opts.PartSize = uint64(s.client.uploadOptions.PartSize)
+	}
+
+	return opts
+}
+
+// UploadFromReader uploads an object from a reader whose length is not known in advance,
+// such as a pipe or the output of a process. The data is sent as a multipart upload,
+// buffering one part of opts.PartSize bytes at a time, until the reader returns io.EOF.
+// The object can be at most PartSize * MaxPartCount bytes long. Keys of opts.UserMetadata
+// that are not valid header names or are reserved headers, and malformed CacheControl or
+// ContentDisposition values, return a client.ValidationError. Each part is sent with its
+// Content-MD5 when UploadOptions.SendContentMD5 is set.
+func (s *objectService) UploadFromReader(ctx context.Context, bucketName string, objectKey string, data io.Reader, opts StreamOptions) (*UploadInfo, error) {
+	if err := validateBucket(bucketName); err != nil {
+		return nil, err
+	}
+
+	if err := validateObjectKey(objectKey); err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return nil, &InvalidObjectDataError{Message: "reader cannot be nil"}
+	}
+
+	if err := validateUserMetadata(opts.UserMetadata); err != nil {
+		return nil, err
+	}
+
+	if err := validateCacheControl(opts.CacheControl); err != nil {
+		return nil, err
+	}
+
+	if err := validateContentDisposition(opts.ContentDisposition); err != nil {
+		return nil, err
+	}
+
+	partSize := opts.PartSize
+	if partSize == 0 {
+		partSize = uint64(s.client.uploadOptions.PartSize)
+	}
+	if partSize == 0 {
+		partSize = DefaultStreamPartSize
+	}
+	if partSize < MinPartSize {
+		return nil, &InvalidObjectDataError{Message: fmt.Sprintf("part size must be at least %d bytes", MinPartSize)}
+	}
+
+	contentType, data, err := detectStreamContentType(objectKey, opts.ContentType, data)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.transferTimeout)
+	defer cancel()
+
+	info, err := s.client.minioClient.PutObject(ctx, bucketName, objectKey, data, -1, minio.PutObjectOptions{
+		ContentType:        contentType,
+		PartSize:           partSize,
+		UserMetadata:       opts.UserMetadata,
+		CacheControl:       opts.CacheControl,
+		ContentDisposition: opts.ContentDisposition,
+		SendContentMd5:     s.client.uploadOptions.SendContentMD5,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &UploadInfo{
+		Bucket:    info.Bucket,
+		Key:       info.Key,
+		ETag:      info.ETag,
+		Size:      info.Size,
+		VersionID: info.VersionID,
+	}, nil
+}
+
+// detectStreamContentType resolves the content type of a streamed object. When it is not
+// given and cannot be derived from the key extension, the leading bytes are sniffed and
+// stitched back in front of the remaining data, which is returned in place of the original reader.
+func detectStreamContentType(objectKey string, contentType string, data io.Reader) (string, io.Reader, error) {
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(objectKey))
+	}
+
+	if contentType != "" {
+		return contentType, data, nil
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(data, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, err
+	}
+	head = head[:n]
+	contentType = http.DetectContentType(head)
+
+	// Rewind seekable readers past the sniffed bytes so the upload starts from the beginning
+	// and keeps the reader seekable, instead of stitching the bytes back in front of it
+	if seeker, ok := data.(io.Seeker); ok {
+		if _, err := seeker.Seek(int64(-n), io.SeekCurrent); err != nil {
+			return "", nil, err
+		}
+		return contentType, data, nil
+	}
+
+	return contentType, io.MultiReader(bytes.NewReader(head), data), nil
+}
+
+// sniffLen is the number of bytes considered by http.DetectContentType.
+const sniffLen = 512
+
+// detectContentType resolves the content type of an object from its key extension,
+// falling back to sniffing the leading bytes of its data.
+func detectContentType(objectKey string, data []byte) string {
+	if contentType := mime.TypeByExtension(path.Ext(objectKey)); contentType != "" {
+		return contentType
+	}
+
+	if len(data) > sniffLen {
+		data = data[:sniffLen]
+	}
+
+	return http.DetectContentType(data)
+}
+
 // Download retrieves an object from a bucket and returns its content as bytes.
 func (s *objectService) Download(ctx context.Context, bucketName string, objectKey string, opts *DownloadOptions) ([]byte, error) {
 	if bucketName == "" {
@@ -104,7 +414,10 @@ func (s *objectService) Download(ctx context.Context, bucketName string, objectK
 		getOpts.VersionID = opts.VersionID
 	}
 
-	object, err := s.client.minioClient.GetObject(ctx, bucketName, objectKey, getOpts)
+	ctx, cancel := s.client.operationContext(ctx, s.client.transferTimeout)
+	defer cancel()
+
+	object, _, err := s.client.minioClient.GetObject(ctx, bucketName, objectKey, getOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +432,8 @@ func (s *objectService) Download(ctx context.Context, bucketName string, objectK
 }
 
 // DownloadStream retrieves an object from a bucket and returns a reader for streaming.
+// The operation timeouts do not apply, since the reader outlives the call. Errors such
+// as a missing object are returned by the call rather than by the first read.
 func (s *objectService) DownloadStream(ctx context.Context, bucketName string, objectKey string, opts *DownloadStreamOptions) (io.Reader, error) {
 	if bucketName == "" {
 		return nil, &InvalidBucketNameError{Name: bucketName}
@@ -133,7 +448,134 @@ func (s *objectService) DownloadStream(ctx context.Context, bucketName string, o
 		getOpts.VersionID = opts.VersionID
 	}
 
-	object, err := s.client.minioClient.GetObject(ctx, bucketName, objectKey, getOpts)
+	object, _, err := s.client.minioClient.GetObject(ctx, bucketName, objectKey, getOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// DownloadRange writes the bytes from start to end of an object, both inclusive, to w,
+// such as an http.ResponseWriter serving partial content. An end of -1 reads to the end
+// of the object. The returned metadata reports the total size of the object, not the
+// size of the range. Returns an ObjectNotFoundError when the object does not exist.
+func (s *objectService) DownloadRange(ctx context.Context, bucketName string, objectKey string, start int64, end int64, w io.Writer) (ObjectMetadata, error) {
+	if err := validateBucket(bucketName); err != nil {
+		return ObjectMetadata{}, err
+	}
+
+	if err := validateObjectKey(objectKey); err != nil {
+		return ObjectMetadata{}, err
+	}
+
+	if start < 0 || end < -1 || (end != -1 && end < start) {
+		return ObjectMetadata{}, &client.ValidationError{
+			Field:   "range",
+			Message: fmt.Sprintf("invalid range %d-%d", start, end),
+		}
+	}
+
+	getOpts := minio.GetObjectOptions{}
+	switch {
+	case end != -1:
+		if err := getOpts.SetRange(start, end); err != nil {
+			return ObjectMetadata{}, err
+		}
+	case start > 0:
+		// SetRange reads from start to the end of the object when end is 0.
+		if err := getOpts.SetRange(start, 0); err != nil {
+			return ObjectMetadata{}, err
+		}
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.transferTimeout)
+	defer cancel()
+
+	body, info, header, err := s.client.minioClient.GetObjectRange(ctx, bucketName, objectKey, getOpts)
+	if err != nil {
+		return ObjectMetadata{}, notFoundError(err, bucketName, objectKey)
+	}
+	defer body.Close()
+
+	if _, err := io.Copy(w, body); err != nil {
+		return ObjectMetadata{}, err
+	}
+
+	metadata := *toObjectMetadata(info)
+	if metadata.Key == "" {
+		metadata.Key = objectKey
+	}
+	if total, ok := contentRangeTotal(header.Get("Content-Range")); ok {
+		metadata.Size = total
+	}
+	return metadata, nil
+}
+
+// contentRangeTotal returns the total size reported by a Content-Range header such as
+// "bytes 0-99/1234". It reports false when the header is missing or the size unknown.
+func contentRangeTotal(contentRange string) (int64, bool) {
+	_, total, ok := strings.Cut(contentRange, "/")
+	if !ok {
+		return 0, false
+	}
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return size, true
+}
+
+// GetAfterPut returns a reader for an object that was just written, such as by an upload
+// that returned etag. Before reading, the object is stat'ed until it is found with that
+// ETag, retrying with the backoff of the core client retry configuration, so a replica
+// lagging behind the write is never read. The read itself is conditioned on the ETag.
+//
+// Returns an ObjectNotFoundError when the object is still missing after the last attempt,
+// and a StaleReadError when it still has another ETag.
+func (s *objectService) GetAfterPut(ctx context.Context, bucketName string, objectKey string, etag string) (io.Reader, error) {
+	if err := validateBucket(bucketName); err != nil {
+		return nil, err
+	}
+
+	if err := validateObjectKey(objectKey); err != nil {
+		return nil, err
+	}
+
+	etag = strings.Trim(etag, `"`)
+	if etag == "" {
+		return nil, &client.ValidationError{Field: "etag", Message: "cannot be empty"}
+	}
+
+	retryConfig := s.client.GetConfig().RetryConfig
+	for attempt := 1; ; attempt++ {
+		metadata, err := s.Stat(ctx, bucketName, objectKey)
+		var notFound *ObjectNotFoundError
+		if err != nil && !errors.As(err, &notFound) {
+			return nil, err
+		}
+		if err == nil && metadata.ETag != etag {
+			err = &StaleReadError{Bucket: bucketName, Key: objectKey, ExpectedETag: etag, ETag: metadata.ETag}
+		}
+		if err == nil {
+			break
+		}
+		if attempt >= retryConfig.MaxAttempts {
+			return nil, err
+		}
+
+		backoff := retry.GetNextBackoff(attempt-1, retryConfig.BackoffFactor, retryConfig.InitialInterval, retryConfig.MaxInterval)
+		if err := s.client.clock.Sleep(ctx, backoff); err != nil {
+			return nil, err
+		}
+	}
+
+	getOpts := minio.GetObjectOptions{}
+	if err := getOpts.SetMatchETag(etag); err != nil {
+		return nil, err
+	}
+
+	object, _, err := s.client.minioClient.GetObject(ctx, bucketName, objectKey, getOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -148,10 +590,14 @@ func (s *objectService) List(ctx context.Context, bucketName string, opts Object
 	}
 
 	result := make([]Object, 0)
-	objectCh := s.client.minioClient.ListObjects(ctx, bucketName, minio.ListObjectsOptions{
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	listOpts := minio.ListObjectsOptions{
 		Prefix:    opts.Prefix,
 		Recursive: opts.Delimiter == "",
-	})
+	}
 
 	limit := 50
 	offset := 0
@@ -164,10 +610,17 @@ func (s *objectService) List(ctx context.Context, bucketName string, opts Object
 		offset = *opts.Offset
 	}
 
+	filter := objectFilter{
+		modifiedAfter:  opts.ModifiedAfter,
+		modifiedBefore: opts.ModifiedBefore,
+		minSize:        opts.MinSize,
+		maxSize:        opts.MaxSize,
+	}
+
 	count := 0
-	for object := range objectCh {
-		if object.Err != nil {
-			return nil, object.Err
+	err := s.client.listObjects(ctx, bucketName, listOpts, func(object minio.ObjectInfo) bool {
+		if !filter.matches(object) {
+			return true
 		}
 
 		if count >= offset && count < offset+limit {
@@ -181,14 +634,44 @@ func (s *objectService) List(ctx context.Context, bucketName string, opts Object
 
 		count++
 
-		if opts.Limit != nil && len(result) >= limit {
-			break
-		}
+		return opts.Limit == nil || len(result) < limit
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return result, nil
 }
 
+// objectFilter holds the client-side criteria applied to listed objects.
+type objectFilter struct {
+	modifiedAfter  *time.Time
+	modifiedBefore *time.Time
+	minSize        *int64
+	maxSize        *int64
+}
+
+// matches reports whether an object satisfies every criteria of the filter.
+func (f objectFilter) matches(object minio.ObjectInfo) bool {
+	if f.modifiedAfter != nil && !object.LastModified.After(*f.modifiedAfter) {
+		return false
+	}
+
+	if f.modifiedBefore != nil && !object.LastModified.Before(*f.modifiedBefore) {
+		return false
+	}
+
+	if f.minSize != nil && object.Size < *f.minSize {
+		return false
+	}
+
+	if f.maxSize != nil && object.Size > *f.maxSize {
+		return false
+	}
+
+	return true
+}
+
 // ListAll retrieves all objects in a bucket without pagination.
 func (s *objectService) ListAll(ctx context.Context, bucketName string, opts ObjectFilterOptions) ([]Object, error) {
 	if bucketName == "" {
@@ -196,28 +679,92 @@ func (s *objectService) ListAll(ctx context.Context, bucketName string, opts Obj
 	}
 
 	result := make([]Object, 0)
-	objectCh := s.client.minioClient.ListObjects(ctx, bucketName, minio.ListObjectsOptions{
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	listOpts := minio.ListObjectsOptions{
 		Prefix:    opts.Prefix,
 		Recursive: opts.Delimiter == "",
+	}
+
+	filter := objectFilter{
+		modifiedAfter:  opts.ModifiedAfter,
+		modifiedBefore: opts.ModifiedBefore,
+		minSize:        opts.MinSize,
+		maxSize:        opts.MaxSize,
+	}
+
+	err := s.client.listObjects(ctx, bucketName, listOpts, func(object minio.ObjectInfo) bool {
+		if filter.matches(object) {
+			result = append(result, Object{
+				Key:          object.Key,
+				Size:         object.Size,
+				LastModified: object.LastModified,
+				ETag:         object.ETag,
+			})
+		}
+		return true
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	for object := range objectCh {
-		if object.Err != nil {
-			return nil, object.Err
+	return result, nil
+}
+
+// LargestObjects returns the n largest objects under a prefix, sorted by size in descending order.
+// Only the current top n objects are kept in memory while the listing is consumed.
+func (s *objectService) LargestObjects(ctx context.Context, bucketName string, prefix string, n int) ([]Object, error) {
+	if err := validateBucket(bucketName); err != nil {
+		return nil, err
+	}
+
+	if n <= 0 {
+		return nil, &InvalidObjectDataError{Message: "number of objects must be greater than zero"}
+	}
+
+	result := make([]Object, 0, n)
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	listOpts := minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	}
+
+	err := s.client.listObjects(ctx, bucketName, listOpts, func(object minio.ObjectInfo) bool {
+		if len(result) == n && object.Size <= result[n-1].Size {
+			return true
 		}
 
-		result = append(result, Object{
+		i := sort.Search(len(result), func(i int) bool {
+			return result[i].Size < object.Size
+		})
+
+		if len(result) < n {
+			result = append(result, Object{})
+		}
+		copy(result[i+1:], result[i:])
+		result[i] = Object{
 			Key:          object.Key,
 			Size:         object.Size,
 			LastModified: object.LastModified,
 			ETag:         object.ETag,
-		})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return result, nil
 }
 
-// Delete removes an object from a bucket.
+// Delete removes an object from a bucket. In a versioned bucket, a delete without
+// opts.VersionID adds a delete marker; deleting a specific version of an object under a
+// legal hold or an active retention returns an ObjectError giving the reason.
 func (s *objectService) Delete(ctx context.Context, bucketName string, objectKey string, opts *DeleteOptions) error {
 	if bucketName == "" {
 		return &InvalidBucketNameError{Name: bucketName}
@@ -228,13 +775,514 @@ func (s *objectService) Delete(ctx context.Context, bucketName string, objectKey
 	}
 
 	removeOpts := minio.RemoveObjectOptions{}
-	if opts != nil && opts.VersionID != "" {
+	if opts != nil {
 		removeOpts.VersionID = opts.VersionID
+		removeOpts.GovernanceBypass = opts.BypassGovernance
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	if opts != nil && !opts.IfUnmodifiedSince.IsZero() {
+		if err := s.checkUnmodified(ctx, bucketName, objectKey, opts); err != nil {
+			return err
+		}
+	}
+
+	if removeOpts.VersionID != "" {
+		if err := s.checkDeletable(ctx, bucketName, objectKey, removeOpts); err != nil {
+			return err
+		}
 	}
 
 	return s.client.minioClient.RemoveObject(ctx, bucketName, objectKey, removeOpts)
 }
 
+// checkUnmodified returns an ObjectModifiedError when the object was modified after
+// opts.IfUnmodifiedSince, and an ObjectNotFoundError when it no longer exists.
+func (s *objectService) checkUnmodified(ctx context.Context, bucketName string, objectKey string, opts *DeleteOptions) error {
+	info, err := s.client.minioClient.StatObject(ctx, bucketName, objectKey, minio.StatObjectOptions{VersionID: opts.VersionID})
+	if err != nil {
+		return notFoundError(err, bucketName, objectKey)
+	}
+
+	if info.LastModified.After(opts.IfUnmodifiedSince) {
+		return &ObjectModifiedError{Bucket: bucketName, Key: objectKey, LastModified: info.LastModified}
+	}
+	return nil
+}
+
+// checkDeletable returns an ObjectError when the object version is under a legal hold or an
+// active retention that the delete cannot bypass, so callers get the reason instead of an
+// access denied error. It only applies to deletes of a specific version, as a delete without
+// a version ID only adds a delete marker, which locks do not prevent. Failures to read the
+// lock state are ignored and left to the delete itself, since buckets without object locking
+// reject these lookups.
+func (s *objectService) checkDeletable(ctx context.Context, bucketName string, objectKey string, opts minio.RemoveObjectOptions) error {
+	locked := func(message string) error {
+		return &ObjectError{Operation: "delete", Bucket: bucketName, Key: objectKey, Message: message}
+	}
+
+	hold, err := s.client.minioClient.GetObjectLegalHold(ctx, bucketName, objectKey, minio.GetObjectLegalHoldOptions{VersionID: opts.VersionID})
+	if err == nil && hold != nil && *hold == minio.LegalHoldEnabled {
+		return locked("locked by legal hold")
+	}
+
+	mode, retainUntil, err := s.client.minioClient.GetObjectRetention(ctx, bucketName, objectKey, opts.VersionID)
+	if err != nil || mode == nil || retainUntil == nil || !retainUntil.After(s.client.clock.Now()) {
+		return nil
+	}
+	if *mode == minio.Governance && opts.GovernanceBypass {
+		return nil
+	}
+
+	return locked(fmt.Sprintf("locked by %s retention until %s", *mode, retainUntil.Format(time.RFC3339)))
+}
+
+// RemoveObjectsStream deletes the objects whose keys are received from objectKeys,
+// batching them into multi-object delete requests. Failures are emitted on the returned
+// channel as they occur, which is closed once objectKeys is closed and every key was processed.
+// Callers must drain the returned channel. If ctx is done before objectKeys is closed,
+// a final ObjectError carrying the context error is emitted.
+func (s *objectService) RemoveObjectsStream(ctx context.Context, bucketName string, objectKeys <-chan string) <-chan ObjectError {
+	return s.removeObjectsStream(ctx, bucketName, objectKeys, nil)
+}
+
+// removeObjectsStream implements RemoveObjectsStream, calling deleted, when not nil, for
+// every key the server confirmed as deleted.
+func (s *objectService) removeObjectsStream(ctx context.Context, bucketName string, objectKeys <-chan string, deleted func(key string)) <-chan ObjectError {
+	errCh := make(chan ObjectError, 1)
+
+	if bucketName == "" {
+		errCh <- ObjectError{Operation: "delete", Bucket: bucketName, Message: (&InvalidBucketNameError{Name: bucketName}).Error()}
+		close(errCh)
+		return errCh
+	}
+
+	objectsCh := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(objectsCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case key, ok := <-objectKeys:
+				if !ok {
+					return
+				}
+				select {
+				case objectsCh <- minio.ObjectInfo{Key: key}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	go func() {
+		defer close(errCh)
+		for result := range s.client.minioClient.RemoveObjectsWithResult(ctx, bucketName, objectsCh, minio.RemoveObjectsOptions{}) {
+			if result.Err == nil {
+				if deleted != nil {
+					deleted(result.ObjectName)
+				}
+				continue
+			}
+			errCh <- ObjectError{
+				Operation: "delete",
+				Bucket:    bucketName,
+				Key:       result.ObjectName,
+				Message:   result.Err.Error(),
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			errCh <- ObjectError{Operation: "delete", Bucket: bucketName, Message: err.Error()}
+		}
+	}()
+
+	return errCh
+}
+
+// RemovePrefix deletes every object whose key starts with prefix, such as all the objects
+// in the "logs/2023/" folder. Keys are fed to RemoveObjectsStream as they are listed, so
+// the listing is never held in memory. It returns the number of deletions the server
+// confirmed and the keys that could not be deleted; the error reports a failed listing or
+// a done context, in which case the objects listed so far may have been deleted. In a
+// versioned bucket, the objects only get a delete marker and their versions are kept.
+//
+// An empty prefix is rejected with a client.ValidationError rather than emptying the bucket.
+func (s *objectService) RemovePrefix(ctx context.Context, bucketName string, prefix string) (int, []ObjectError, error) {
+	if err := validatePrefixRemoval(bucketName, prefix); err != nil {
+		return 0, nil, err
+	}
+
+	ctx, cancelTimeout := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancelTimeout()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	keys := make(chan string)
+	listDone := make(chan struct{})
+	var listErr error
+	go func() {
+		defer close(listDone)
+		defer close(keys)
+		listErr = s.client.listObjects(ctx, bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}, func(object minio.ObjectInfo) bool {
+			select {
+			case keys <- object.Key:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	deleted := 0
+	var failed []ObjectError
+	var err error
+	for objErr := range s.removeObjectsStream(ctx, bucketName, keys, func(string) { deleted++ }) {
+		if objErr.Key == "" {
+			err = &objErr
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				err = ctxErr
+			}
+			continue
+		}
+		failed = append(failed, objErr)
+	}
+
+	cancel()
+	<-listDone
+	if listErr != nil {
+		err = listErr
+	}
+
+	return deleted, failed, err
+}
+
+// PreviewRemovePrefix lists the objects RemovePrefix would delete for the same bucket and
+// prefix, without deleting anything, along with the total size that would be freed. Objects
+// written or deleted after the preview are not reflected in it.
+func (s *objectService) PreviewRemovePrefix(ctx context.Context, bucketName string, prefix string) (*PrefixRemovalPreview, error) {
+	if err := validatePrefixRemoval(bucketName, prefix); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	preview := &PrefixRemovalPreview{Keys: make([]string, 0)}
+	err := s.client.listObjects(ctx, bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}, func(object minio.ObjectInfo) bool {
+		preview.Keys = append(preview.Keys, object.Key)
+		preview.TotalSize += object.Size
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// validatePrefixRemoval validates the arguments of RemovePrefix and PreviewRemovePrefix.
+func validatePrefixRemoval(bucketName string, prefix string) error {
+	if err := validateBucket(bucketName); err != nil {
+		return err
+	}
+
+	if prefix == "" {
+		return &client.ValidationError{Field: "prefix", Message: "cannot be empty, as it would delete every object in the bucket"}
+	}
+
+	return nil
+}
+
+// ListIncompleteUploads lists the multipart uploads of a bucket that were started but neither
+// completed nor aborted, limited to the keys starting with prefix when it is not empty.
+func (s *objectService) ListIncompleteUploads(ctx context.Context, bucketName string, prefix string) ([]IncompleteUpload, error) {
+	if err := validateBucket(bucketName); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	uploads := make([]IncompleteUpload, 0)
+	for upload := range s.client.minioClient.ListIncompleteUploads(ctx, bucketName, prefix, true) {
+		if upload.Err != nil {
+			return nil, upload.Err
+		}
+		uploads = append(uploads, IncompleteUpload{
+			Key:          upload.Key,
+			UploadID:     upload.UploadID,
+			Initiated:    upload.Initiated,
+			StorageClass: upload.StorageClass,
+			Size:         upload.Size,
+		})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return uploads, nil
+}
+
+// AbortIncompleteUpload aborts a multipart upload, deleting the parts uploaded so far.
+// Other uploads of the same key are not affected. Returns an UploadNotFoundError when
+// the upload does not exist or was already completed or aborted.
+func (s *objectService) AbortIncompleteUpload(ctx context.Context, bucketName string, objectKey string, uploadID string) error {
+	if err := validateBucket(bucketName); err != nil {
+		return err
+	}
+
+	if err := validateObjectKey(objectKey); err != nil {
+		return err
+	}
+
+	if uploadID == "" {
+		return &client.ValidationError{Field: "uploadID", Message: "cannot be empty"}
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	err := s.client.minioClient.AbortMultipartUpload(ctx, bucketName, objectKey, uploadID)
+	if minio.ToErrorResponse(err).Code == "NoSuchUpload" {
+		return &UploadNotFoundError{Bucket: bucketName, Key: objectKey, UploadID: uploadID}
+	}
+
+	return err
+}
+
+// AbortUploadsOlderThan aborts the incomplete multipart uploads of a bucket started more
+// than age ago, so that a scheduled job can clean up after clients that failed mid-upload.
+// Uploads completed or aborted meanwhile are skipped. It returns the number of uploads
+// aborted, including when it stops at the first error.
+func (s *objectService) AbortUploadsOlderThan(ctx context.Context, bucketName string, age time.Duration) (int, error) {
+	if age <= 0 {
+		return 0, &client.ValidationError{Field: "age", Message: "must be positive"}
+	}
+
+	uploads, err := s.ListIncompleteUploads(ctx, bucketName, "")
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := s.client.clock.Now().Add(-age)
+	aborted := 0
+	for _, upload := range uploads {
+		if !upload.Initiated.Before(cutoff) {
+			continue
+		}
+
+		err := s.AbortIncompleteUpload(ctx, bucketName, upload.Key, upload.UploadID)
+		var notFound *UploadNotFoundError
+		if errors.As(err, &notFound) {
+			continue
+		}
+		if err != nil {
+			return aborted, err
+		}
+		aborted++
+	}
+
+	return aborted, nil
+}
+
+// Copy duplicates an object with a server-side copy. By default the destination keeps the
+// metadata of the source; with the REPLACE directive it gets opts.UserMetadata instead,
+// which must then hold the full metadata set. Setting UserMetadata with the COPY directive
+// returns a client.ValidationError, as S3 would silently ignore it. An object can only be
+// copied onto itself with REPLACE, to update its metadata. Sources larger than 5 GiB are
+// copied with a server-side multipart copy.
+// Returns an ObjectNotFoundError if the source does not exist and a BucketNotFoundError
+// if the source or destination bucket does not exist.
+func (s *objectService) Copy(ctx context.Context, srcBucket string, srcKey string, dstBucket string, dstKey string, opts CopyOptions) error {
+	if err := validateCopy(srcBucket, srcKey, dstBucket, dstKey, opts); err != nil {
+		return err
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	return s.copyObject(ctx, srcBucket, srcKey, dstBucket, dstKey, opts)
+}
+
+// validateCopy checks the source, destination and metadata directive of a copy.
+func validateCopy(srcBucket string, srcKey string, dstBucket string, dstKey string, opts CopyOptions) error {
+	for _, bucket := range []string{srcBucket, dstBucket} {
+		if err := validateBucket(bucket); err != nil {
+			return err
+		}
+	}
+	for _, key := range []string{srcKey, dstKey} {
+		if err := validateObjectKey(key); err != nil {
+			return err
+		}
+	}
+
+	switch opts.MetadataDirective {
+	case "", MetadataDirectiveCopy:
+		if opts.UserMetadata != nil {
+			return &client.ValidationError{
+				Field:   "userMetadata",
+				Message: "cannot be set with the COPY metadata directive, use REPLACE",
+			}
+		}
+		if srcBucket == dstBucket && srcKey == dstKey {
+			return &InvalidObjectKeyError{Key: dstKey}
+		}
+	case MetadataDirectiveReplace:
+		if opts.UserMetadata == nil {
+			return &client.ValidationError{
+				Field:   "userMetadata",
+				Message: "must hold the full metadata set with the REPLACE metadata directive",
+			}
+		}
+	default:
+		return &client.ValidationError{
+			Field:   "metadataDirective",
+			Message: fmt.Sprintf("must be %s or %s, got %q", MetadataDirectiveCopy, MetadataDirectiveReplace, opts.MetadataDirective),
+		}
+	}
+
+	return nil
+}
+
+// copyObject runs a copy validated by validateCopy. It goes through ComposeObject, which
+// issues a single CopyObject for sources up to 5 GiB and a multipart copy above that.
+// A missing source maps to ObjectNotFoundError and a missing source or destination bucket
+// to a BucketNotFoundError naming that bucket.
+func (s *objectService) copyObject(ctx context.Context, srcBucket string, srcKey string, dstBucket string, dstKey string, opts CopyOptions) error {
+	src := minio.CopySrcOptions{Bucket: srcBucket, Object: srcKey, VersionID: opts.SourceVersionID}
+	dst := minio.CopyDestOptions{Bucket: dstBucket, Object: dstKey}
+	if opts.MetadataDirective == MetadataDirectiveReplace {
+		dst.UserMetadata = opts.UserMetadata
+		dst.ReplaceMetadata = true
+	}
+
+	if _, err := s.client.minioClient.ComposeObject(ctx, dst, src); err != nil {
+		if isBucketNotFound(err) {
+			bucket := minio.ToErrorResponse(err).BucketName
+			if bucket == "" {
+				bucket = dstBucket
+			}
+			return &BucketNotFoundError{Bucket: bucket}
+		}
+		return notFoundError(err, srcBucket, srcKey)
+	}
+	return nil
+}
+
+// Move relocates an object with a server-side copy followed by a delete of the source.
+// The source is only removed after the copy succeeds, so a failed copy never loses data.
+// Metadata and tags are preserved unless opts.UserMetadata is set, and sources larger than
+// 5 GiB are copied in parts, as with Copy.
+// Returns an ObjectNotFoundError if the source does not exist and a BucketNotFoundError if
+// the source or destination bucket does not exist; if the copy succeeds but
+// the source cannot be removed, the returned ObjectError has Operation "delete" and both
+// the source and destination exist.
+func (s *objectService) Move(ctx context.Context, srcBucket string, srcKey string, dstBucket string, dstKey string, opts MoveOptions) error {
+	copyOpts := CopyOptions{SourceVersionID: opts.SourceVersionID}
+	if opts.UserMetadata != nil {
+		copyOpts.MetadataDirective = MetadataDirectiveReplace
+		copyOpts.UserMetadata = opts.UserMetadata
+	}
+	if err := validateCopy(srcBucket, srcKey, dstBucket, dstKey, copyOpts); err != nil {
+		return err
+	}
+	if srcBucket == dstBucket && srcKey == dstKey {
+		return &InvalidObjectKeyError{Key: dstKey}
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	if err := s.copyObject(ctx, srcBucket, srcKey, dstBucket, dstKey, copyOpts); err != nil {
+		return err
+	}
+
+	removeOpts := minio.RemoveObjectOptions{VersionID: opts.SourceVersionID}
+	if err := s.client.minioClient.RemoveObject(ctx, srcBucket, srcKey, removeOpts); err != nil {
+		return &ObjectError{
+			Operation: "delete",
+			Bucket:    srcBucket,
+			Key:       srcKey,
+			Message:   fmt.Sprintf("copied to %s/%s but source was not removed: %v", dstBucket, dstKey, err),
+		}
+	}
+
+	return nil
+}
+
+// Compose creates an object by concatenating sources server-side, in order, without
+// downloading them, such as merging daily log chunks. Sources may come from other buckets
+// and be byte ranges of objects. Every source but the last must be at least MinPartSize
+// bytes long, and there can be at most MaxPartCount sources; a shorter source returns an
+// InvalidObjectDataError.
+// Returns an ObjectNotFoundError if a source does not exist.
+func (s *objectService) Compose(ctx context.Context, dstBucket string, dstKey string, sources []ComposeSource) (*UploadInfo, error) {
+	if err := validateBucket(dstBucket); err != nil {
+		return nil, err
+	}
+	if err := validateObjectKey(dstKey); err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 || len(sources) > MaxPartCount {
+		return nil, &client.ValidationError{
+			Field:   "sources",
+			Message: fmt.Sprintf("must have between 1 and %d sources", MaxPartCount),
+		}
+	}
+
+	srcs := make([]minio.CopySrcOptions, len(sources))
+	for i, source := range sources {
+		if err := validateBucket(source.Bucket); err != nil {
+			return nil, err
+		}
+		if err := validateObjectKey(source.Key); err != nil {
+			return nil, err
+		}
+
+		src := minio.CopySrcOptions{Bucket: source.Bucket, Object: source.Key, VersionID: source.VersionID}
+		if source.Range != nil {
+			if source.Range.Start < 0 || source.Range.End < source.Range.Start {
+				return nil, &client.ValidationError{
+					Field:   fmt.Sprintf("sources[%d].range", i),
+					Message: fmt.Sprintf("invalid range %d-%d", source.Range.Start, source.Range.End),
+				}
+			}
+			src.MatchRange = true
+			src.Start, src.End = source.Range.Start, source.Range.End
+		}
+		srcs[i] = src
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.transferTimeout)
+	defer cancel()
+
+	info, err := s.client.minioClient.ComposeObject(ctx, minio.CopyDestOptions{Bucket: dstBucket, Object: dstKey}, srcs...)
+	if err != nil {
+		resp := minio.ToErrorResponse(err)
+		if resp.Code == minio.InvalidArgument && strings.Contains(resp.Message, "too small") {
+			return nil, &InvalidObjectDataError{Message: fmt.Sprintf(
+				"every source but the last must be at least %d bytes: %s", MinPartSize, resp.Message)}
+		}
+		return nil, notFoundError(err, resp.BucketName, resp.Key)
+	}
+
+	return &UploadInfo{
+		Bucket:    info.Bucket,
+		Key:       info.Key,
+		ETag:      info.ETag,
+		Size:      info.Size,
+		VersionID: info.VersionID,
+	}, nil
+}
+
 // Metadata returns metadata about an object.
 func (s *objectService) Metadata(ctx context.Context, bucketName string, objectKey string) (*Object, error) {
 	if bucketName == "" {
@@ -245,6 +1293,9 @@ func (s *objectService) Metadata(ctx context.Context, bucketName string, objectK
 		return nil, &InvalidObjectKeyError{Key: objectKey}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	info, err := s.client.minioClient.StatObject(ctx, bucketName, objectKey, minio.StatObjectOptions{})
 	if err != nil {
 		return nil, err
@@ -259,6 +1310,68 @@ func (s *objectService) Metadata(ctx context.Context, bucketName string, objectK
 	}, nil
 }
 
+// Stat issues a HEAD request for an object and returns its complete metadata, such as to
+// confirm an object exists and read its size before handing out a presigned URL.
+// Returns an ObjectNotFoundError if the object does not exist, and a BucketNotFoundError
+// if the bucket does not.
+func (s *objectService) Stat(ctx context.Context, bucketName string, objectKey string) (*ObjectMetadata, error) {
+	if err := validateBucket(bucketName); err != nil {
+		return nil, err
+	}
+
+	if err := validateObjectKey(objectKey); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	info, err := s.client.minioClient.StatObject(ctx, bucketName, objectKey, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, notFoundError(err, bucketName, objectKey)
+	}
+
+	return toObjectMetadata(info), nil
+}
+
+// toObjectMetadata converts MinIO object information to ObjectMetadata.
+func toObjectMetadata(info minio.ObjectInfo) *ObjectMetadata {
+	return &ObjectMetadata{
+		Key:          info.Key,
+		Size:         info.Size,
+		ETag:         info.ETag,
+		ContentType:  info.ContentType,
+		LastModified: info.LastModified,
+		UserMetadata: info.UserMetadata,
+		VersionID:    info.VersionID,
+	}
+}
+
+// isNotFound reports whether a MinIO error means the object does not exist.
+// A missing bucket is not a missing object; see isBucketNotFound.
+func isNotFound(err error) bool {
+	resp := minio.ToErrorResponse(err)
+	return resp.Code == "NoSuchKey" || (resp.StatusCode == http.StatusNotFound && resp.Code != "NoSuchBucket")
+}
+
+// isBucketNotFound reports whether a MinIO error means the bucket does not exist.
+func isBucketNotFound(err error) bool {
+	return minio.ToErrorResponse(err).Code == "NoSuchBucket"
+}
+
+// notFoundError returns a BucketNotFoundError when err reports that the bucket does not
+// exist, an ObjectNotFoundError for bucket/key when it reports that the object does not,
+// and err unchanged otherwise.
+func notFoundError(err error, bucket, key string) error {
+	switch {
+	case isBucketNotFound(err):
+		return &BucketNotFoundError{Bucket: bucket}
+	case isNotFound(err):
+		return &ObjectNotFoundError{Bucket: bucket, Key: key}
+	}
+	return err
+}
+
 // LockObject applies a retention lock to an object until the specified date.
 func (s *objectService) LockObject(ctx context.Context, bucketName string, objectKey string, retainUntilDate time.Time) error {
 	if bucketName == "" {
@@ -282,6 +1395,9 @@ func (s *objectService) LockObject(ctx context.Context, bucketName string, objec
 		GovernanceBypass: false,
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	return s.client.minioClient.PutObjectRetention(ctx, bucketName, objectKey, opts)
 }
 
@@ -302,6 +1418,9 @@ func (s *objectService) UnlockObject(ctx context.Context, bucketName string, obj
 		GovernanceBypass: true,
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	return s.client.minioClient.PutObjectRetention(ctx, bucketName, objectKey, opts)
 }
 
@@ -315,6 +1434,9 @@ func (s *objectService) GetObjectLockStatus(ctx context.Context, bucketName stri
 		return false, &InvalidObjectKeyError{Key: objectKey}
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	mode, _, err := s.client.minioClient.GetObjectRetention(ctx, bucketName, objectKey, "")
 	if err != nil {
 		return false, err
@@ -326,6 +1448,40 @@ func (s *objectService) GetObjectLockStatus(ctx context.Context, bucketName stri
 	return isLocked, nil
 }
 
+// GetRetention retrieves the retention lock of an object and the time remaining until it expires.
+// Returns a NoRetentionError if the object has no retention set and an ObjectNotFoundError
+// if the object does not exist.
+func (s *objectService) GetRetention(ctx context.Context, bucketName string, objectKey string) (*RetentionInfo, error) {
+	if err := validateBucket(bucketName); err != nil {
+		return nil, err
+	}
+
+	if err := validateObjectKey(objectKey); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	mode, retainUntil, err := s.client.minioClient.GetObjectRetention(ctx, bucketName, objectKey, "")
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchObjectLockConfiguration" {
+			return nil, &NoRetentionError{Bucket: bucketName, Key: objectKey}
+		}
+		return nil, notFoundError(err, bucketName, objectKey)
+	}
+
+	if mode == nil || retainUntil == nil || retainUntil.IsZero() {
+		return nil, &NoRetentionError{Bucket: bucketName, Key: objectKey}
+	}
+
+	return &RetentionInfo{
+		Mode:        RetentionMode(*mode),
+		RetainUntil: *retainUntil,
+		Remaining:   max(retainUntil.Sub(s.client.clock.Now()), 0),
+	}, nil
+}
+
 // ListVersions retrieves all versions of an object from a versioned bucket.
 func (s *objectService) ListVersions(ctx context.Context, bucketName string, objectKey string, opts *ListVersionsOptions) ([]ObjectVersion, error) {
 	if bucketName == "" {
@@ -337,10 +1493,14 @@ func (s *objectService) ListVersions(ctx context.Context, bucketName string, obj
 	}
 
 	result := make([]ObjectVersion, 0)
-	objectVersionCh := s.client.minioClient.ListObjects(ctx, bucketName, minio.ListObjectsOptions{
+
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
+	listOpts := minio.ListObjectsOptions{
 		Prefix:    objectKey,
 		Recursive: true,
-	})
+	}
 
 	limit := 50
 	offset := 0
@@ -355,11 +1515,7 @@ func (s *objectService) ListVersions(ctx context.Context, bucketName string, obj
 	}
 
 	count := 0
-	for objectInfo := range objectVersionCh {
-		if objectInfo.Err != nil {
-			return nil, objectInfo.Err
-		}
-
+	err := s.client.listObjects(ctx, bucketName, listOpts, func(objectInfo minio.ObjectInfo) bool {
 		// Only include versions for the exact object key (not prefixes)
 		if objectInfo.Key == objectKey {
 			if count >= offset && count < offset+limit {
@@ -373,6 +1529,10 @@ func (s *objectService) ListVersions(ctx context.Context, bucketName string, obj
 			}
 			count++
 		}
+		return true
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return result, nil
@@ -400,9 +1560,15 @@ func (s *objectService) GetPresignedURL(ctx context.Context, bucketName string,
 		expiryInSeconds = *opts.ExpiryInSeconds
 	}
 
+	ctx, cancel := s.client.operationContext(ctx, s.client.operationTimeout)
+	defer cancel()
+
 	switch opts.Method {
 	case http.MethodGet:
-		presignedURL, err = s.client.minioClient.PresignedGetObject(ctx, bucketName, objectKey, expiryInSeconds, url.Values{})
+		reqParams := url.Values{}
+		maps.Copy(reqParams, s.client.presignParams)
+		maps.Copy(reqParams, opts.ReqParams)
+		presignedURL, err = s.client.minioClient.PresignedGetObject(ctx, bucketName, objectKey, expiryInSeconds, reqParams)
 	case http.MethodPut:
 		presignedURL, err = s.client.minioClient.PresignedPutObject(ctx, bucketName, objectKey, expiryInSeconds)
 	}
@@ -413,3 +1579,91 @@ func (s *objectService) GetPresignedURL(ctx context.Context, bucketName string,
 
 	return &PresignedURL{URL: presignedURL.String()}, nil
 }
+
+// GetPresignedURLs generates presigned URLs for many objects of a bucket concurrently,
+// applying the same options to every key and signing up to opts.Concurrency URLs at once.
+// It returns the URLs generated by key and one ObjectError per key that failed; keys not
+// yet generated when ctx is done fail with the context error, and the URLs generated
+// until then are still returned.
+func (s *objectService) GetPresignedURLs(ctx context.Context, bucketName string, objectKeys []string, opts GetPresignedURLOptions) (map[string]*PresignedURL, []error) {
+	if err := validateBucket(bucketName); err != nil {
+		return nil, []error{err}
+	}
+
+	urls := make([]*PresignedURL, len(objectKeys))
+	errs := make([]error, len(objectKeys))
+
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(objectKeys)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				urls[i], errs[i] = s.GetPresignedURL(ctx, bucketName, objectKeys[i], opts)
+			}
+		}()
+	}
+
+	for i := range objectKeys {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	result := make(map[string]*PresignedURL, len(objectKeys))
+	var failed []error
+	for i, key := range objectKeys {
+		if errs[i] != nil {
+			failed = append(failed, &ObjectError{Operation: "presign", Bucket: bucketName, Key: key, Message: errs[i].Error()})
+			continue
+		}
+		result[key] = urls[i]
+	}
+
+	return result, failed
+}
+
+// RefreshPresignedURL generates a new presigned URL, valid for newExpiry, for the same
+// object and response overrides as oldURL, which may already be expired. The HTTP method
+// is not part of a presigned URL, so it must be given: http.MethodGet or http.MethodPut,
+// the method oldURL was generated for. oldURL must have been generated by GetPresignedURL
+// for the endpoint of this client; otherwise an InvalidPresignedURLError is returned.
+func (s *objectService) RefreshPresignedURL(ctx context.Context, oldURL *url.URL, method string, newExpiry time.Duration) (*url.URL, error) {
+	if method != http.MethodGet && method != http.MethodPut {
+		return nil, &client.ValidationError{Field: "method", Message: "must be GET or PUT"}
+	}
+	if newExpiry <= 0 || newExpiry > MaxPresignExpiry {
+		return nil, &client.ValidationError{Field: "newExpiry", Message: "must be between 1 second and 7 days"}
+	}
+
+	if _, _, err := VerifyPresignedURL(oldURL); err != nil {
+		return nil, err
+	}
+
+	bucketName, objectKey, err := s.client.presignedObject(oldURL)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := GetPresignedURLOptions{Method: method, ExpiryInSeconds: &newExpiry}
+	if method == http.MethodGet {
+		opts.ReqParams = presignReqParams(oldURL.Query())
+	}
+
+	presigned, err := s.GetPresignedURL(ctx, bucketName, objectKey, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return url.Parse(presigned.URL)
+}
@@ -0,0 +1,2072 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/MagaluCloud/mgc-sdk-go/internal/clock"
+	"github.com/minio/minio-go/v7"
+)
+
+// newMockObjectService creates an ObjectService backed by a mock holding a single empty bucket,
+// along with the client it belongs to, configured with the given options.
+func newMockObjectService(t *testing.T, opts ...ClientOption) (ObjectService, *mockMinioClient, *ObjectStorageClient) {
+	t.Helper()
+
+	mock := newMockMinioClient()
+	mock.buckets["test-bucket"] = &mockBucket{
+		name:         "test-bucket",
+		creationDate: time.Now(),
+		objects:      make(map[string]*mockObject),
+	}
+
+	core := client.NewMgcClient()
+	osClient, err := New(core, "minioadmin", "minioadmin", append(opts, WithMinioClientInterface(mock))...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return osClient.Objects(), mock, osClient
+}
+
+// TestObjectServiceUpload_DetectsContentType tests content-type resolution on Upload
+func TestObjectServiceUpload_DetectsContentType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		objectKey   string
+		data        []byte
+		contentType string
+		want        string
+	}{
+		{
+			name:      "from extension",
+			objectKey: "index.html",
+			data:      []byte("plain text"),
+			want:      "text/html; charset=utf-8",
+		},
+		{
+			name:      "sniffed from data",
+			objectKey: "image",
+			data:      []byte("\x89PNG\r\n\x1a\n0000"),
+			want:      "image/png",
+		},
+		{
+			name:        "explicit content type wins",
+			objectKey:   "index.html",
+			data:        []byte("<html></html>"),
+			contentType: "application/x-custom",
+			want:        "application/x-custom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mock, _ := newMockObjectService(t)
+
+			err := svc.Upload(context.Background(), "test-bucket", tt.objectKey, tt.data, tt.contentType)
+			if err != nil {
+				t.Fatalf("Upload() error = %v", err)
+			}
+
+			if mock.lastContentType != tt.want {
+				t.Errorf("Upload() content type = %q, want %q", mock.lastContentType, tt.want)
+			}
+		})
+	}
+}
+
+// TestObjectServiceUploadStream_DetectsContentType tests sniffing preserves the streamed data
+func TestObjectServiceUploadStream_DetectsContentType(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+
+	data := append([]byte("%PDF-1.4\n"), bytes.Repeat([]byte("x"), 1024)...)
+	err := svc.UploadStream(context.Background(), "test-bucket", "document", bytes.NewReader(data), int64(len(data)), "")
+	if err != nil {
+		t.Fatalf("UploadStream() error = %v", err)
+	}
+
+	if mock.lastContentType != "application/pdf" {
+		t.Errorf("UploadStream() content type = %q, want %q", mock.lastContentType, "application/pdf")
+	}
+
+	if !bytes.Equal(mock.buckets["test-bucket"].objects["document"].data, data) {
+		t.Error("UploadStream() did not upload the complete data after sniffing")
+	}
+}
+
+// TestObjectServiceStat_WithMockSuccess tests Stat maps the object metadata
+func TestObjectServiceStat_WithMockSuccess(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	lastModified := time.Now().Add(-time.Hour)
+	mock.buckets["test-bucket"].objects["test-key"] = &mockObject{
+		key:          "test-key",
+		size:         42,
+		lastModified: lastModified,
+		etag:         "etag-1",
+		contentType:  "text/plain",
+	}
+
+	metadata, err := svc.Stat(context.Background(), "test-bucket", "test-key")
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+
+	if metadata.Key != "test-key" || metadata.Size != 42 || metadata.ETag != "etag-1" {
+		t.Errorf("Stat() returned unexpected metadata: %+v", metadata)
+	}
+
+	if metadata.ContentType != "text/plain" {
+		t.Errorf("Stat() content type = %q, want %q", metadata.ContentType, "text/plain")
+	}
+
+	if !metadata.LastModified.Equal(lastModified) {
+		t.Errorf("Stat() last modified = %v, want %v", metadata.LastModified, lastModified)
+	}
+}
+
+// TestObjectServiceStat_NotFound tests Stat returns ObjectNotFoundError for missing keys
+func TestObjectServiceStat_NotFound(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _ := newMockObjectService(t)
+
+	_, err := svc.Stat(context.Background(), "test-bucket", "missing-key")
+	if err == nil {
+		t.Fatal("Stat() expected error for missing object, got nil")
+	}
+
+	if _, ok := err.(*ObjectNotFoundError); !ok {
+		t.Errorf("Stat() expected ObjectNotFoundError, got %T", err)
+	}
+}
+
+// TestObjectServiceStat_BucketNotFound tests Stat reports a missing bucket as such, not as a missing object
+func TestObjectServiceStat_BucketNotFound(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _ := newMockObjectService(t)
+
+	_, err := svc.Stat(context.Background(), "missing-bucket", "test-key")
+	var notFound *BucketNotFoundError
+	if !errors.As(err, &notFound) || notFound.Bucket != "missing-bucket" {
+		t.Errorf("Stat() error = %v, want BucketNotFoundError for missing-bucket", err)
+	}
+}
+
+// TestObjectServiceStat_InvalidParameters tests Stat validates its arguments
+func TestObjectServiceStat_InvalidParameters(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _ := newMockObjectService(t)
+
+	if _, err := svc.Stat(context.Background(), "", "test-key"); err == nil {
+		t.Error("Stat() expected error for empty bucket name, got nil")
+	}
+
+	if _, err := svc.Stat(context.Background(), "test-bucket", ""); err == nil {
+		t.Error("Stat() expected error for empty object key, got nil")
+	}
+}
+
+// TestObjectServiceList_ModifiedRange tests the last-modified filters on List and ListAll
+func TestObjectServiceList_ModifiedRange(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	svc, mock, _ := newMockObjectService(t)
+	for key, age := range map[string]time.Duration{
+		"old":    72 * time.Hour,
+		"recent": 24 * time.Hour,
+		"new":    time.Hour,
+	} {
+		mock.buckets["test-bucket"].objects[key] = &mockObject{key: key, lastModified: now.Add(-age)}
+	}
+
+	after := now.Add(-48 * time.Hour)
+	before := now.Add(-2 * time.Hour)
+
+	objects, err := svc.List(context.Background(), "test-bucket", ObjectListOptions{
+		ModifiedAfter:  &after,
+		ModifiedBefore: &before,
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(objects) != 1 || objects[0].Key != "recent" {
+		t.Errorf("List() = %+v, want only the recent object", objects)
+	}
+
+	all, err := svc.ListAll(context.Background(), "test-bucket", ObjectFilterOptions{ModifiedAfter: &after})
+	if err != nil {
+		t.Fatalf("ListAll() error = %v", err)
+	}
+
+	if len(all) != 2 {
+		t.Errorf("ListAll() returned %d objects, want 2", len(all))
+	}
+}
+
+// TestObjectServiceList_SizeRange tests the size filters on List
+func TestObjectServiceList_SizeRange(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	for key, size := range map[string]int64{"small": 10, "medium": 100, "large": 1000} {
+		mock.buckets["test-bucket"].objects[key] = &mockObject{key: key, size: size}
+	}
+
+	minSize := int64(50)
+	maxSize := int64(500)
+
+	objects, err := svc.List(context.Background(), "test-bucket", ObjectListOptions{
+		MinSize: &minSize,
+		MaxSize: &maxSize,
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(objects) != 1 || objects[0].Key != "medium" {
+		t.Errorf("List() = %+v, want only the medium object", objects)
+	}
+}
+
+// TestObjectServiceLargestObjects tests the top-N selection by size
+func TestObjectServiceLargestObjects(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	for key, size := range map[string]int64{"a": 5, "b": 50, "c": 500, "d": 5000, "e": 1} {
+		mock.buckets["test-bucket"].objects[key] = &mockObject{key: key, size: size}
+	}
+
+	objects, err := svc.LargestObjects(context.Background(), "test-bucket", "", 3)
+	if err != nil {
+		t.Fatalf("LargestObjects() error = %v", err)
+	}
+
+	want := []string{"d", "c", "b"}
+	if len(objects) != len(want) {
+		t.Fatalf("LargestObjects() returned %d objects, want %d", len(objects), len(want))
+	}
+
+	for i, key := range want {
+		if objects[i].Key != key {
+			t.Errorf("LargestObjects()[%d] = %s, want %s", i, objects[i].Key, key)
+		}
+	}
+
+	if _, err := svc.LargestObjects(context.Background(), "test-bucket", "", 0); err == nil {
+		t.Error("LargestObjects() expected error for n = 0, got nil")
+	}
+}
+
+// TestObjectServiceGetPresignedURLs tests batch presigned URL generation
+func TestObjectServiceGetPresignedURLs(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	var gotParams url.Values
+	var mu sync.Mutex
+	mock.presignedGetObjectFunc = func(ctx context.Context, bucketName, objectName string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
+		if objectName == "missing.jpg" {
+			return nil, errors.New("signing failed")
+		}
+		mu.Lock()
+		gotParams = reqParams
+		mu.Unlock()
+		return url.Parse("https://mock-minio/" + bucketName + "/" + objectName)
+	}
+
+	opts := GetPresignedURLOptions{
+		Method:    http.MethodGet,
+		ReqParams: url.Values{"response-content-disposition": {"attachment"}},
+	}
+	urls, errs := svc.GetPresignedURLs(context.Background(), "test-bucket", []string{"a.jpg", "missing.jpg", "b.jpg", ""}, opts)
+
+	if len(urls) != 2 {
+		t.Fatalf("GetPresignedURLs() returned %d URLs, want 2", len(urls))
+	}
+	if urls["a.jpg"].URL != "https://mock-minio/test-bucket/a.jpg" {
+		t.Errorf("GetPresignedURLs() a.jpg = %q", urls["a.jpg"].URL)
+	}
+	if gotParams.Get("response-content-disposition") != "attachment" {
+		t.Errorf("GetPresignedURLs() expected reqParams to be forwarded, got %v", gotParams)
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("GetPresignedURLs() returned %d errors, want 2", len(errs))
+	}
+	var objErr *ObjectError
+	if !errors.As(errs[0], &objErr) || objErr.Key != "missing.jpg" {
+		t.Errorf("GetPresignedURLs() expected ObjectError for missing.jpg, got %v", errs[0])
+	}
+	if !errors.As(errs[1], &objErr) || objErr.Key != "" {
+		t.Errorf("GetPresignedURLs() expected ObjectError for empty key, got %v", errs[1])
+	}
+}
+
+// TestObjectServiceGetPresignedURL_DefaultParams tests client defaults are merged into GET params, per-call params winning
+func TestObjectServiceGetPresignedURL_DefaultParams(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockMinioClient()
+	var gotParams url.Values
+	mock.presignedGetObjectFunc = func(ctx context.Context, bucketName, objectName string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
+		gotParams = reqParams
+		return url.Parse("https://mock-minio/" + bucketName + "/" + objectName)
+	}
+	defaults := url.Values{
+		"response-content-disposition": {"attachment"},
+		"response-cache-control":       {"no-cache"},
+	}
+	osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock), WithDefaultPresignParams(defaults))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defaults.Set("response-cache-control", "changed")
+
+	opts := GetPresignedURLOptions{
+		Method:    http.MethodGet,
+		ReqParams: url.Values{"response-content-disposition": {`attachment; filename="report.pdf"`}},
+	}
+	if _, err := osClient.Objects().GetPresignedURL(context.Background(), "test-bucket", "report.pdf", opts); err != nil {
+		t.Fatalf("GetPresignedURL() error = %v", err)
+	}
+
+	want := url.Values{
+		"response-content-disposition": {`attachment; filename="report.pdf"`},
+		"response-cache-control":       {"no-cache"},
+	}
+	if !reflect.DeepEqual(gotParams, want) {
+		t.Errorf("GetPresignedURL() reqParams = %v, want %v", gotParams, want)
+	}
+	if opts.ReqParams.Get("response-cache-control") != "" {
+		t.Error("GetPresignedURL() modified the per-call params")
+	}
+}
+
+// TestObjectServiceGetPresignedURLs_ContextCancelled tests that a done context fails every key
+func TestObjectServiceGetPresignedURLs_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	mock.presignedGetObjectFunc = func(ctx context.Context, bucketName, objectName string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
+		t.Errorf("unexpected presign of %s after cancellation", objectName)
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	urls, errs := svc.GetPresignedURLs(ctx, "test-bucket", []string{"a.jpg", "b.jpg"}, GetPresignedURLOptions{Method: http.MethodGet})
+	if len(urls) != 0 {
+		t.Errorf("GetPresignedURLs() returned %d URLs, want 0", len(urls))
+	}
+	if len(errs) != 2 {
+		t.Errorf("GetPresignedURLs() returned %d errors, want 2", len(errs))
+	}
+}
+
+// TestObjectServiceGetPresignedURLs_Concurrency tests no more than opts.Concurrency URLs are signed at once
+func TestObjectServiceGetPresignedURLs_Concurrency(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	var running, maxRunning atomic.Int32
+	mock.presignedGetObjectFunc = func(ctx context.Context, bucketName, objectName string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for current := maxRunning.Load(); n > current && !maxRunning.CompareAndSwap(current, n); current = maxRunning.Load() {
+		}
+		time.Sleep(time.Millisecond)
+		return url.Parse("https://mock-minio/" + bucketName + "/" + objectName)
+	}
+
+	keys := make([]string, 20)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("obj-%d", i)
+	}
+	urls, errs := svc.GetPresignedURLs(context.Background(), "test-bucket", keys, GetPresignedURLOptions{Method: http.MethodGet, Concurrency: 2})
+
+	if len(urls) != len(keys) || len(errs) != 0 {
+		t.Errorf("GetPresignedURLs() = %d URLs and %v, want %d URLs", len(urls), errs, len(keys))
+	}
+	if maxRunning.Load() > 2 {
+		t.Errorf("GetPresignedURLs() signed %d URLs at once, want at most 2", maxRunning.Load())
+	}
+}
+
+// TestObjectServiceGetPresignedURLs_CancelledMidway tests URLs signed before cancellation are returned
+func TestObjectServiceGetPresignedURLs_CancelledMidway(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signed := 0
+	mock.presignedGetObjectFunc = func(ctx context.Context, bucketName, objectName string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
+		if signed++; signed == 3 {
+			cancel()
+		}
+		return url.Parse("https://mock-minio/" + bucketName + "/" + objectName)
+	}
+
+	keys := []string{"a", "b", "c", "d", "e"}
+	urls, errs := svc.GetPresignedURLs(ctx, "test-bucket", keys, GetPresignedURLOptions{Method: http.MethodGet, Concurrency: 1})
+
+	if len(urls) != 3 || urls["a"] == nil || urls["c"] == nil {
+		t.Errorf("GetPresignedURLs() URLs = %v, want a, b and c", urls)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("GetPresignedURLs() returned %d errors, want 2", len(errs))
+	}
+	var objErr *ObjectError
+	if !errors.As(errs[0], &objErr) || objErr.Key != "d" || objErr.Message != context.Canceled.Error() {
+		t.Errorf("GetPresignedURLs() error = %v, want context canceled for d", errs[0])
+	}
+}
+
+// TestObjectServiceGetPresignedURLs_InvalidBucket tests bucket validation
+func TestObjectServiceGetPresignedURLs_InvalidBucket(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _ := newMockObjectService(t)
+	urls, errs := svc.GetPresignedURLs(context.Background(), "", []string{"a.jpg"}, GetPresignedURLOptions{Method: http.MethodGet})
+	if urls != nil {
+		t.Errorf("GetPresignedURLs() expected nil URLs, got %v", urls)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("GetPresignedURLs() returned %d errors, want 1", len(errs))
+	}
+	if _, ok := errs[0].(*InvalidBucketNameError); !ok {
+		t.Errorf("GetPresignedURLs() expected InvalidBucketNameError, got %T", errs[0])
+	}
+}
+
+// endlessListing returns a ListObjects implementation that produces objects until its
+// context is done, and a channel closed when the producer goroutine exits.
+func endlessListing(onSend func(n int)) (func(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo, <-chan struct{}) {
+	exited := make(chan struct{})
+	return func(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+		ch := make(chan minio.ObjectInfo)
+		go func() {
+			defer close(exited)
+			defer close(ch)
+			for n := 0; ; n++ {
+				select {
+				case ch <- minio.ObjectInfo{Key: fmt.Sprintf("obj-%d", n), Size: int64(n)}:
+					if onSend != nil {
+						onSend(n)
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return ch
+	}, exited
+}
+
+func waitProducerExit(t *testing.T, exited <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Error("listing goroutine still running after the consumer returned")
+	}
+}
+
+// TestObjectServiceList_StopsListingOnEarlyReturn tests that a limited listing releases the producer
+func TestObjectServiceList_StopsListingOnEarlyReturn(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	listFunc, exited := endlessListing(nil)
+	mock.listObjectsFunc = listFunc
+
+	limit := 3
+	objects, err := svc.List(context.Background(), "test-bucket", ObjectListOptions{Limit: &limit})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(objects) != limit {
+		t.Errorf("List() returned %d objects, want %d", len(objects), limit)
+	}
+
+	waitProducerExit(t, exited)
+}
+
+// TestObjectServiceListAll_ContextCancelledMidListing tests that cancelling mid-listing aborts cleanly
+func TestObjectServiceListAll_ContextCancelledMidListing(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listFunc, exited := endlessListing(func(n int) {
+		if n == 10 {
+			cancel()
+		}
+	})
+	mock.listObjectsFunc = listFunc
+
+	_, err := svc.ListAll(ctx, "test-bucket", ObjectFilterOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ListAll() expected context.Canceled, got %v", err)
+	}
+
+	waitProducerExit(t, exited)
+}
+
+// TestMockListObjects_RespectsCancellation tests that the default mock listing stops on cancellation
+func TestMockListObjects_RespectsCancellation(t *testing.T) {
+	t.Parallel()
+
+	mock := newMockMinioClient()
+	bucket := &mockBucket{name: "test-bucket", objects: make(map[string]*mockObject)}
+	for i := range 100 {
+		key := fmt.Sprintf("obj-%d", i)
+		bucket.objects[key] = &mockObject{key: key}
+	}
+	mock.buckets["test-bucket"] = bucket
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := mock.ListObjects(ctx, "test-bucket", minio.ListObjectsOptions{})
+	<-ch
+	cancel()
+
+	// The producer may complete the send it was blocked on, but no other
+	remaining := 0
+	for range ch {
+		remaining++
+	}
+	if remaining > 1 {
+		t.Errorf("mock listing produced %d objects after cancellation, want at most 1", remaining)
+	}
+}
+
+// TestObjectServiceUploadFromReader tests uploads from readers of unknown size
+func TestObjectServiceUploadFromReader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		bucket       string
+		key          string
+		data         io.Reader
+		opts         StreamOptions
+		wantPartSize uint64
+		wantType     string
+		wantErr      bool
+	}{
+		{
+			name:         "default part size",
+			bucket:       "test-bucket",
+			key:          "logs/app",
+			data:         strings.NewReader("line 1\nline 2\n"),
+			wantPartSize: DefaultStreamPartSize,
+			wantType:     "text/plain; charset=utf-8",
+		},
+		{
+			name:         "custom part size and content type",
+			bucket:       "test-bucket",
+			key:          "dump",
+			data:         strings.NewReader("payload"),
+			opts:         StreamOptions{PartSize: 64 * 1024 * 1024, ContentType: "application/octet-stream"},
+			wantPartSize: 64 * 1024 * 1024,
+			wantType:     "application/octet-stream",
+		},
+		{
+			name:    "part size below minimum",
+			bucket:  "test-bucket",
+			key:     "dump",
+			data:    strings.NewReader("payload"),
+			opts:    StreamOptions{PartSize: MinPartSize - 1},
+			wantErr: true,
+		},
+		{
+			name:    "nil reader",
+			bucket:  "test-bucket",
+			key:     "dump",
+			wantErr: true,
+		},
+		{
+			name:    "empty bucket",
+			key:     "dump",
+			data:    strings.NewReader("payload"),
+			wantErr: true,
+		},
+		{
+			name:    "empty key",
+			bucket:  "test-bucket",
+			data:    strings.NewReader("payload"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mock, _ := newMockObjectService(t)
+
+			info, err := svc.UploadFromReader(context.Background(), tt.bucket, tt.key, tt.data, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UploadFromReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if mock.lastObjectSize != -1 {
+				t.Errorf("PutObject() size = %d, want -1", mock.lastObjectSize)
+			}
+			if mock.lastPutOptions.PartSize != tt.wantPartSize {
+				t.Errorf("PutObject() part size = %d, want %d", mock.lastPutOptions.PartSize, tt.wantPartSize)
+			}
+			if mock.lastContentType != tt.wantType {
+				t.Errorf("PutObject() content type = %q, want %q", mock.lastContentType, tt.wantType)
+			}
+
+			stored := mock.buckets[tt.bucket].objects[tt.key]
+			if info.Size != int64(len(stored.data)) {
+				t.Errorf("UploadFromReader() size = %d, want %d", info.Size, len(stored.data))
+			}
+			if info.Key != tt.key || info.Bucket != tt.bucket {
+				t.Errorf("UploadFromReader() info = %+v", info)
+			}
+		})
+	}
+}
+
+// TestObjectServiceUploadStream_SniffKeepsReaderSeekable tests content sniffing rewinds seekable readers
+func TestObjectServiceUploadStream_SniffKeepsReaderSeekable(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	var gotSeekable bool
+	mock.putObjectFunc = func(ctx context.Context, bucketName string, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (minio.UploadInfo, error) {
+		_, gotSeekable = reader.(io.Seeker)
+		data, _ := io.ReadAll(reader)
+		if string(data) != "plain text content" {
+			t.Errorf("PutObject() data = %q", data)
+		}
+		return minio.UploadInfo{}, nil
+	}
+
+	err := svc.UploadStream(context.Background(), "test-bucket", "noext", strings.NewReader("plain text content"), 18, "")
+	if err != nil {
+		t.Fatalf("UploadStream() error = %v", err)
+	}
+	if !gotSeekable {
+		t.Error("UploadStream() expected the sniffed reader to remain seekable")
+	}
+}
+
+// keysChan returns a closed channel holding the given keys
+func keysChan(keys ...string) <-chan string {
+	ch := make(chan string, len(keys))
+	for _, key := range keys {
+		ch <- key
+	}
+	close(ch)
+	return ch
+}
+
+// TestObjectServiceRemoveObjectsStream tests keys are deleted and failures reported per key
+func TestObjectServiceRemoveObjectsStream(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	for _, key := range []string{"a", "b", "locked"} {
+		mock.buckets["test-bucket"].objects[key] = &mockObject{key: key}
+	}
+	mock.removeObjectFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error {
+		if objectName == "locked" {
+			return errors.New("object is locked")
+		}
+		delete(mock.buckets[bucketName].objects, objectName)
+		return nil
+	}
+
+	var got []ObjectError
+	for objErr := range svc.RemoveObjectsStream(context.Background(), "test-bucket", keysChan("a", "b", "locked")) {
+		got = append(got, objErr)
+	}
+
+	if len(got) != 1 || got[0].Key != "locked" || got[0].Operation != "delete" || got[0].Message != "object is locked" {
+		t.Fatalf("RemoveObjectsStream() errors = %+v, want one failure for locked", got)
+	}
+	if objects := mock.buckets["test-bucket"].objects; len(objects) != 1 || objects["locked"] == nil {
+		t.Errorf("RemoveObjectsStream() left objects %v, want only locked", objects)
+	}
+}
+
+// TestObjectServiceRemoveObjectsStream_InvalidBucket tests an empty bucket name is reported on the channel
+func TestObjectServiceRemoveObjectsStream_InvalidBucket(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _ := newMockObjectService(t)
+
+	var got []ObjectError
+	for objErr := range svc.RemoveObjectsStream(context.Background(), "", keysChan("a")) {
+		got = append(got, objErr)
+	}
+
+	if len(got) != 1 || got[0].Operation != "delete" {
+		t.Errorf("RemoveObjectsStream() errors = %+v, want one invalid bucket error", got)
+	}
+}
+
+// TestObjectServiceRemoveObjectsStream_ContextCanceled tests the stream stops when ctx is canceled
+func TestObjectServiceRemoveObjectsStream_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _ := newMockObjectService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	keys := make(chan string)
+
+	errCh := svc.RemoveObjectsStream(ctx, "test-bucket", keys)
+	keys <- "a"
+	cancel()
+
+	var got []ObjectError
+	for objErr := range errCh {
+		got = append(got, objErr)
+	}
+
+	if len(got) == 0 || got[len(got)-1].Message != context.Canceled.Error() {
+		t.Errorf("RemoveObjectsStream() errors = %+v, want a final context error", got)
+	}
+}
+
+// TestObjectServiceGetAfterPut tests the read waits for the object to have the expected ETag
+func TestObjectServiceGetAfterPut(t *testing.T) {
+	t.Parallel()
+
+	stale := minio.ObjectInfo{Key: "data.txt", ETag: "old"}
+	fresh := minio.ObjectInfo{Key: "data.txt", ETag: "new"}
+	notFound := minio.ErrorResponse{Code: "NoSuchKey", StatusCode: http.StatusNotFound}
+
+	tests := []struct {
+		name         string
+		stats        []any
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "already consistent", stats: []any{fresh}, wantAttempts: 1},
+		{name: "missing then written", stats: []any{notFound, notFound, fresh}, wantAttempts: 3},
+		{name: "stale then updated", stats: []any{stale, fresh}, wantAttempts: 2},
+		{name: "stays stale", stats: []any{stale}, wantAttempts: client.DefaultMaxAttempts, wantErr: &StaleReadError{}},
+		{name: "stays missing", stats: []any{notFound}, wantAttempts: client.DefaultMaxAttempts, wantErr: &ObjectNotFoundError{}},
+		{name: "stat failure", stats: []any{errors.New("access denied")}, wantAttempts: 1, wantErr: errors.New("")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockMinioClient()
+			fake := clock.NewFake(time.Now())
+			osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock), withClock(fake))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			attempts := 0
+			mock.statObjectFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.StatObjectOptions) (minio.ObjectInfo, error) {
+				stat := tt.stats[min(attempts, len(tt.stats)-1)]
+				attempts++
+				if err, ok := stat.(error); ok {
+					return minio.ObjectInfo{}, err
+				}
+				return stat.(minio.ObjectInfo), nil
+			}
+			var gotOpts minio.GetObjectOptions
+			mock.getObjectFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectOptions) (io.ReadCloser, minio.ObjectInfo, error) {
+				gotOpts = opts
+				return nil, minio.ObjectInfo{}, nil
+			}
+
+			_, err = osClient.Objects().GetAfterPut(context.Background(), "test-bucket", "data.txt", `"new"`)
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Fatalf("GetAfterPut() error = %v, want %T", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts || len(fake.Sleeps()) != tt.wantAttempts-1 {
+				t.Errorf("GetAfterPut() attempts = %d with %d backoffs, want %d", attempts, len(fake.Sleeps()), tt.wantAttempts)
+			}
+			if tt.wantErr == nil && gotOpts.Header().Get("If-Match") != `"new"` {
+				t.Errorf("GetAfterPut() If-Match = %q, want %q", gotOpts.Header().Get("If-Match"), `"new"`)
+			}
+		})
+	}
+}
+
+// TestObjectServiceDownload_MockData tests downloads read the data stored by uploads
+func TestObjectServiceDownload_MockData(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _ := newMockObjectService(t)
+	if err := svc.Upload(context.Background(), "test-bucket", "notes.txt", []byte("hello world"), "text/plain"); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	data, err := svc.Download(context.Background(), "test-bucket", "notes.txt", nil)
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if string(data) != "hello world" {
+		t.Errorf("Download() = %q, want %q", data, "hello world")
+	}
+
+	reader, err := svc.DownloadStream(context.Background(), "test-bucket", "notes.txt", nil)
+	if err != nil {
+		t.Fatalf("DownloadStream() error = %v", err)
+	}
+	streamed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(streamed) != "hello world" {
+		t.Errorf("DownloadStream() = %q, want %q", streamed, "hello world")
+	}
+
+	if _, err := svc.Download(context.Background(), "test-bucket", "missing.txt", nil); !isNotFound(err) {
+		t.Errorf("Download() error = %v, want NoSuchKey", err)
+	}
+}
+
+// TestMockGetObject_Range tests the mock serves the range set on the options
+func TestMockGetObject_Range(t *testing.T) {
+	t.Parallel()
+
+	_, mock, _ := newMockObjectService(t)
+	mock.buckets["test-bucket"].objects["video.mp4"] = &mockObject{key: "video.mp4", data: []byte("0123456789"), size: 10}
+
+	opts := minio.GetObjectOptions{}
+	if err := opts.SetRange(3, 5); err != nil {
+		t.Fatalf("SetRange() error = %v", err)
+	}
+	object, info, err := mock.GetObject(context.Background(), "test-bucket", "video.mp4", opts)
+	if err != nil {
+		t.Fatalf("GetObject() error = %v", err)
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(object)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(data) != "345" || info.Size != 3 {
+		t.Errorf("GetObject() = %q of size %d, want %q of size 3", data, info.Size, "345")
+	}
+}
+
+// TestObjectServiceDownloadRange tests byte ranges are written with the total object size
+func TestObjectServiceDownloadRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		start, end int64
+		want       string
+		wantErr    bool
+	}{
+		{name: "bounded range", start: 2, end: 5, want: "2345"},
+		{name: "open-ended range", start: 6, end: -1, want: "6789"},
+		{name: "whole object", start: 0, end: -1, want: "0123456789"},
+		{name: "end past object", start: 8, end: 100, want: "89"},
+		{name: "single byte", start: 0, end: 0, want: "0"},
+		{name: "start past object", start: 20, end: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _ := newMockObjectService(t)
+			if err := svc.Upload(context.Background(), "test-bucket", "video.mp4", []byte("0123456789"), "video/mp4"); err != nil {
+				t.Fatalf("Upload() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			metadata, err := svc.DownloadRange(context.Background(), "test-bucket", "video.mp4", tt.start, tt.end, &buf)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("DownloadRange() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DownloadRange() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("DownloadRange() wrote %q, want %q", buf.String(), tt.want)
+			}
+			if metadata.Size != 10 || metadata.Key != "video.mp4" || metadata.ContentType != "video/mp4" {
+				t.Errorf("DownloadRange() metadata = %+v, want video.mp4 of 10 bytes", metadata)
+			}
+		})
+	}
+}
+
+// TestObjectServiceDownloadRange_Errors tests invalid ranges and missing objects
+func TestObjectServiceDownloadRange_Errors(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _ := newMockObjectService(t)
+
+	for _, r := range [][2]int64{{-1, 5}, {5, 2}, {0, -2}} {
+		_, err := svc.DownloadRange(context.Background(), "test-bucket", "video.mp4", r[0], r[1], io.Discard)
+		var validErr *client.ValidationError
+		if !errors.As(err, &validErr) || validErr.Field != "range" {
+			t.Errorf("DownloadRange(%d, %d) error = %v, want ValidationError for range", r[0], r[1], err)
+		}
+	}
+
+	_, err := svc.DownloadRange(context.Background(), "test-bucket", "missing.mp4", 0, -1, io.Discard)
+	var notFound *ObjectNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("DownloadRange() error = %v, want ObjectNotFoundError", err)
+	}
+}
+
+// TestObjectServiceGetAfterPut_EmptyETag tests an ETag is required
+func TestObjectServiceGetAfterPut_EmptyETag(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _ := newMockObjectService(t)
+
+	_, err := svc.GetAfterPut(context.Background(), "test-bucket", "data.txt", "")
+	var validErr *client.ValidationError
+	if !errors.As(err, &validErr) || validErr.Field != "etag" {
+		t.Errorf("GetAfterPut() error = %v, want ValidationError for etag", err)
+	}
+}
+
+// TestObjectServiceRemovePrefix tests that only the keys under the prefix are deleted
+func TestObjectServiceRemovePrefix(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	for _, key := range []string{"logs/2023/a", "logs/2023/b", "logs/2023/locked", "logs/2024/c"} {
+		mock.buckets["test-bucket"].objects[key] = &mockObject{key: key}
+	}
+	// Deletions are recorded rather than applied, as the mock listing reads the same map
+	var mu sync.Mutex
+	var deleted []string
+	mock.removeObjectFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error {
+		if objectName == "logs/2023/locked" {
+			return errors.New("object is locked")
+		}
+		mu.Lock()
+		deleted = append(deleted, objectName)
+		mu.Unlock()
+		return nil
+	}
+
+	count, failed, err := svc.RemovePrefix(context.Background(), "test-bucket", "logs/2023/")
+	if err != nil {
+		t.Fatalf("RemovePrefix() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("RemovePrefix() count = %d, want 2", count)
+	}
+	if len(failed) != 1 || failed[0].Key != "logs/2023/locked" || failed[0].Message != "object is locked" {
+		t.Errorf("RemovePrefix() failures = %+v, want one for logs/2023/locked", failed)
+	}
+	sort.Strings(deleted)
+	if want := []string{"logs/2023/a", "logs/2023/b"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("RemovePrefix() deleted %v, want %v", deleted, want)
+	}
+}
+
+// TestObjectServiceRemovePrefix_EmptyPrefix tests an empty prefix never deletes the whole bucket
+func TestObjectServiceRemovePrefix_EmptyPrefix(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	mock.buckets["test-bucket"].objects["a"] = &mockObject{key: "a"}
+	mock.removeObjectFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error {
+		t.Errorf("unexpected deletion of %s", objectName)
+		return nil
+	}
+
+	_, _, err := svc.RemovePrefix(context.Background(), "test-bucket", "")
+	var validErr *client.ValidationError
+	if !errors.As(err, &validErr) || validErr.Field != "prefix" {
+		t.Errorf("RemovePrefix() error = %v, want ValidationError for prefix", err)
+	}
+}
+
+// TestObjectServiceRemovePrefix_ListingError tests a failed listing is returned after deleting the keys listed
+func TestObjectServiceRemovePrefix_ListingError(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	mock.listObjectsFunc = func(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+		ch := make(chan minio.ObjectInfo, 2)
+		ch <- minio.ObjectInfo{Key: "logs/a"}
+		ch <- minio.ObjectInfo{Err: errors.New("listing failed")}
+		close(ch)
+		return ch
+	}
+
+	count, failed, err := svc.RemovePrefix(context.Background(), "test-bucket", "logs/")
+	if err == nil || err.Error() != "listing failed" {
+		t.Errorf("RemovePrefix() error = %v, want listing failed", err)
+	}
+	if count != 1 || len(failed) != 0 {
+		t.Errorf("RemovePrefix() = %d, %v, want 1 and no failures", count, failed)
+	}
+}
+
+// TestObjectServiceRemovePrefix_Streams tests deletion starts before the listing ends
+func TestObjectServiceRemovePrefix_Streams(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	listFunc, exited := endlessListing(nil)
+	mock.listObjectsFunc = listFunc
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var removed atomic.Int64
+	mock.removeObjectFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error {
+		if removed.Add(1) == 100 {
+			cancel()
+		}
+		return nil
+	}
+
+	count, _, err := svc.RemovePrefix(ctx, "test-bucket", "obj-")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RemovePrefix() error = %v, want context.Canceled", err)
+	}
+	if want := int(removed.Load()); count != want || count < 100 {
+		t.Errorf("RemovePrefix() count = %d, want the %d confirmed deletions", count, want)
+	}
+
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatal("listing goroutine did not exit")
+	}
+}
+
+// TestObjectServicePreviewRemovePrefix tests the preview lists the keys under the prefix without deleting them
+func TestObjectServicePreviewRemovePrefix(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	objects := mock.buckets["test-bucket"].objects
+	objects["logs/2023/a"] = &mockObject{key: "logs/2023/a", size: 10}
+	objects["logs/2023/b"] = &mockObject{key: "logs/2023/b", size: 32}
+	objects["logs/2024/c"] = &mockObject{key: "logs/2024/c", size: 5}
+	mock.removeObjectFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error {
+		t.Errorf("unexpected deletion of %s", objectName)
+		return nil
+	}
+
+	preview, err := svc.PreviewRemovePrefix(context.Background(), "test-bucket", "logs/2023/")
+	if err != nil {
+		t.Fatalf("PreviewRemovePrefix() error = %v", err)
+	}
+	sort.Strings(preview.Keys)
+	if want := []string{"logs/2023/a", "logs/2023/b"}; !reflect.DeepEqual(preview.Keys, want) {
+		t.Errorf("PreviewRemovePrefix() keys = %v, want %v", preview.Keys, want)
+	}
+	if preview.TotalSize != 42 {
+		t.Errorf("PreviewRemovePrefix() total size = %d, want 42", preview.TotalSize)
+	}
+	if len(objects) != 3 {
+		t.Errorf("PreviewRemovePrefix() left %d objects, want 3", len(objects))
+	}
+}
+
+// TestObjectServicePreviewRemovePrefix_Errors tests the preview validates like RemovePrefix and reports listing errors
+func TestObjectServicePreviewRemovePrefix_Errors(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+
+	_, err := svc.PreviewRemovePrefix(context.Background(), "test-bucket", "")
+	var validErr *client.ValidationError
+	if !errors.As(err, &validErr) || validErr.Field != "prefix" {
+		t.Errorf("PreviewRemovePrefix() error = %v, want ValidationError for prefix", err)
+	}
+
+	mock.listObjectsFunc = func(ctx context.Context, bucketName string, opts minio.ListObjectsOptions) <-chan minio.ObjectInfo {
+		ch := make(chan minio.ObjectInfo, 1)
+		ch <- minio.ObjectInfo{Err: errors.New("listing failed")}
+		close(ch)
+		return ch
+	}
+	preview, err := svc.PreviewRemovePrefix(context.Background(), "test-bucket", "logs/")
+	if err == nil || err.Error() != "listing failed" || preview != nil {
+		t.Errorf("PreviewRemovePrefix() = %v, %v, want listing failed", preview, err)
+	}
+}
+
+// TestObjectServiceMove tests the source is copied to the destination and then removed
+func TestObjectServiceMove(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	mock.buckets["test-bucket"].objects["src.txt"] = &mockObject{key: "src.txt", data: []byte("hello"), size: 5}
+	var gotDst minio.CopyDestOptions
+	composeObject := mock.ComposeObject
+	mock.composeObjectFunc = func(ctx context.Context, dst minio.CopyDestOptions, srcs ...minio.CopySrcOptions) (minio.UploadInfo, error) {
+		gotDst = dst
+		mock.composeObjectFunc = nil
+		return composeObject(ctx, dst, srcs...)
+	}
+
+	if err := svc.Move(context.Background(), "test-bucket", "src.txt", "test-bucket", "dst.txt", MoveOptions{}); err != nil {
+		t.Fatalf("Move() error = %v", err)
+	}
+
+	objects := mock.buckets["test-bucket"].objects
+	if objects["src.txt"] != nil {
+		t.Error("Move() left the source object")
+	}
+	if dst := objects["dst.txt"]; dst == nil || string(dst.data) != "hello" {
+		t.Errorf("Move() destination = %+v, want copy of source", dst)
+	}
+	if gotDst.ReplaceMetadata || gotDst.ReplaceTags {
+		t.Errorf("Move() replaced metadata or tags by default: %+v", gotDst)
+	}
+}
+
+// TestObjectServiceMove_ReplaceMetadata tests UserMetadata replaces the source metadata
+func TestObjectServiceMove_ReplaceMetadata(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	var gotDst minio.CopyDestOptions
+	var gotSrc minio.CopySrcOptions
+	mock.composeObjectFunc = func(ctx context.Context, dst minio.CopyDestOptions, srcs ...minio.CopySrcOptions) (minio.UploadInfo, error) {
+		gotDst, gotSrc = dst, srcs[0]
+		return minio.UploadInfo{}, nil
+	}
+
+	opts := MoveOptions{SourceVersionID: "v1", UserMetadata: map[string]string{"owner": "ops"}}
+	if err := svc.Move(context.Background(), "test-bucket", "src.txt", "other-bucket", "dst.txt", opts); err != nil {
+		t.Fatalf("Move() error = %v", err)
+	}
+
+	if !gotDst.ReplaceMetadata || gotDst.UserMetadata["owner"] != "ops" || gotDst.Bucket != "other-bucket" {
+		t.Errorf("Move() destination options = %+v, want replaced metadata in other-bucket", gotDst)
+	}
+	if gotSrc.VersionID != "v1" {
+		t.Errorf("Move() source version = %q, want v1", gotSrc.VersionID)
+	}
+}
+
+// TestObjectServiceCopy tests the metadata directive decides whether the source metadata is kept
+func TestObjectServiceCopy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		srcKey      string
+		opts        CopyOptions
+		wantReplace bool
+		wantErr     any
+	}{
+		{name: "default keeps metadata", srcKey: "src.txt"},
+		{name: "copy keeps metadata", srcKey: "src.txt", opts: CopyOptions{MetadataDirective: MetadataDirectiveCopy}},
+		{
+			name:        "replace",
+			srcKey:      "src.txt",
+			opts:        CopyOptions{MetadataDirective: MetadataDirectiveReplace, UserMetadata: map[string]string{"owner": "ops"}},
+			wantReplace: true,
+		},
+		{
+			name:        "replace onto itself",
+			srcKey:      "dst.txt",
+			opts:        CopyOptions{MetadataDirective: MetadataDirectiveReplace, UserMetadata: map[string]string{}},
+			wantReplace: true,
+		},
+		{name: "copy onto itself", srcKey: "dst.txt", wantErr: new(*InvalidObjectKeyError)},
+		{
+			name:    "copy with metadata",
+			srcKey:  "src.txt",
+			opts:    CopyOptions{UserMetadata: map[string]string{"owner": "ops"}},
+			wantErr: new(*client.ValidationError),
+		},
+		{
+			name:    "replace without metadata",
+			srcKey:  "src.txt",
+			opts:    CopyOptions{MetadataDirective: MetadataDirectiveReplace},
+			wantErr: new(*client.ValidationError),
+		},
+		{name: "unknown directive", srcKey: "src.txt", opts: CopyOptions{MetadataDirective: "MERGE"}, wantErr: new(*client.ValidationError)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mock, _ := newMockObjectService(t)
+			var gotDst *minio.CopyDestOptions
+			mock.composeObjectFunc = func(ctx context.Context, dst minio.CopyDestOptions, srcs ...minio.CopySrcOptions) (minio.UploadInfo, error) {
+				gotDst = &dst
+				return minio.UploadInfo{}, nil
+			}
+
+			err := svc.Copy(context.Background(), "test-bucket", tt.srcKey, "test-bucket", "dst.txt", tt.opts)
+
+			if tt.wantErr != nil {
+				if !errors.As(err, tt.wantErr) {
+					t.Errorf("Copy() error = %v, want %T", err, tt.wantErr)
+				}
+				if gotDst != nil {
+					t.Error("Copy() sent an invalid copy")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Copy() error = %v", err)
+			}
+			if gotDst.ReplaceMetadata != tt.wantReplace || !reflect.DeepEqual(gotDst.UserMetadata, tt.opts.UserMetadata) {
+				t.Errorf("Copy() destination options = %+v", gotDst)
+			}
+		})
+	}
+}
+
+// TestObjectServiceCopy_SourceNotFound tests a missing source returns ObjectNotFoundError
+func TestObjectServiceCopy_SourceNotFound(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _ := newMockObjectService(t)
+	err := svc.Copy(context.Background(), "test-bucket", "missing.txt", "test-bucket", "dst.txt", CopyOptions{})
+
+	var notFound *ObjectNotFoundError
+	if !errors.As(err, &notFound) || notFound.Key != "missing.txt" {
+		t.Errorf("Copy() error = %v, want ObjectNotFoundError", err)
+	}
+}
+
+// TestObjectServiceCopy_BucketNotFound tests a missing bucket is reported by name, not as a missing source
+func TestObjectServiceCopy_BucketNotFound(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	mock.buckets["test-bucket"].objects["src.txt"] = &mockObject{key: "src.txt", data: []byte("hello"), size: 5}
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		srcBucket  string
+		dstBucket  string
+		wantBucket string
+	}{
+		{name: "missing destination", srcBucket: "test-bucket", dstBucket: "missing-bucket", wantBucket: "missing-bucket"},
+		{name: "missing source", srcBucket: "missing-bucket", dstBucket: "test-bucket", wantBucket: "missing-bucket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.Copy(ctx, tt.srcBucket, "src.txt", tt.dstBucket, "dst.txt", CopyOptions{})
+
+			var bucketErr *BucketNotFoundError
+			if !errors.As(err, &bucketErr) || bucketErr.Bucket != tt.wantBucket {
+				t.Errorf("Copy() error = %v, want BucketNotFoundError for %s", err, tt.wantBucket)
+			}
+		})
+	}
+}
+
+// TestObjectServiceCompose tests sources are concatenated in order, honoring byte ranges
+func TestObjectServiceCompose(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	day1 := bytes.Repeat([]byte("a"), MinPartSize)
+	objects := mock.buckets["test-bucket"].objects
+	objects["logs/day1"] = &mockObject{key: "logs/day1", data: day1, size: int64(len(day1))}
+	objects["logs/day2"] = &mockObject{key: "logs/day2", data: []byte("0123456789"), size: 10}
+
+	info, err := svc.Compose(context.Background(), "test-bucket", "logs/all", []ComposeSource{
+		{Bucket: "test-bucket", Key: "logs/day1"},
+		{Bucket: "test-bucket", Key: "logs/day2", Range: &ByteRange{Start: 2, End: 4}},
+	})
+	if err != nil {
+		t.Fatalf("Compose() error = %v", err)
+	}
+
+	if info.Key != "logs/all" || info.Size != MinPartSize+3 {
+		t.Errorf("Compose() info = %+v, want logs/all of %d bytes", info, MinPartSize+3)
+	}
+	if got := objects["logs/all"].data; !bytes.HasSuffix(got, []byte("234")) {
+		t.Errorf("Compose() data ends with %q, want 234", got[len(got)-3:])
+	}
+}
+
+// TestObjectServiceCompose_Errors tests invalid sources are rejected before composing
+func TestObjectServiceCompose_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		sources []ComposeSource
+		wantErr any
+	}{
+		{name: "no sources", wantErr: new(*client.ValidationError)},
+		{
+			name:    "small source",
+			sources: []ComposeSource{{Bucket: "test-bucket", Key: "small"}, {Bucket: "test-bucket", Key: "small"}},
+			wantErr: new(*InvalidObjectDataError),
+		},
+		{
+			name:    "small range",
+			sources: []ComposeSource{{Bucket: "test-bucket", Key: "small", Range: &ByteRange{Start: 0, End: 1}}, {Bucket: "test-bucket", Key: "small"}},
+			wantErr: new(*InvalidObjectDataError),
+		},
+		{
+			name:    "invalid range",
+			sources: []ComposeSource{{Bucket: "test-bucket", Key: "small", Range: &ByteRange{Start: 5, End: 1}}},
+			wantErr: new(*client.ValidationError),
+		},
+		{
+			name:    "missing source",
+			sources: []ComposeSource{{Bucket: "test-bucket", Key: "missing"}, {Bucket: "test-bucket", Key: "small"}},
+			wantErr: new(*ObjectNotFoundError),
+		},
+		{name: "missing last source", sources: []ComposeSource{{Bucket: "test-bucket", Key: "missing"}}, wantErr: new(*ObjectNotFoundError)},
+		{name: "invalid key", sources: []ComposeSource{{Bucket: "test-bucket"}}, wantErr: new(*InvalidObjectKeyError)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mock, _ := newMockObjectService(t)
+			mock.buckets["test-bucket"].objects["small"] = &mockObject{key: "small", data: []byte("tiny"), size: 4}
+
+			_, err := svc.Compose(context.Background(), "test-bucket", "composed", tt.sources)
+			if !errors.As(err, tt.wantErr) {
+				t.Errorf("Compose() error = %v, want %T", err, tt.wantErr)
+			}
+			if _, exists := mock.buckets["test-bucket"].objects["composed"]; exists {
+				t.Error("Compose() created the destination")
+			}
+		})
+	}
+}
+
+// TestObjectServiceMove_CopyFails tests the source is kept when the copy fails
+func TestObjectServiceMove_CopyFails(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	mock.buckets["test-bucket"].objects["src.txt"] = &mockObject{key: "src.txt"}
+	mock.composeObjectFunc = func(ctx context.Context, dst minio.CopyDestOptions, srcs ...minio.CopySrcOptions) (minio.UploadInfo, error) {
+		return minio.UploadInfo{}, errors.New("access denied")
+	}
+	removed := false
+	mock.removeObjectFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error {
+		removed = true
+		return nil
+	}
+
+	if err := svc.Move(context.Background(), "test-bucket", "src.txt", "test-bucket", "dst.txt", MoveOptions{}); err == nil {
+		t.Fatal("Move() expected error, got nil")
+	}
+	if removed {
+		t.Error("Move() removed the source after a failed copy")
+	}
+}
+
+// TestObjectServiceMove_Errors tests validation, missing sources and failed source removal
+func TestObjectServiceMove_Errors(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	mock.buckets["test-bucket"].objects["locked.txt"] = &mockObject{key: "locked.txt"}
+	mock.removeObjectFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error {
+		return errors.New("object is locked")
+	}
+	ctx := context.Background()
+
+	var bucketErr *InvalidBucketNameError
+	if err := svc.Move(ctx, "", "src.txt", "test-bucket", "dst.txt", MoveOptions{}); !errors.As(err, &bucketErr) {
+		t.Errorf("Move() with empty bucket error = %v, want InvalidBucketNameError", err)
+	}
+	var keyErr *InvalidObjectKeyError
+	if err := svc.Move(ctx, "test-bucket", "src.txt", "test-bucket", "src.txt", MoveOptions{}); !errors.As(err, &keyErr) {
+		t.Errorf("Move() onto itself error = %v, want InvalidObjectKeyError", err)
+	}
+	var notFound *ObjectNotFoundError
+	if err := svc.Move(ctx, "test-bucket", "missing.txt", "test-bucket", "dst.txt", MoveOptions{}); !errors.As(err, &notFound) {
+		t.Errorf("Move() of missing source error = %v, want ObjectNotFoundError", err)
+	}
+	var missingBucket *BucketNotFoundError
+	if err := svc.Move(ctx, "test-bucket", "locked.txt", "missing-bucket", "dst.txt", MoveOptions{}); !errors.As(err, &missingBucket) || missingBucket.Bucket != "missing-bucket" {
+		t.Errorf("Move() to missing bucket error = %v, want BucketNotFoundError for missing-bucket", err)
+	}
+	var objErr *ObjectError
+	if err := svc.Move(ctx, "test-bucket", "locked.txt", "test-bucket", "dst.txt", MoveOptions{}); !errors.As(err, &objErr) || objErr.Operation != "delete" {
+		t.Errorf("Move() with failed removal error = %v, want delete ObjectError", err)
+	}
+}
+
+// TestObjectServiceGetRetention tests the retention is returned with the time remaining
+func TestObjectServiceGetRetention(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mode := minio.Compliance
+	retainUntil := now.Add(48 * time.Hour)
+	expiredUntil := now.Add(-time.Hour)
+
+	mock := newMockMinioClient()
+	mock.buckets["test-bucket"] = &mockBucket{
+		name: "test-bucket",
+		objects: map[string]*mockObject{
+			"locked.txt":   {key: "locked.txt", retention: &mockObjectRetention{mode: &mode, retainUntilDate: &retainUntil}},
+			"expired.txt":  {key: "expired.txt", retention: &mockObjectRetention{mode: &mode, retainUntilDate: &expiredUntil}},
+			"unlocked.txt": {key: "unlocked.txt"},
+		},
+	}
+	osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock), withClock(clock.NewFake(now)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	svc := osClient.Objects()
+	ctx := context.Background()
+
+	info, err := svc.GetRetention(ctx, "test-bucket", "locked.txt")
+	if err != nil {
+		t.Fatalf("GetRetention() error = %v", err)
+	}
+	if info.Mode != RetentionModeCompliance || !info.RetainUntil.Equal(retainUntil) || info.Remaining != 48*time.Hour {
+		t.Errorf("GetRetention() = %+v, want COMPLIANCE until %v with 48h remaining", info, retainUntil)
+	}
+
+	info, err = svc.GetRetention(ctx, "test-bucket", "expired.txt")
+	if err != nil {
+		t.Fatalf("GetRetention() error = %v", err)
+	}
+	if info.Remaining != 0 {
+		t.Errorf("GetRetention() remaining = %v, want 0 for a past retention", info.Remaining)
+	}
+
+	var noRetention *NoRetentionError
+	if _, err := svc.GetRetention(ctx, "test-bucket", "unlocked.txt"); !errors.As(err, &noRetention) {
+		t.Errorf("GetRetention() error = %v, want NoRetentionError", err)
+	}
+}
+
+// TestObjectServiceGetRetention_Errors tests MinIO errors are mapped to typed errors
+func TestObjectServiceGetRetention_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr any
+	}{
+		{
+			name:    "no lock configuration",
+			err:     minio.ErrorResponse{Code: "NoSuchObjectLockConfiguration", StatusCode: http.StatusNotFound},
+			wantErr: new(*NoRetentionError),
+		},
+		{
+			name:    "object not found",
+			err:     minio.ErrorResponse{Code: "NoSuchKey", StatusCode: http.StatusNotFound},
+			wantErr: new(*ObjectNotFoundError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mock, _ := newMockObjectService(t)
+			mock.getObjectRetentionFunc = func(ctx context.Context, bucketName string, objectName string, versionID string) (*minio.RetentionMode, *time.Time, error) {
+				return nil, nil, tt.err
+			}
+
+			_, err := svc.GetRetention(context.Background(), "test-bucket", "file.txt")
+			if !errors.As(err, tt.wantErr) {
+				t.Errorf("GetRetention() error = %v, want %T", err, tt.wantErr)
+			}
+		})
+	}
+
+	svc, _, _ := newMockObjectService(t)
+	var keyErr *InvalidObjectKeyError
+	if _, err := svc.GetRetention(context.Background(), "test-bucket", ""); !errors.As(err, &keyErr) {
+		t.Errorf("GetRetention() error = %v, want InvalidObjectKeyError", err)
+	}
+}
+
+// TestObjectServiceDelete_RetentionLocked tests deletes of locked versions fail before reaching the backend
+func TestObjectServiceDelete_RetentionLocked(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	future := now.Add(24 * time.Hour)
+	past := now.Add(-time.Hour)
+	governance, compliance := minio.Governance, minio.Compliance
+	legalHold := minio.LegalHoldEnabled
+
+	tests := []struct {
+		name        string
+		retention   *mockObjectRetention
+		legalHold   *minio.LegalHoldStatus
+		lookupErr   error
+		opts        *DeleteOptions
+		wantMessage string
+	}{
+		{name: "no retention", opts: &DeleteOptions{VersionID: "v1"}},
+		{name: "expired retention", retention: &mockObjectRetention{mode: &compliance, retainUntilDate: &past}, opts: &DeleteOptions{VersionID: "v1"}},
+		{
+			name:        "governance retention",
+			retention:   &mockObjectRetention{mode: &governance, retainUntilDate: &future},
+			opts:        &DeleteOptions{VersionID: "v1"},
+			wantMessage: "locked by GOVERNANCE retention until 2024-01-02T00:00:00Z",
+		},
+		{
+			name:      "governance retention bypassed",
+			retention: &mockObjectRetention{mode: &governance, retainUntilDate: &future},
+			opts:      &DeleteOptions{VersionID: "v1", BypassGovernance: true},
+		},
+		{
+			name:        "compliance retention cannot be bypassed",
+			retention:   &mockObjectRetention{mode: &compliance, retainUntilDate: &future},
+			opts:        &DeleteOptions{VersionID: "v1", BypassGovernance: true},
+			wantMessage: "locked by COMPLIANCE retention until 2024-01-02T00:00:00Z",
+		},
+		{name: "legal hold", legalHold: &legalHold, opts: &DeleteOptions{VersionID: "v1", BypassGovernance: true}, wantMessage: "locked by legal hold"},
+		{name: "lock lookup fails", lookupErr: errors.New("bucket is missing object lock configuration"), opts: &DeleteOptions{VersionID: "v1"}},
+		{name: "delete marker is not checked", retention: &mockObjectRetention{mode: &compliance, retainUntilDate: &future}, legalHold: &legalHold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockMinioClient()
+			mock.buckets["test-bucket"] = &mockBucket{
+				name:    "test-bucket",
+				objects: map[string]*mockObject{"file.txt": {key: "file.txt", retention: tt.retention}},
+			}
+			mock.getLegalHoldFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.GetObjectLegalHoldOptions) (*minio.LegalHoldStatus, error) {
+				return tt.legalHold, tt.lookupErr
+			}
+			if tt.lookupErr != nil {
+				mock.getObjectRetentionFunc = func(ctx context.Context, bucketName string, objectName string, versionID string) (*minio.RetentionMode, *time.Time, error) {
+					return nil, nil, tt.lookupErr
+				}
+			}
+			var removeOpts *minio.RemoveObjectOptions
+			mock.removeObjectFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error {
+				removeOpts = &opts
+				return nil
+			}
+
+			osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock), withClock(clock.NewFake(now)))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			err = osClient.Objects().Delete(context.Background(), "test-bucket", "file.txt", tt.opts)
+
+			if tt.wantMessage == "" {
+				if err != nil {
+					t.Fatalf("Delete() error = %v", err)
+				}
+				if removeOpts == nil {
+					t.Fatal("Delete() expected the object to be removed")
+				}
+				if tt.opts != nil && removeOpts.GovernanceBypass != tt.opts.BypassGovernance {
+					t.Errorf("Delete() GovernanceBypass = %v, want %v", removeOpts.GovernanceBypass, tt.opts.BypassGovernance)
+				}
+				return
+			}
+
+			var objErr *ObjectError
+			if !errors.As(err, &objErr) || objErr.Operation != "delete" || objErr.Message != tt.wantMessage {
+				t.Errorf("Delete() error = %v, want delete ObjectError %q", err, tt.wantMessage)
+			}
+			if removeOpts != nil {
+				t.Error("Delete() removed a locked object")
+			}
+		})
+	}
+}
+
+// TestObjectServiceDelete_IfUnmodifiedSince tests conditional deletes keep objects modified after the given time
+func TestObjectServiceDelete_IfUnmodifiedSince(t *testing.T) {
+	t.Parallel()
+
+	lastModified := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		key     string
+		since   time.Time
+		wantErr any
+	}{
+		{name: "unmodified", key: "file.txt", since: lastModified.Add(time.Minute)},
+		{name: "modified at the given time", key: "file.txt", since: lastModified},
+		{name: "modified after", key: "file.txt", since: lastModified.Add(-time.Minute), wantErr: new(*ObjectModifiedError)},
+		{name: "missing object", key: "missing.txt", since: lastModified, wantErr: new(*ObjectNotFoundError)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockMinioClient()
+			mock.buckets["test-bucket"] = &mockBucket{
+				name:    "test-bucket",
+				objects: map[string]*mockObject{"file.txt": {key: "file.txt", lastModified: lastModified}},
+			}
+			removed := false
+			mock.removeObjectFunc = func(ctx context.Context, bucketName string, objectName string, opts minio.RemoveObjectOptions) error {
+				removed = true
+				return nil
+			}
+			osClient, err := New(client.NewMgcClient(), "minioadmin", "minioadmin", WithMinioClientInterface(mock))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			err = osClient.Objects().Delete(context.Background(), "test-bucket", tt.key, &DeleteOptions{IfUnmodifiedSince: tt.since})
+
+			if tt.wantErr == nil {
+				if err != nil || !removed {
+					t.Errorf("Delete() error = %v, removed = %v", err, removed)
+				}
+				return
+			}
+			if !errors.As(err, tt.wantErr) {
+				t.Errorf("Delete() error = %v, want %T", err, tt.wantErr)
+			}
+			if removed {
+				t.Error("Delete() removed the object")
+			}
+		})
+	}
+}
+
+// TestObjectServiceUpload_MultipartThreshold tests uploads switch to multipart from the configured threshold
+func TestObjectServiceUpload_MultipartThreshold(t *testing.T) {
+	t.Parallel()
+
+	const partSize = MinPartSize
+	tests := []struct {
+		name          string
+		size          int
+		wantMultipart bool
+	}{
+		{name: "below threshold", size: 1024, wantMultipart: false},
+		{name: "at threshold", size: 2048, wantMultipart: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mock, _ := newMockObjectService(t, WithUploadOptions(UploadOptions{MultipartThreshold: 2048, PartSize: partSize}))
+
+			data := bytes.Repeat([]byte("a"), tt.size)
+			if err := svc.Upload(context.Background(), "test-bucket", "data.bin", data, ""); err != nil {
+				t.Fatalf("Upload() error = %v", err)
+			}
+
+			opts := mock.lastPutOptions
+			if opts.DisableMultipart == tt.wantMultipart {
+				t.Errorf("DisableMultipart = %v, want %v", opts.DisableMultipart, !tt.wantMultipart)
+			}
+			if tt.wantMultipart && opts.PartSize != partSize {
+				t.Errorf("PartSize = %d, want %d", opts.PartSize, partSize)
+			}
+		})
+	}
+}
+
+// TestObjectServiceUploadFromReader_UserMetadata tests user metadata is stored and returned by Stat
+func TestObjectServiceUploadFromReader_UserMetadata(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	metadata := map[string]string{"owner": "finance", "X-Amz-Meta-Project": "billing"}
+
+	_, err := svc.UploadFromReader(context.Background(), "test-bucket", "report.csv", strings.NewReader("id,total\n"), StreamOptions{UserMetadata: metadata})
+	if err != nil {
+		t.Fatalf("UploadFromReader() error = %v", err)
+	}
+	if !reflect.DeepEqual(mock.lastPutOptions.UserMetadata, metadata) {
+		t.Errorf("PutObject() user metadata = %v, want %v", mock.lastPutOptions.UserMetadata, metadata)
+	}
+
+	stat, err := svc.Stat(context.Background(), "test-bucket", "report.csv")
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	want := map[string]string{"Owner": "finance", "Project": "billing"}
+	if !reflect.DeepEqual(stat.UserMetadata, want) {
+		t.Errorf("Stat() user metadata = %v, want %v", stat.UserMetadata, want)
+	}
+}
+
+// TestObjectServiceUploadFromReader_Headers tests Cache-Control and Content-Disposition are set on upload
+func TestObjectServiceUploadFromReader_Headers(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t)
+	opts := StreamOptions{CacheControl: "public, max-age=3600", ContentDisposition: `attachment; filename="report.csv"`}
+
+	if _, err := svc.UploadFromReader(context.Background(), "test-bucket", "report.csv", strings.NewReader("id\n"), opts); err != nil {
+		t.Fatalf("UploadFromReader() error = %v", err)
+	}
+	if mock.lastPutOptions.CacheControl != opts.CacheControl || mock.lastPutOptions.ContentDisposition != opts.ContentDisposition {
+		t.Errorf("PutObject() options = %+v, want %+v", mock.lastPutOptions, opts)
+	}
+
+	obj := mock.buckets["test-bucket"].objects["report.csv"]
+	if obj.metadata.Get("Cache-Control") != opts.CacheControl || obj.metadata.Get("Content-Disposition") != opts.ContentDisposition {
+		t.Errorf("stored headers = %v, want Cache-Control and Content-Disposition", obj.metadata)
+	}
+
+	for _, opts := range []StreamOptions{{CacheControl: "max-age=3600;"}, {ContentDisposition: "attachment; filename"}} {
+		_, err := svc.UploadFromReader(context.Background(), "test-bucket", "report.csv", strings.NewReader("id\n"), opts)
+		var validErr *client.ValidationError
+		if !errors.As(err, &validErr) {
+			t.Errorf("UploadFromReader(%+v) error = %v, want ValidationError", opts, err)
+		}
+	}
+}
+
+// TestValidateCacheControl tests Cache-Control values must be lists of directives
+func TestValidateCacheControl(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{"", "no-store", "public, max-age=3600", "private,no-cache", `no-cache="Set-Cookie"`, "s-maxage=60, stale-while-revalidate=30",
+		`private="Set-Cookie, X-Foo", max-age=60`, `no-cache="a\"b, c"`}
+	for _, value := range valid {
+		if err := validateCacheControl(value); err != nil {
+			t.Errorf("validateCacheControl(%q) error = %v", value, err)
+		}
+	}
+
+	invalid := []string{"max-age=", "public,,max-age=1", "max age=10", "max-age=3600;", `no-cache="unterminated`, "=1", `no-cache="a"b"`, `private="Set-Cookie, X-Foo`}
+	for _, value := range invalid {
+		var validErr *client.ValidationError
+		if err := validateCacheControl(value); !errors.As(err, &validErr) || validErr.Field != "cache_control" {
+			t.Errorf("validateCacheControl(%q) error = %v, want ValidationError for cache_control", value, err)
+		}
+	}
+}
+
+// TestValidateUserMetadata tests user metadata keys must be header names that are not reserved
+func TestValidateUserMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "plain key", key: "owner"},
+		{name: "prefixed key", key: "x-amz-meta-owner"},
+		{name: "token characters", key: "app.version_2"},
+		{name: "space", key: "my key", wantErr: true},
+		{name: "colon", key: "owner:", wantErr: true},
+		{name: "non-ascii", key: "proprietário", wantErr: true},
+		{name: "empty", key: "", wantErr: true},
+		{name: "prefix only", key: "X-Amz-Meta-", wantErr: true},
+		{name: "s3 header", key: "x-amz-acl", wantErr: true},
+		{name: "minio header", key: "X-Minio-Internal", wantErr: true},
+		{name: "content header", key: "Content-Type", wantErr: true},
+		{name: "cache control", key: "cache-control", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUserMetadata(map[string]string{tt.key: "value"})
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("validateUserMetadata(%q) error = %v", tt.key, err)
+				}
+				return
+			}
+			var validErr *client.ValidationError
+			if !errors.As(err, &validErr) || validErr.Field != "user_metadata" {
+				t.Errorf("validateUserMetadata(%q) error = %v, want ValidationError for user_metadata", tt.key, err)
+			}
+		})
+	}
+}
+
+// TestObjectServiceUploadFromReader_ClientPartSize tests the client part size is the stream default
+func TestObjectServiceUploadFromReader_ClientPartSize(t *testing.T) {
+	t.Parallel()
+
+	svc, mock, _ := newMockObjectService(t, WithUploadOptions(UploadOptions{PartSize: 32 * 1024 * 1024}))
+
+	_, err := svc.UploadFromReader(context.Background(), "test-bucket", "data.txt", strings.NewReader("data"), StreamOptions{})
+	if err != nil {
+		t.Fatalf("UploadFromReader() error = %v", err)
+	}
+	if mock.lastPutOptions.PartSize != 32*1024*1024 {
+		t.Errorf("PartSize = %d, want %d", mock.lastPutOptions.PartSize, 32*1024*1024)
+	}
+}
+
+// TestObjectServiceUpload_SendContentMD5 tests every upload sends Content-MD5 when enabled
+func TestObjectServiceUpload_SendContentMD5(t *testing.T) {
+	t.Parallel()
+
+	uploads := map[string]func(svc ObjectService) error{
+		"Upload": func(svc ObjectService) error {
+			return svc.Upload(context.Background(), "test-bucket", "data.txt", []byte("data"), "text/plain")
+		},
+		"UploadStream": func(svc ObjectService) error {
+			return svc.UploadStream(context.Background(), "test-bucket", "data.txt", strings.NewReader("data"), 4, "text/plain")
+		},
+		"UploadStream multipart": func(svc ObjectService) error {
+			return svc.UploadStream(context.Background(), "test-bucket", "data.txt", strings.NewReader("data"), DefaultMultipartThreshold, "text/plain")
+		},
+		"UploadFromReader": func(svc ObjectService) error {
+			_, err := svc.UploadFromReader(context.Background(), "test-bucket", "data.txt", strings.NewReader("data"), StreamOptions{})
+			return err
+		},
+	}
+
+	for name, upload := range uploads {
+		for _, enabled := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/%v", name, enabled), func(t *testing.T) {
+				svc, mock, _ := newMockObjectService(t, WithUploadOptions(UploadOptions{SendContentMD5: enabled}))
+
+				if err := upload(svc); err != nil {
+					t.Fatalf("upload error = %v", err)
+				}
+				if mock.lastPutOptions.SendContentMd5 != enabled {
+					t.Errorf("SendContentMd5 = %v, want %v", mock.lastPutOptions.SendContentMd5, enabled)
+				}
+			})
+		}
+	}
+}
+
+// addIncompleteUploads stores in "test-bucket" incomplete uploads started 3h, 2h and 10m before now.
+func addIncompleteUploads(mock *mockMinioClient, now time.Time) {
+	mock.buckets["test-bucket"].uploads = []minio.ObjectMultipartInfo{
+		{Key: "backups/db.tar", UploadID: "old-1", Initiated: now.Add(-3 * time.Hour)},
+		{Key: "backups/db.tar", UploadID: "old-2", Initiated: now.Add(-2 * time.Hour)},
+		{Key: "media/video.mp4", UploadID: "recent", Initiated: now.Add(-10 * time.Minute), Size: 1024},
+	}
+}
+
+// TestObjectServiceListIncompleteUploads tests listing incomplete uploads by prefix
+func TestObjectServiceListIncompleteUploads(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	svc, mock, _ := newMockObjectService(t, withClock(clock.NewFake(now)))
+	addIncompleteUploads(mock, now)
+	ctx := context.Background()
+
+	uploads, err := svc.ListIncompleteUploads(ctx, "test-bucket", "")
+	if err != nil {
+		t.Fatalf("ListIncompleteUploads() error = %v", err)
+	}
+	if len(uploads) != 3 {
+		t.Fatalf("ListIncompleteUploads() returned %d uploads, want 3", len(uploads))
+	}
+
+	uploads, err = svc.ListIncompleteUploads(ctx, "test-bucket", "media/")
+	if err != nil {
+		t.Fatalf("ListIncompleteUploads() error = %v", err)
+	}
+	want := []IncompleteUpload{{Key: "media/video.mp4", UploadID: "recent", Initiated: now.Add(-10 * time.Minute), Size: 1024}}
+	if !reflect.DeepEqual(uploads, want) {
+		t.Errorf("ListIncompleteUploads() = %+v, want %+v", uploads, want)
+	}
+
+	if _, err := svc.ListIncompleteUploads(ctx, "missing-bucket", ""); minio.ToErrorResponse(err).Code != "NoSuchBucket" {
+		t.Errorf("ListIncompleteUploads() error = %v, want NoSuchBucket", err)
+	}
+	if _, err := svc.ListIncompleteUploads(ctx, "", ""); !errors.As(err, new(*InvalidBucketNameError)) {
+		t.Errorf("ListIncompleteUploads() error = %v, want InvalidBucketNameError", err)
+	}
+}
+
+// TestObjectServiceAbortIncompleteUpload tests that only the given upload is aborted
+func TestObjectServiceAbortIncompleteUpload(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	svc, mock, _ := newMockObjectService(t, withClock(clock.NewFake(now)))
+	addIncompleteUploads(mock, now)
+	ctx := context.Background()
+
+	if err := svc.AbortIncompleteUpload(ctx, "test-bucket", "backups/db.tar", "old-1"); err != nil {
+		t.Fatalf("AbortIncompleteUpload() error = %v", err)
+	}
+	uploads := mock.buckets["test-bucket"].uploads
+	if len(uploads) != 2 || uploads[0].UploadID != "old-2" {
+		t.Errorf("remaining uploads = %+v, want old-2 and recent", uploads)
+	}
+
+	err := svc.AbortIncompleteUpload(ctx, "test-bucket", "backups/db.tar", "old-1")
+	var notFound *UploadNotFoundError
+	if !errors.As(err, &notFound) || notFound.UploadID != "old-1" {
+		t.Errorf("AbortIncompleteUpload() error = %v, want UploadNotFoundError", err)
+	}
+
+	var validErr *client.ValidationError
+	if err := svc.AbortIncompleteUpload(ctx, "test-bucket", "backups/db.tar", ""); !errors.As(err, &validErr) || validErr.Field != "uploadID" {
+		t.Errorf("AbortIncompleteUpload() error = %v, want ValidationError for uploadID", err)
+	}
+}
+
+// TestObjectServiceAbortUploadsOlderThan tests the cleanup of stale uploads
+func TestObjectServiceAbortUploadsOlderThan(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	svc, mock, _ := newMockObjectService(t, withClock(clock.NewFake(now)))
+	addIncompleteUploads(mock, now)
+	// old-2 completes while the cleanup runs
+	mock.abortUploadFunc = func(ctx context.Context, bucketName, objectName, uploadID string) error {
+		if uploadID == "old-2" {
+			return minio.ErrorResponse{Code: "NoSuchUpload", StatusCode: http.StatusNotFound}
+		}
+		return nil
+	}
+
+	aborted, err := svc.AbortUploadsOlderThan(context.Background(), "test-bucket", time.Hour)
+	if err != nil {
+		t.Fatalf("AbortUploadsOlderThan() error = %v", err)
+	}
+	if aborted != 1 {
+		t.Errorf("AbortUploadsOlderThan() = %d, want 1", aborted)
+	}
+}
+
+// TestObjectServiceAbortUploadsOlderThan_Errors tests that cleanup stops at the first failure
+func TestObjectServiceAbortUploadsOlderThan_Errors(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	svc, mock, _ := newMockObjectService(t, withClock(clock.NewFake(now)))
+	addIncompleteUploads(mock, now)
+	mock.abortUploadFunc = func(ctx context.Context, bucketName, objectName, uploadID string) error {
+		if uploadID == "old-2" {
+			return minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden}
+		}
+		return nil
+	}
+	ctx := context.Background()
+
+	aborted, err := svc.AbortUploadsOlderThan(ctx, "test-bucket", time.Hour)
+	if minio.ToErrorResponse(err).Code != "AccessDenied" || aborted != 1 {
+		t.Errorf("AbortUploadsOlderThan() = %d, %v, want 1 and AccessDenied", aborted, err)
+	}
+
+	var validErr *client.ValidationError
+	if _, err := svc.AbortUploadsOlderThan(ctx, "test-bucket", 0); !errors.As(err, &validErr) || validErr.Field != "age" {
+		t.Errorf("AbortUploadsOlderThan() error = %v, want ValidationError for age", err)
+	}
+}
+
+// TestObjectServiceAbortIncompleteUpload_Instrumented tests aborts through the instrumented client, which WithLogger installs
+func TestObjectServiceAbortIncompleteUpload_Instrumented(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	now := time.Now()
+	svc, mock, _ := newMockObjectService(t, withClock(clock.NewFake(now)), WithLogger(logger))
+	addIncompleteUploads(mock, now)
+
+	if err := svc.AbortIncompleteUpload(context.Background(), "test-bucket", "media/video.mp4", "recent"); err != nil {
+		t.Fatalf("AbortIncompleteUpload() error = %v", err)
+	}
+	if len(mock.buckets["test-bucket"].uploads) != 2 {
+		t.Errorf("remaining uploads = %+v, want 2", mock.buckets["test-bucket"].uploads)
+	}
+	if !strings.Contains(buf.String(), `"operation":"AbortMultipartUpload"`) || !strings.Contains(buf.String(), `"upload_id":"recent"`) {
+		t.Errorf("log = %s, want AbortMultipartUpload entry", buf.String())
+	}
+}
+
+// TestAbortMultipartUpload_MinioClient tests that a MinIO client aborts a single upload by ID
+func TestAbortMultipartUpload_MinioClient(t *testing.T) {
+	t.Parallel()
+
+	var gotMethod, gotPath, gotUploadID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("location") {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">br-se1</LocationConstraint>`))
+			return
+		}
+		gotMethod, gotPath, gotUploadID = r.Method, r.URL.Path, r.URL.Query().Get("uploadId")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	osClient, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := osClient.Objects().AbortIncompleteUpload(context.Background(), "test-bucket", "backups/db.tar", "old-1"); err != nil {
+		t.Fatalf("AbortIncompleteUpload() error = %v", err)
+	}
+	if gotMethod != http.MethodDelete || gotPath != "/test-bucket/backups/db.tar" || gotUploadID != "old-1" {
+		t.Errorf("request = %s %s?uploadId=%s, want DELETE /test-bucket/backups/db.tar?uploadId=old-1", gotMethod, gotPath, gotUploadID)
+	}
+}
+
+// TestDownloadRange_MinioClient tests that a MinIO client sends the range and reads the total size
+func TestDownloadRange_MinioClient(t *testing.T) {
+	t.Parallel()
+
+	var gotRange string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("location") {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">br-se1</LocationConstraint>`))
+			return
+		}
+		gotRange = r.Header.Get("Range")
+		w.Header().Set("Content-Type", "video/mp4")
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Header().Set("Content-Range", "bytes 100-103/5000")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	osClient, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	metadata, err := osClient.Objects().DownloadRange(context.Background(), "test-bucket", "video.mp4", 100, -1, &buf)
+	if err != nil {
+		t.Fatalf("DownloadRange() error = %v", err)
+	}
+	if gotRange != "bytes=100-" {
+		t.Errorf("Range = %q, want %q", gotRange, "bytes=100-")
+	}
+	if buf.String() != "data" || metadata.Size != 5000 || metadata.ETag != "etag" {
+		t.Errorf("DownloadRange() wrote %q with metadata %+v, want data of a 5000 byte object", buf.String(), metadata)
+	}
+}
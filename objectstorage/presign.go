@@ -0,0 +1,115 @@
+package objectstorage
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// presignAlgorithm is the only signing algorithm used by presigned URLs.
+	presignAlgorithm = "AWS4-HMAC-SHA256"
+	// presignDateFormat is the layout of the X-Amz-Date query parameter.
+	presignDateFormat = "20060102T150405Z"
+	// MaxPresignExpiry is the longest validity a presigned URL may carry.
+	MaxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// VerifyPresignedURL checks that u is a well-formed presigned URL and whether it has expired.
+// It parses X-Amz-Date and X-Amz-Expires to compute the expiry and checks the structure of
+// the X-Amz-Algorithm, X-Amz-Credential, X-Amz-SignedHeaders and X-Amz-Signature parameters.
+//
+// valid is true when the URL is well-formed and not expired; expired is true when it is
+// well-formed but past its expiry. A malformed URL returns an InvalidPresignedURLError.
+//
+// The signature itself is not verified: that requires the secret key, so a URL whose
+// parameters were tampered with but kept well-formed is still reported as valid. Use this
+// to reject stale or broken URLs early, not as a replacement for the backend's check.
+func VerifyPresignedURL(u *url.URL) (valid bool, expired bool, err error) {
+	if u == nil {
+		return false, false, &InvalidPresignedURLError{Message: "URL is nil"}
+	}
+
+	q := u.Query()
+	if algorithm := q.Get("X-Amz-Algorithm"); algorithm != presignAlgorithm {
+		return false, false, &InvalidPresignedURLError{Param: "X-Amz-Algorithm", Message: "must be " + presignAlgorithm}
+	}
+
+	// The credential scope is <access-key>/<date>/<region>/<service>/aws4_request.
+	credential := strings.Split(q.Get("X-Amz-Credential"), "/")
+	if len(credential) != 5 || credential[0] == "" || credential[4] != "aws4_request" {
+		return false, false, &InvalidPresignedURLError{Param: "X-Amz-Credential", Message: "is malformed"}
+	}
+
+	signedAt, err := time.Parse(presignDateFormat, q.Get("X-Amz-Date"))
+	if err != nil {
+		return false, false, &InvalidPresignedURLError{Param: "X-Amz-Date", Message: "is not a valid timestamp"}
+	}
+	if credential[1] != signedAt.Format("20060102") {
+		return false, false, &InvalidPresignedURLError{Param: "X-Amz-Credential", Message: "date does not match X-Amz-Date"}
+	}
+
+	seconds, err := strconv.ParseInt(q.Get("X-Amz-Expires"), 10, 64)
+	if err != nil || seconds <= 0 || time.Duration(seconds)*time.Second > MaxPresignExpiry {
+		return false, false, &InvalidPresignedURLError{Param: "X-Amz-Expires", Message: "must be between 1 second and 7 days"}
+	}
+
+	if q.Get("X-Amz-SignedHeaders") == "" {
+		return false, false, &InvalidPresignedURLError{Param: "X-Amz-SignedHeaders", Message: "is missing"}
+	}
+
+	signature := q.Get("X-Amz-Signature")
+	if _, err := hex.DecodeString(signature); err != nil || len(signature) != 64 {
+		return false, false, &InvalidPresignedURLError{Param: "X-Amz-Signature", Message: "must be 64 hex characters"}
+	}
+
+	if time.Now().After(signedAt.Add(time.Duration(seconds) * time.Second)) {
+		return false, true, nil
+	}
+	return true, false, nil
+}
+
+// presignedObject returns the bucket and key of a presigned URL for the endpoint of the
+// client, addressed either in path style or in virtual-hosted style.
+func (c *ObjectStorageClient) presignedObject(u *url.URL) (string, string, error) {
+	endpoint, err := url.Parse(c.endpoint.String())
+	if err != nil {
+		return "", "", err
+	}
+
+	host := strings.ToLower(u.Host)
+	endpointHost := strings.ToLower(endpoint.Host)
+	path := strings.TrimPrefix(u.Path, "/")
+
+	var bucketName, objectKey string
+	switch {
+	case host == endpointHost:
+		bucketName, objectKey, _ = strings.Cut(path, "/")
+	case strings.HasSuffix(host, "."+endpointHost):
+		bucketName, objectKey = strings.TrimSuffix(host, "."+endpointHost), path
+	default:
+		return "", "", &InvalidPresignedURLError{Message: fmt.Sprintf("host %s does not belong to endpoint %s", u.Host, c.endpoint)}
+	}
+
+	if bucketName == "" || objectKey == "" {
+		return "", "", &InvalidPresignedURLError{Message: "path does not name an object"}
+	}
+
+	return bucketName, objectKey, nil
+}
+
+// presignReqParams returns the request parameters signed into a presigned URL,
+// such as the response header overrides, without the signature parameters.
+func presignReqParams(query url.Values) url.Values {
+	params := url.Values{}
+	for name, values := range query {
+		if strings.HasPrefix(strings.ToLower(name), "x-amz-") {
+			continue
+		}
+		params[name] = values
+	}
+	return params
+}
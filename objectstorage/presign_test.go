@@ -0,0 +1,273 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+func presignedTestURL(signedAt time.Time, modify func(q url.Values)) *url.URL {
+	q := url.Values{}
+	q.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	q.Set("X-Amz-Credential", "minioadmin/"+signedAt.UTC().Format("20060102")+"/br-se1/s3/aws4_request")
+	q.Set("X-Amz-Date", signedAt.UTC().Format("20060102T150405Z"))
+	q.Set("X-Amz-Expires", "300")
+	q.Set("X-Amz-SignedHeaders", "host")
+	q.Set("X-Amz-Signature", strings.Repeat("ab", 32))
+	if modify != nil {
+		modify(q)
+	}
+	return &url.URL{Scheme: "https", Host: "br-se1.magaluobjects.com", Path: "/my-bucket/hello.txt", RawQuery: q.Encode()}
+}
+
+func TestVerifyPresignedURL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tests := []struct {
+		name        string
+		url         *url.URL
+		wantValid   bool
+		wantExpired bool
+		wantParam   string
+	}{
+		{name: "valid", url: presignedTestURL(now, nil), wantValid: true},
+		{name: "expired", url: presignedTestURL(now.Add(-time.Hour), nil), wantExpired: true},
+		{name: "nil URL", url: nil},
+		{
+			name:      "wrong algorithm",
+			url:       presignedTestURL(now, func(q url.Values) { q.Set("X-Amz-Algorithm", "HMAC-SHA1") }),
+			wantParam: "X-Amz-Algorithm",
+		},
+		{
+			name:      "malformed credential",
+			url:       presignedTestURL(now, func(q url.Values) { q.Set("X-Amz-Credential", "minioadmin") }),
+			wantParam: "X-Amz-Credential",
+		},
+		{
+			name:      "invalid date",
+			url:       presignedTestURL(now, func(q url.Values) { q.Set("X-Amz-Date", "yesterday") }),
+			wantParam: "X-Amz-Date",
+		},
+		{
+			name:      "credential date mismatch",
+			url:       presignedTestURL(now, func(q url.Values) { q.Set("X-Amz-Date", "20200101T000000Z") }),
+			wantParam: "X-Amz-Credential",
+		},
+		{
+			name:      "expiry too long",
+			url:       presignedTestURL(now, func(q url.Values) { q.Set("X-Amz-Expires", "604801") }),
+			wantParam: "X-Amz-Expires",
+		},
+		{
+			name:      "missing signed headers",
+			url:       presignedTestURL(now, func(q url.Values) { q.Del("X-Amz-SignedHeaders") }),
+			wantParam: "X-Amz-SignedHeaders",
+		},
+		{
+			name:      "truncated signature",
+			url:       presignedTestURL(now, func(q url.Values) { q.Set("X-Amz-Signature", "abcd") }),
+			wantParam: "X-Amz-Signature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			valid, expired, err := VerifyPresignedURL(tt.url)
+			if valid != tt.wantValid || expired != tt.wantExpired {
+				t.Errorf("VerifyPresignedURL() = (%v, %v), want (%v, %v)", valid, expired, tt.wantValid, tt.wantExpired)
+			}
+
+			wantErr := !tt.wantValid && !tt.wantExpired
+			if !wantErr {
+				if err != nil {
+					t.Errorf("VerifyPresignedURL() unexpected error: %v", err)
+				}
+				return
+			}
+
+			var urlErr *InvalidPresignedURLError
+			if !errors.As(err, &urlErr) || urlErr.Param != tt.wantParam {
+				t.Errorf("VerifyPresignedURL() error = %v, want InvalidPresignedURLError for %q", err, tt.wantParam)
+			}
+		})
+	}
+}
+
+func TestObjectServiceRefreshPresignedURL(t *testing.T) {
+	t.Parallel()
+
+	_, mock, osClient := newMockObjectService(t)
+	var gotMethod, gotBucket, gotKey string
+	var gotExpiry time.Duration
+	var gotParams url.Values
+	mock.presignedGetObjectFunc = func(ctx context.Context, bucketName, objectName string, expiry time.Duration, reqParams url.Values) (*url.URL, error) {
+		gotMethod, gotBucket, gotKey, gotExpiry, gotParams = http.MethodGet, bucketName, objectName, expiry, reqParams
+		return url.Parse("https://br-se1.magaluobjects.com/" + bucketName + "/" + objectName + "?X-Amz-Expires=3600")
+	}
+	mock.presignedPutObjectFunc = func(ctx context.Context, bucketName, objectName string, expiry time.Duration) (*url.URL, error) {
+		gotMethod, gotBucket, gotKey, gotExpiry, gotParams = http.MethodPut, bucketName, objectName, expiry, nil
+		return url.Parse("https://br-se1.magaluobjects.com/" + bucketName + "/" + objectName + "?X-Amz-Expires=3600")
+	}
+
+	tests := []struct {
+		name       string
+		url        *url.URL
+		method     string
+		wantMethod string
+		wantBucket string
+		wantKey    string
+		wantParams url.Values
+	}{
+		{
+			name:       "download",
+			url:        presignedTestURL(time.Now().Add(-time.Hour), func(q url.Values) { q.Set("response-content-disposition", "attachment") }),
+			method:     http.MethodGet,
+			wantMethod: http.MethodGet,
+			wantBucket: "my-bucket",
+			wantKey:    "hello.txt",
+			wantParams: url.Values{"response-content-disposition": {"attachment"}},
+		},
+		{
+			name:       "upload",
+			url:        presignedTestURL(time.Now(), nil),
+			method:     http.MethodPut,
+			wantMethod: http.MethodPut,
+			wantBucket: "my-bucket",
+			wantKey:    "hello.txt",
+		},
+		{
+			name: "virtual-hosted style",
+			url: func() *url.URL {
+				u := presignedTestURL(time.Now(), nil)
+				u.Host, u.Path = "photos.br-se1.magaluobjects.com", "/2024/beach.jpg"
+				return u
+			}(),
+			method:     http.MethodGet,
+			wantMethod: http.MethodGet,
+			wantBucket: "photos",
+			wantKey:    "2024/beach.jpg",
+			wantParams: url.Values{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := osClient.Objects().RefreshPresignedURL(context.Background(), tt.url, tt.method, time.Hour)
+			if err != nil {
+				t.Fatalf("RefreshPresignedURL() error = %v", err)
+			}
+			if got == nil {
+				t.Fatal("RefreshPresignedURL() returned nil URL")
+			}
+			if gotMethod != tt.wantMethod || gotBucket != tt.wantBucket || gotKey != tt.wantKey {
+				t.Errorf("presigned %s %s/%s, want %s %s/%s", gotMethod, gotBucket, gotKey, tt.wantMethod, tt.wantBucket, tt.wantKey)
+			}
+			if gotExpiry != time.Hour {
+				t.Errorf("expiry = %v, want %v", gotExpiry, time.Hour)
+			}
+			if !reflect.DeepEqual(gotParams, tt.wantParams) {
+				t.Errorf("reqParams = %v, want %v", gotParams, tt.wantParams)
+			}
+		})
+	}
+}
+
+func TestObjectServiceRefreshPresignedURL_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, _, osClient := newMockObjectService(t)
+
+	tests := []struct {
+		name    string
+		url     *url.URL
+		method  string
+		expiry  time.Duration
+		wantErr error
+	}{
+		{name: "not presigned", url: &url.URL{Scheme: "https", Host: "br-se1.magaluobjects.com", Path: "/my-bucket/hello.txt"}, expiry: time.Hour, wantErr: &InvalidPresignedURLError{}},
+		{
+			name: "other endpoint",
+			url: func() *url.URL {
+				u := presignedTestURL(time.Now(), nil)
+				u.Host = "br-ne1.magaluobjects.com"
+				return u
+			}(),
+			expiry:  time.Hour,
+			wantErr: &InvalidPresignedURLError{},
+		},
+		{
+			name: "bucket only",
+			url: func() *url.URL {
+				u := presignedTestURL(time.Now(), nil)
+				u.Path = "/my-bucket/"
+				return u
+			}(),
+			expiry:  time.Hour,
+			wantErr: &InvalidPresignedURLError{},
+		},
+		{name: "expiry too long", url: presignedTestURL(time.Now(), nil), expiry: MaxPresignExpiry + time.Second, wantErr: &client.ValidationError{}},
+		{name: "unknown method", url: presignedTestURL(time.Now(), nil), method: http.MethodDelete, expiry: time.Hour, wantErr: &client.ValidationError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			_, err := osClient.Objects().RefreshPresignedURL(context.Background(), tt.url, method, tt.expiry)
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("RefreshPresignedURL() error = %v, want %T", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestObjectServiceRefreshPresignedURL_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	server := newS3Server(t, "")
+	osClient, err := New(createMockCoreClient(), "minioadmin", "minioadmin", WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	expiry := 5 * time.Minute
+
+	for _, method := range []string{http.MethodGet, http.MethodPut} {
+		t.Run(method, func(t *testing.T) {
+			presigned, err := osClient.Objects().GetPresignedURL(context.Background(), "photos", "2024/beach.jpg", GetPresignedURLOptions{
+				Method:          method,
+				ExpiryInSeconds: &expiry,
+			})
+			if err != nil {
+				t.Fatalf("GetPresignedURL() error = %v", err)
+			}
+			old, _ := url.Parse(presigned.URL)
+
+			got, err := osClient.Objects().RefreshPresignedURL(context.Background(), old, method, time.Hour)
+			if err != nil {
+				t.Fatalf("RefreshPresignedURL() error = %v", err)
+			}
+
+			if got.Path != old.Path {
+				t.Errorf("path = %s, want %s", got.Path, old.Path)
+			}
+			if expires := got.Query().Get("X-Amz-Expires"); expires != "3600" {
+				t.Errorf("X-Amz-Expires = %s, want 3600", expires)
+			}
+			if old.Query().Has("x-id") || got.Query().Has("x-id") {
+				t.Errorf("presigned URLs carry an x-id parameter: %s, %s", old, got)
+			}
+		})
+	}
+}
@@ -2,16 +2,55 @@ package objectstorage
 
 import (
 	"net/http"
+
+	"github.com/MagaluCloud/mgc-sdk-go/client"
+	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
+)
+
+const (
+	// DefaultMaxIdleConns is the default limit of idle connections across all hosts.
+	DefaultMaxIdleConns = 256
+	// DefaultMaxIdleConnsPerHost is the default limit of idle connections to the endpoint.
+	// It is much higher than net/http's default of 2, since every request goes to the same host.
+	DefaultMaxIdleConnsPerHost = 128
 )
 
+// newTransport returns a copy of http.DefaultTransport with the connection pool tuned by opts.
+func newTransport(opts TransportOptions) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = DefaultMaxIdleConns
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+
+	if opts.MaxIdleConns > 0 {
+		transport.MaxIdleConns = opts.MaxIdleConns
+	}
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+	transport.MaxConnsPerHost = opts.MaxConnsPerHost
+
+	return transport
+}
+
+// forceDeleteTransport sets the request ID and default headers on every request and the
+// force delete header on deletes whose context was marked with WithForceDelete.
 type forceDeleteTransport struct {
-	base http.RoundTripper
+	base    http.RoundTripper
+	headers map[string]string
 }
 
 func (t *forceDeleteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID, ok := client.RequestIDFromContext(req.Context())
+	if !ok {
+		requestID = client.NewRequestID()
+	}
+	req.Header.Set(client.RequestIDHeader, requestID)
+
 	if req.Method == http.MethodDelete && HasForceDelete(req.Context()) {
 		req.Header.Set("X-Force-Container-Delete", "true")
 	}
 
+	mgc_http.SetDefaultHeaders(req, t.headers)
+
 	return t.base.RoundTrip(req)
 }
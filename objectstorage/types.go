@@ -1,6 +1,9 @@
 package objectstorage
 
-import "time"
+import (
+	"net/url"
+	"time"
+)
 
 // Bucket represents an object storage bucket.
 type Bucket struct {
@@ -17,6 +20,47 @@ type Object struct {
 	ContentType  string    `json:"content_type,omitempty"`
 }
 
+// ObjectMetadata represents the complete metadata of a stored object.
+type ObjectMetadata struct {
+	Key          string            `json:"key"`
+	Size         int64             `json:"size"`
+	ETag         string            `json:"etag,omitempty"`
+	ContentType  string            `json:"content_type,omitempty"`
+	LastModified time.Time         `json:"last_modified"`
+	UserMetadata map[string]string `json:"user_metadata,omitempty"`
+	VersionID    string            `json:"version_id,omitempty"`
+}
+
+// BucketUsage summarizes the storage used by the objects of a bucket.
+// Prefixes breaks the totals down by top-level prefix ("folder"); objects
+// that are not inside a folder are accounted under the empty key.
+type BucketUsage struct {
+	TotalSize   int64                  `json:"total_size"`
+	ObjectCount int64                  `json:"object_count"`
+	Prefixes    map[string]PrefixUsage `json:"prefixes"`
+}
+
+// NotificationEvent describes an event that occurred on an object of a bucket,
+// such as s3:ObjectCreated:Put or s3:ObjectRemoved:Delete.
+type NotificationEvent struct {
+	EventName   string    `json:"event_name"`
+	EventTime   time.Time `json:"event_time"`
+	Bucket      string    `json:"bucket"`
+	Key         string    `json:"key"`
+	Size        int64     `json:"size,omitempty"`
+	ETag        string    `json:"etag,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	VersionID   string    `json:"version_id,omitempty"`
+	// Err is set on the last event sent before the stream stops because of a failure.
+	Err error `json:"-"`
+}
+
+// PrefixUsage summarizes the storage used by the objects under a prefix.
+type PrefixUsage struct {
+	TotalSize   int64 `json:"total_size"`
+	ObjectCount int64 `json:"object_count"`
+}
+
 // BucketListOptions defines parameters for filtering and pagination of bucket lists.
 type BucketListOptions struct {
 	Limit  *int `json:"_limit,omitempty"`
@@ -24,26 +68,44 @@ type BucketListOptions struct {
 }
 
 // ObjectListOptions defines parameters for filtering and pagination of object lists.
+// The time and size filters are applied client-side, since listings cannot be
+// filtered by them on the server. Sizes are in bytes.
 type ObjectListOptions struct {
-	Limit     *int   `json:"_limit,omitempty"`
-	Offset    *int   `json:"_offset,omitempty"`
-	Prefix    string `json:"prefix,omitempty"`
-	Delimiter string `json:"delimiter,omitempty"`
+	Limit          *int       `json:"_limit,omitempty"`
+	Offset         *int       `json:"_offset,omitempty"`
+	Prefix         string     `json:"prefix,omitempty"`
+	Delimiter      string     `json:"delimiter,omitempty"`
+	ModifiedAfter  *time.Time `json:"modified_after,omitempty"`
+	ModifiedBefore *time.Time `json:"modified_before,omitempty"`
+	MinSize        *int64     `json:"min_size,omitempty"`
+	MaxSize        *int64     `json:"max_size,omitempty"`
 }
 
 // ObjectFilterOptions defines filtering options for ListAll (without pagination).
 type ObjectFilterOptions struct {
-	Prefix    string `json:"prefix,omitempty"`
-	Delimiter string `json:"delimiter,omitempty"`
+	Prefix         string     `json:"prefix,omitempty"`
+	Delimiter      string     `json:"delimiter,omitempty"`
+	ModifiedAfter  *time.Time `json:"modified_after,omitempty"`
+	ModifiedBefore *time.Time `json:"modified_before,omitempty"`
+	MinSize        *int64     `json:"min_size,omitempty"`
+	MaxSize        *int64     `json:"max_size,omitempty"`
 }
 
 // Statement represents a single statement in an S3 bucket policy.
 type Statement struct {
-	Sid       string `json:"Sid,omitempty"`
-	Effect    string `json:"Effect"`
-	Principal any    `json:"Principal"`
-	Action    any    `json:"Action"`
-	Resource  any    `json:"Resource"`
+	Sid          string `json:"Sid,omitempty"`
+	Effect       string `json:"Effect"`
+	Principal    any    `json:"Principal,omitempty"`
+	NotPrincipal any    `json:"NotPrincipal,omitempty"`
+	Action       any    `json:"Action"`
+	Resource     any    `json:"Resource"`
+}
+
+// PublicOptions defines parameters for making a bucket publicly readable.
+type PublicOptions struct {
+	// Prefix limits public read access to the objects under it, such as "assets/".
+	// The whole bucket is public when empty.
+	Prefix string `json:"prefix,omitempty"`
 }
 
 // Policy represents an S3 bucket policy with version and statements.
@@ -94,6 +156,99 @@ type ObjectVersion struct {
 	ETag           string    `json:"etag,omitempty"`
 }
 
+// IncompleteUpload is a multipart upload that was started but neither completed nor aborted.
+// Its parts are stored, and billed, until the upload is aborted.
+type IncompleteUpload struct {
+	Key          string    `json:"key"`
+	UploadID     string    `json:"upload_id"`
+	Initiated    time.Time `json:"initiated"`
+	StorageClass string    `json:"storage_class,omitempty"`
+	Size         int64     `json:"size"`
+}
+
+// PrefixRemovalPreview lists the objects a RemovePrefix call would delete.
+type PrefixRemovalPreview struct {
+	Keys      []string `json:"keys"`
+	TotalSize int64    `json:"total_size"`
+}
+
+// StreamOptions defines optional parameters for uploading objects of unknown size.
+type StreamOptions struct {
+	// ContentType of the object. When empty, it is detected from the key extension
+	// or the leading bytes of the data.
+	ContentType string `json:"content_type,omitempty"`
+	// PartSize is the size in bytes of each multipart part, DefaultStreamPartSize when zero.
+	// It must be at least MinPartSize. One part is buffered in memory at a time, and
+	// an object can have at most MaxPartCount parts, which bounds its total size.
+	PartSize uint64 `json:"part_size,omitempty"`
+	// UserMetadata is stored with the object as x-amz-meta-* headers and returned by Stat.
+	// Keys may include the x-amz-meta- prefix, must be valid HTTP header names, and cannot
+	// be other S3 or standard headers such as x-amz-acl or Content-Type.
+	UserMetadata map[string]string `json:"user_metadata,omitempty"`
+	// CacheControl is returned as the Cache-Control header when the object is served,
+	// such as "public, max-age=3600". It must be a list of directives.
+	CacheControl string `json:"cache_control,omitempty"`
+	// ContentDisposition is returned as the Content-Disposition header when the object is
+	// served, such as `attachment; filename="report.csv"` to force browsers to download it.
+	ContentDisposition string `json:"content_disposition,omitempty"`
+}
+
+// UploadOptions tunes how uploads of known size are split into parts, and whether the
+// server verifies the integrity of every upload.
+type UploadOptions struct {
+	// MultipartThreshold is the object size in bytes from which uploads switch from a single
+	// request to a multipart upload, DefaultMultipartThreshold when zero. It cannot exceed
+	// MaxSinglePutSize.
+	MultipartThreshold int64 `json:"multipart_threshold,omitempty"`
+	// PartSize is the size in bytes of each multipart part. When zero, it is derived from
+	// the object size. It must be at least MinPartSize, and it is also the default part size
+	// of UploadFromReader.
+	PartSize int64 `json:"part_size,omitempty"`
+	// SendContentMD5 sends the base64 MD5 of each request body in the Content-MD5 header,
+	// so the server rejects corrupted uploads instead of storing them. Hashing reads every
+	// byte an extra time before it is sent: single-request uploads from readers that cannot
+	// seek are buffered whole in memory, and multipart parts are no longer sent in parallel.
+	SendContentMD5 bool `json:"send_content_md5,omitempty"`
+}
+
+// TransportOptions tunes the connection pool of the HTTP transport used for object storage.
+// Zero fields use the defaults, which keep many idle connections to the single endpoint host.
+type TransportOptions struct {
+	// MaxIdleConns limits idle connections across all hosts, DefaultMaxIdleConns when zero.
+	MaxIdleConns int `json:"max_idle_conns,omitempty"`
+	// MaxIdleConnsPerHost limits idle connections to the endpoint, DefaultMaxIdleConnsPerHost when zero.
+	MaxIdleConnsPerHost int `json:"max_idle_conns_per_host,omitempty"`
+	// MaxConnsPerHost limits connections to the endpoint, including active ones. Zero means no limit.
+	MaxConnsPerHost int `json:"max_conns_per_host,omitempty"`
+}
+
+// RetentionMode is the retention mode of a locked object.
+type RetentionMode string
+
+const (
+	// RetentionModeCompliance prevents any user from deleting the object until the retention expires.
+	RetentionModeCompliance RetentionMode = "COMPLIANCE"
+	// RetentionModeGovernance allows users with special permissions to bypass the retention.
+	RetentionModeGovernance RetentionMode = "GOVERNANCE"
+)
+
+// RetentionInfo describes the retention lock of an object.
+type RetentionInfo struct {
+	Mode        RetentionMode `json:"mode"`
+	RetainUntil time.Time     `json:"retain_until"`
+	// Remaining is the time left until RetainUntil when the retention was read, or zero once it passed.
+	Remaining time.Duration `json:"remaining"`
+}
+
+// UploadInfo describes an uploaded object.
+type UploadInfo struct {
+	Bucket    string `json:"bucket"`
+	Key       string `json:"key"`
+	ETag      string `json:"etag"`
+	Size      int64  `json:"size"`
+	VersionID string `json:"version_id,omitempty"`
+}
+
 // DownloadOptions defines optional parameters for downloading objects.
 type DownloadOptions struct {
 	VersionID string `json:"version_id,omitempty"`
@@ -107,6 +262,61 @@ type DownloadStreamOptions struct {
 // DeleteOptions defines optional parameters for deleting objects.
 type DeleteOptions struct {
 	VersionID string `json:"version_id,omitempty"`
+	// BypassGovernance deletes objects under a GOVERNANCE retention, which requires the
+	// s3:BypassGovernanceRetention permission. COMPLIANCE retentions and legal holds cannot be bypassed.
+	BypassGovernance bool `json:"bypass_governance,omitempty"`
+	// IfUnmodifiedSince, when set, keeps the object if it was modified after this time,
+	// returning an ObjectModifiedError. It is checked right before the removal, which
+	// narrows but does not close the window for concurrent writes.
+	IfUnmodifiedSince time.Time `json:"if_unmodified_since,omitzero"`
+}
+
+// MetadataDirective tells a copy whether the destination keeps the source metadata
+// or gets a new metadata set.
+type MetadataDirective string
+
+const (
+	// MetadataDirectiveCopy keeps the metadata of the source. It is the default.
+	MetadataDirectiveCopy MetadataDirective = "COPY"
+	// MetadataDirectiveReplace replaces the metadata of the source with CopyOptions.UserMetadata.
+	MetadataDirectiveReplace MetadataDirective = "REPLACE"
+)
+
+// CopyOptions defines parameters for copying an object.
+// The zero value copies the latest version and keeps its metadata.
+type CopyOptions struct {
+	// SourceVersionID copies a specific version of the source object.
+	SourceVersionID string `json:"source_version_id,omitempty"`
+	// MetadataDirective selects between keeping the source metadata (COPY, the default)
+	// and replacing it (REPLACE).
+	MetadataDirective MetadataDirective `json:"metadata_directive,omitempty"`
+	// UserMetadata is the full metadata set of the destination. It is required with
+	// REPLACE, even if empty, and cannot be set with COPY, which would ignore it.
+	UserMetadata map[string]string `json:"user_metadata,omitempty"`
+}
+
+// ComposeSource references an object, or a byte range of it, concatenated by Compose.
+type ComposeSource struct {
+	Bucket    string `json:"bucket"`
+	Key       string `json:"key"`
+	VersionID string `json:"version_id,omitempty"`
+	// Range, when set, uses only these bytes of the object.
+	Range *ByteRange `json:"range,omitempty"`
+}
+
+// ByteRange selects the bytes from Start to End of an object, both inclusive.
+type ByteRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// MoveOptions defines parameters for moving an object.
+// The zero value moves the latest version and keeps its metadata and tags.
+type MoveOptions struct {
+	// SourceVersionID moves a specific version of the source object.
+	SourceVersionID string `json:"source_version_id,omitempty"`
+	// UserMetadata, when set, replaces the user metadata of the source on the destination.
+	UserMetadata map[string]string `json:"user_metadata,omitempty"`
 }
 
 // ListVersionsOptions defines parameters for listing object versions.
@@ -118,6 +328,13 @@ type ListVersionsOptions struct {
 type GetPresignedURLOptions struct {
 	Method          string         `json:"method,omitempty"`
 	ExpiryInSeconds *time.Duration `json:"expiry_in_seconds,omitempty"`
+	// ReqParams are response header overrides (e.g. response-content-disposition)
+	// signed into GET URLs, replacing the client's WithDefaultPresignParams of the
+	// same name. They are ignored for PUT.
+	ReqParams url.Values `json:"-"`
+	// Concurrency bounds how many URLs GetPresignedURLs signs at once, GOMAXPROCS when zero
+	// or negative, since signing is CPU-bound. It is ignored by GetPresignedURL.
+	Concurrency int `json:"-"`
 }
 
 type PresignedURL struct {
@@ -2,14 +2,30 @@ package sshkeys
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/MagaluCloud/mgc-sdk-go/client"
 	mgc_http "github.com/MagaluCloud/mgc-sdk-go/internal/http"
 )
 
+// publicKeyTypes are the SSH public key algorithms accepted by Create.
+var publicKeyTypes = []string{
+	"ssh-rsa",
+	"ssh-ed25519",
+	"ecdsa-sha2-nistp256",
+	"ecdsa-sha2-nistp384",
+	"ecdsa-sha2-nistp521",
+	"sk-ssh-ed25519@openssh.com",
+	"sk-ecdsa-sha2-nistp256@openssh.com",
+}
+
 type (
 	// ListSSHKeysResponse represents a list of SSH keys response
 	ListSSHKeysResponse struct {
@@ -82,8 +98,17 @@ func (s *keyService) List(ctx context.Context, opts ListOptions) ([]SSHKey, erro
 	return result.Results, nil
 }
 
-// Create registers a new SSH key globally
+// Create registers a new SSH key globally.
+// The key must be an OpenSSH public key, such as the contents of ~/.ssh/id_ed25519.pub;
+// malformed keys are rejected with a client.ValidationError before calling the API.
 func (s *keyService) Create(ctx context.Context, req CreateSSHKeyRequest) (*SSHKey, error) {
+	if req.Name == "" {
+		return nil, &client.ValidationError{Field: "name", Message: "cannot be empty"}
+	}
+	if err := ValidatePublicKey(req.Key); err != nil {
+		return nil, err
+	}
+
 	return mgc_http.ExecuteSimpleRequestWithRespBody[SSHKey](
 		ctx,
 		s.client.newRequest,
@@ -120,3 +145,30 @@ func (s *keyService) Delete(ctx context.Context, keyID string) (*SSHKey, error)
 		nil,
 	)
 }
+
+// ValidatePublicKey checks that key is a well-formed OpenSSH public key in the
+// "<type> <base64 data> [comment]" format, with a supported type matching its data.
+func ValidatePublicKey(key string) error {
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return &client.ValidationError{Field: "key", Message: `must be in the format "<type> <base64 data> [comment]"`}
+	}
+
+	keyType := fields[0]
+	if !slices.Contains(publicKeyTypes, keyType) {
+		return &client.ValidationError{Field: "key", Message: fmt.Sprintf("unsupported key type %q", keyType)}
+	}
+
+	data, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return &client.ValidationError{Field: "key", Message: "key data is not valid base64"}
+	}
+
+	// The key data starts with its type as a length-prefixed string.
+	if len(data) < 4 || int(binary.BigEndian.Uint32(data)) != len(keyType) ||
+		len(data) < 4+len(keyType) || string(data[4:4+len(keyType)]) != keyType {
+		return &client.ValidationError{Field: "key", Message: fmt.Sprintf("key data does not match key type %q", keyType)}
+	}
+
+	return nil
+}
@@ -3,11 +3,13 @@ package sshkeys
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -101,7 +103,7 @@ func TestKeyService(t *testing.T) {
 		t.Run("successful creation", func(t *testing.T) {
 			key, err := service.Create(context.Background(), CreateSSHKeyRequest{
 				Name: "new-key",
-				Key:  "ssh-rsa...",
+				Key:  testPublicKey,
 			})
 
 			if err != nil {
@@ -311,3 +313,63 @@ func handleCreateKey(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(response)
 }
+
+const testPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl user@host"
+
+func TestValidatePublicKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		wantMessage string
+	}{
+		{name: "valid", key: testPublicKey},
+		{name: "without comment", key: strings.TrimSuffix(testPublicKey, " user@host")},
+		{name: "empty", key: "", wantMessage: `must be in the format "<type> <base64 data> [comment]"`},
+		{name: "missing data", key: "ssh-ed25519", wantMessage: `must be in the format "<type> <base64 data> [comment]"`},
+		{name: "unsupported type", key: "ssh-dss AAAA", wantMessage: `unsupported key type "ssh-dss"`},
+		{name: "invalid base64", key: "ssh-ed25519 not*base64", wantMessage: "key data is not valid base64"},
+		{
+			name:        "type mismatch",
+			key:         strings.Replace(testPublicKey, "ssh-ed25519", "ssh-rsa", 1),
+			wantMessage: `key data does not match key type "ssh-rsa"`,
+		},
+		{name: "truncated data", key: "ssh-rsa AAAA", wantMessage: `key data does not match key type "ssh-rsa"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePublicKey(tt.key)
+			if tt.wantMessage == "" {
+				if err != nil {
+					t.Errorf("ValidatePublicKey() error = %v", err)
+				}
+				return
+			}
+
+			var validationErr *client.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != "key" || validationErr.Message != tt.wantMessage {
+				t.Errorf("ValidatePublicKey() error = %v, want %q", err, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestKeyService_CreateValidatesKey(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer ts.Close()
+
+	service := New(client.NewMgcClient(client.WithAPIKey("test-api-key")), WithGlobalBasePath(client.MgcUrl(ts.URL))).Keys()
+
+	for _, req := range []CreateSSHKeyRequest{
+		{Name: "", Key: testPublicKey},
+		{Name: "new-key", Key: "ssh-rsa..."},
+	} {
+		_, err := service.Create(context.Background(), req)
+		var validationErr *client.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Create(%+v) error = %v, want ValidationError", req, err)
+		}
+	}
+}